@@ -0,0 +1,12 @@
+package authclient
+
+import "github.com/Bengo-Hub/shared-auth-client/authapi"
+
+// API returns the generated low-level client for auth-service, sharing this client's base URL
+// and HTTP transport (timeouts, reachability tracking, debug logging). Use it for endpoints
+// the high-level methods do not wrap yet; responses come back undecorated, without AuthError
+// classification.
+func (c *Client) API() *authapi.ClientWithResponses {
+	api, _ := authapi.NewClientWithResponses(c.baseURL, authapi.WithHTTPClient(c.httpClient))
+	return api
+}
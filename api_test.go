@@ -0,0 +1,28 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientAPIUsesGeneratedEndpoints(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/tenants/by-slug/acme" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"t-1","slug":"acme","name":"Acme","status":"active"}`))
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(srv.URL, nil).API().GetTenantBySlugWithResponse(context.Background(), "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.JSON200 == nil || resp.JSON200.Id != "t-1" || resp.JSON200.Status != "active" {
+		t.Fatalf("JSON200 = %+v (status %d)", resp.JSON200, resp.StatusCode())
+	}
+}
@@ -0,0 +1,167 @@
+package authclient
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAPIVersion is the auth-service API revision this release of authclient is built
+// against. It is sent on every Client request unless overridden with WithAPIVersion.
+const DefaultAPIVersion = "1"
+
+// Version negotiation headers. Requests carry both spellings of the requested version; the
+// response echoes the version auth-service served in APIVersionHeader.
+const (
+	APIVersionHeader    = "X-API-Version"
+	AcceptVersionHeader = "Accept-Version"
+)
+
+// DeprecationNotice describes an auth-service response that announced its endpoint is
+// deprecated (RFC 9745 Deprecation header) or will be removed (RFC 8594 Sunset header).
+type DeprecationNotice struct {
+	Method       string
+	Path         string
+	Version      string    // version auth-service served, from APIVersionHeader
+	DeprecatedAt time.Time // zero when auth-service only flagged the endpoint as deprecated
+	Sunset       time.Time // zero when no removal date was announced
+	Link         string    // migration guide, from a Link header with rel="deprecation" or "sunset"
+}
+
+// DeprecationHook receives deprecation notices; see WithDeprecationHook.
+type DeprecationHook func(DeprecationNotice)
+
+// WithAPIVersion requests a specific auth-service API version instead of DefaultAPIVersion.
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+// WithDeprecationHook calls hook the first time each endpoint reports a deprecation or
+// sunset, e.g. to raise an alert or count usage. Notices are also logged at Warn level.
+func WithDeprecationHook(hook DeprecationHook) ClientOption {
+	return func(c *Client) {
+		c.onDeprecation = hook
+	}
+}
+
+// maxDeprecationEndpoints bounds the endpoints apiVersionTransport remembers, since paths
+// may contain IDs. Past it, notices are reported again rather than growing without bound.
+const maxDeprecationEndpoints = 1024
+
+// apiVersionTransport sends the requested API version and reports deprecation headers.
+type apiVersionTransport struct {
+	base    http.RoundTripper
+	version string
+	logger  Logger
+	hook    DeprecationHook
+
+	mu   sync.Mutex
+	seen map[string]struct{} // "METHOD path" already reported
+}
+
+func (t *apiVersionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.version != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(APIVersionHeader, t.version)
+		req.Header.Set(AcceptVersionHeader, t.version)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if notice, ok := parseDeprecation(resp.Header); ok && t.firstReport(req.Method+" "+req.URL.Path) {
+		notice.Method, notice.Path = req.Method, req.URL.Path
+		t.report(notice)
+	}
+	return resp, nil
+}
+
+func (t *apiVersionTransport) firstReport(endpoint string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.seen[endpoint]; ok {
+		return false
+	}
+	if t.seen == nil {
+		t.seen = make(map[string]struct{})
+	}
+	if len(t.seen) < maxDeprecationEndpoints {
+		t.seen[endpoint] = struct{}{}
+	}
+	return true
+}
+
+func (t *apiVersionTransport) report(n DeprecationNotice) {
+	args := []any{"method", n.Method, "path", n.Path}
+	if n.Version != "" {
+		args = append(args, "version", n.Version)
+	}
+	if !n.DeprecatedAt.IsZero() {
+		args = append(args, "deprecated_at", n.DeprecatedAt.Format(time.RFC3339))
+	}
+	if !n.Sunset.IsZero() {
+		args = append(args, "sunset", n.Sunset.Format(time.RFC3339))
+	}
+	if n.Link != "" {
+		args = append(args, "link", n.Link)
+	}
+	t.logger.Warn("auth-service: deprecated API in use", args...)
+	if t.hook != nil {
+		t.hook(n)
+	}
+}
+
+// parseDeprecation extracts a DeprecationNotice from response headers. It accepts the RFC
+// 9745 "@<unix seconds>" form of Deprecation as well as the older "true" and HTTP-date forms.
+func parseDeprecation(h http.Header) (DeprecationNotice, bool) {
+	deprecation, sunset := strings.TrimSpace(h.Get("Deprecation")), strings.TrimSpace(h.Get("Sunset"))
+	if deprecation == "" && sunset == "" {
+		return DeprecationNotice{}, false
+	}
+	n := DeprecationNotice{Version: h.Get(APIVersionHeader)}
+	switch {
+	case strings.HasPrefix(deprecation, "@"):
+		if secs, err := strconv.ParseInt(deprecation[1:], 10, 64); err == nil {
+			n.DeprecatedAt = time.Unix(secs, 0).UTC()
+		}
+	case deprecation != "" && deprecation != "true":
+		if t, err := http.ParseTime(deprecation); err == nil {
+			n.DeprecatedAt = t
+		}
+	}
+	if sunset != "" {
+		if t, err := http.ParseTime(sunset); err == nil {
+			n.Sunset = t
+		}
+	}
+	n.Link = deprecationLink(h.Values("Link"))
+	return n, true
+}
+
+// deprecationLink returns the target of the first Link with rel="deprecation" or "sunset".
+func deprecationLink(values []string) string {
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(val, `"`)) {
+					if strings.EqualFold(rel, "deprecation") || strings.EqualFold(rel, "sunset") {
+						return strings.Trim(strings.TrimSpace(target), "<>")
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		ok      bool
+		want    DeprecationNotice
+	}{
+		{"none", nil, false, DeprecationNotice{}},
+		{"rfc 9745", map[string]string{"Deprecation": "@1767225600"}, true,
+			DeprecationNotice{DeprecatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{"boolean", map[string]string{"Deprecation": "true"}, true, DeprecationNotice{}},
+		{"sunset with link", map[string]string{
+			"Sunset":         "Wed, 01 Jul 2026 00:00:00 GMT",
+			"Link":           `<https://docs.example/next>; rel="alternate", <https://docs.example/migrate>; rel="sunset"`,
+			APIVersionHeader: "1",
+		}, true, DeprecationNotice{Version: "1", Sunset: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), Link: "https://docs.example/migrate"}},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		for k, v := range tt.headers {
+			h.Set(k, v)
+		}
+		got, ok := parseDeprecation(h)
+		if ok != tt.ok || got.Version != tt.want.Version || !got.DeprecatedAt.Equal(tt.want.DeprecatedAt) ||
+			!got.Sunset.Equal(tt.want.Sunset) || got.Link != tt.want.Link {
+			t.Errorf("%s: parseDeprecation = %+v, %v; want %+v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestClientAPIVersion(t *testing.T) {
+	var sent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = r.Header.Get(APIVersionHeader)
+		w.Header().Set("Deprecation", "true")
+		w.Write([]byte(`{"access_token":"at"}`))
+	}))
+	defer srv.Close()
+
+	var notices []DeprecationNotice
+	client := NewClient(srv.URL, nil, WithAPIVersion("2"), WithDeprecationHook(func(n DeprecationNotice) {
+		notices = append(notices, n)
+	}))
+	for range 3 {
+		if _, err := client.Login(context.Background(), LoginRequest{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sent != "2" {
+		t.Errorf("%s = %q, want 2", APIVersionHeader, sent)
+	}
+	if len(notices) != 1 || notices[0].Path != "/api/v1/auth/login" || notices[0].Method != http.MethodPost {
+		t.Errorf("notices = %+v, want one for POST /api/v1/auth/login", notices)
+	}
+}
@@ -2,66 +2,159 @@ package authclient
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultAPIKeyHeader is the request header carrying API keys.
+	DefaultAPIKeyHeader = "X-API-Key"
+	// DefaultAPIKeyValidatePath is the auth-service endpoint used to validate API keys.
+	DefaultAPIKeyValidatePath = "/api/v1/admin/api-keys/validate"
 )
 
+// errSignatureMismatch is the cause reported for offline-signed keys that fail verification.
+var errSignatureMismatch = errors.New("signature mismatch")
+
 // APIKeyValidator validates API keys by checking them against auth-service.
 // Supports both service-to-service authentication and external API access.
 type APIKeyValidator struct {
 	authServiceURL string
 	httpClient     *http.Client
-	cache          map[string]*apiKeyInfo
+	cache          *apiKeyCache
 	cacheTTL       time.Duration
+	maxEntries     int
+	refreshAhead   time.Duration
+	refreshGroup   singleflight.Group
+	validatePath   string
+	headerName     string
+	logger         Logger
+	onError        ErrorHook
+	audit          AuditSink
+
+	batchConcurrency int
+	prefixBackends   []prefixBackend
+	protobuf         *protobufState     // see WithAPIKeyProtobuf
+	decodeMode       DecodeMode         // see WithAPIKeyDecodeMode
+	credentials      CredentialProvider // see WithAPIKeyCredentials
+
+	// Offline verification material for bk_<keyid>.<secret> keys (see apikey_offline.go).
+	signingMu   sync.RWMutex
+	signingKeys [][]byte
+	offlineMeta map[string]APIKeyValidationResult // key fingerprint -> metadata; see LoadSigningMaterial
 }
 
-type apiKeyInfo struct {
-	clientID             string
-	tenantID             string
-	tenantSlug           string
-	scopes               []string
-	roles                []string
-	service              string
-	subscriptionPlan     string
-	subscriptionFeatures []string
-	subscriptionLimits   map[string]int
-	subscriptionStatus   string
-	expiresAt            time.Time
+// prefixBackend routes keys with a given prefix to a specific auth-service instance.
+type prefixBackend struct {
+	prefix string
+	url    string
+}
+
+// cachedAPIKey is a validation result held in the LRU cache.
+type cachedAPIKey struct {
+	result    APIKeyValidationResult
+	expiresAt time.Time
+	refreshAt time.Time // after this, hits trigger a background revalidation
 }
 
 // APIKeyValidationResult contains the full result of API key validation.
 type APIKeyValidationResult struct {
-	ClientID             string         `json:"client_id"`
-	TenantID             string         `json:"tenant_id"`
-	TenantSlug           string         `json:"tenant_slug"`
-	Scopes               []string       `json:"scopes"`
-	Roles                []string       `json:"roles"`
-	Service              string         `json:"service"`
-	SubscriptionPlan     string         `json:"subscription_plan"`
-	SubscriptionFeatures []string       `json:"subscription_features"`
-	SubscriptionLimits   map[string]int `json:"subscription_limits"`
-	SubscriptionStatus   string         `json:"subscription_status"`
+	ClientID             string           `json:"client_id"`
+	TenantID             string           `json:"tenant_id"`
+	TenantSlug           string           `json:"tenant_slug"`
+	Scopes               []string         `json:"scopes"`
+	Roles                []string         `json:"roles"`
+	Service              string           `json:"service"`
+	SubscriptionPlan     string           `json:"subscription_plan"`
+	SubscriptionFeatures []string         `json:"subscription_features"`
+	SubscriptionLimits   map[string]int   `json:"subscription_limits"`
+	SubscriptionStatus   string           `json:"subscription_status"`
+	ExpiresAt            *time.Time       `json:"expires_at,omitempty"` // key expiry; nil = never expires
+	RateLimit            *APIKeyRateLimit `json:"rate_limit,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modelled above; see DecodeMode
+}
+
+// APIKeyRateLimit describes the request budget auth-service attached to an API key.
+type APIKeyRateLimit struct {
+	RequestsPerMinute int `json:"requests_per_minute"`
+	Burst             int `json:"burst,omitempty"`
+}
+
+// APIKeyInfo is the stable, struct-based view of a validated API key returned by
+// ValidateAPIKeyInfo. New fields are added here rather than to positional return values.
+type APIKeyInfo struct {
+	ClientID   string
+	TenantID   string
+	TenantSlug string
+	Scopes     []string
+	Roles      []string
+	Service    string
+	ExpiresAt  *time.Time // key expiry; nil = never expires
+	RateLimit  *APIKeyRateLimit
 }
 
 // NewAPIKeyValidator creates a new API key validator.
-func NewAPIKeyValidator(authServiceURL string, httpClient *http.Client) *APIKeyValidator {
+func NewAPIKeyValidator(authServiceURL string, httpClient *http.Client, opts ...APIKeyValidatorOption) *APIKeyValidator {
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: 10 * time.Second}
 	}
-	return &APIKeyValidator{
+	v := &APIKeyValidator{
 		authServiceURL: strings.TrimSuffix(authServiceURL, "/"),
 		httpClient:     httpClient,
-		cache:          make(map[string]*apiKeyInfo),
 		cacheTTL:       5 * time.Minute,
+		maxEntries:     DefaultAPIKeyCacheMaxEntries,
+		refreshAhead:   time.Minute,
+		validatePath:   DefaultAPIKeyValidatePath,
+		headerName:     DefaultAPIKeyHeader,
+		logger:         NopLogger(),
+
+		batchConcurrency: DefaultAPIKeyBatchConcurrency,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	if v.refreshAhead >= v.cacheTTL {
+		// A window as long as the TTL would revalidate on every hit.
+		v.refreshAhead = v.cacheTTL / 5
 	}
+	v.cache = newAPIKeyCache(v.maxEntries)
+	return v
+}
+
+// Fingerprint returns the hex SHA-256 of an API key. The cache is keyed by fingerprint so
+// plaintext keys never stay resident in memory; use it whenever a key must be logged or
+// correlated across services.
+func Fingerprint(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// HeaderName returns the request header this validator reads API keys from.
+func (v *APIKeyValidator) HeaderName() string {
+	return v.headerName
+}
+
+// CacheStats returns a snapshot of the API key cache counters (size, hits, misses, evictions).
+func (v *APIKeyValidator) CacheStats() APIKeyCacheStats {
+	return v.cache.stats()
 }
 
 // ValidateAPIKey validates an API key by checking it against auth-service.
 // Returns client_id, tenant_id, scopes, and service if valid.
-// Deprecated: Use ValidateAPIKeyFull for complete subscription data.
+//
+// Deprecated: Use ValidateAPIKeyInfo, or ValidateAPIKeyFull for complete subscription data.
 func (v *APIKeyValidator) ValidateAPIKey(ctx context.Context, apiKey string) (clientID, tenantID string, scopes []string, service string, err error) {
 	result, err := v.ValidateAPIKeyFull(ctx, apiKey)
 	if err != nil {
@@ -70,68 +163,169 @@ func (v *APIKeyValidator) ValidateAPIKey(ctx context.Context, apiKey string) (cl
 	return result.ClientID, result.TenantID, result.Scopes, result.Service, nil
 }
 
+// ValidateAPIKeyInfo validates an API key and returns its identity, scopes, expiry and
+// rate-limit information as a struct.
+func (v *APIKeyValidator) ValidateAPIKeyInfo(ctx context.Context, apiKey string) (*APIKeyInfo, error) {
+	result, err := v.ValidateAPIKeyFull(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	return &APIKeyInfo{
+		ClientID:   result.ClientID,
+		TenantID:   result.TenantID,
+		TenantSlug: result.TenantSlug,
+		Scopes:     result.Scopes,
+		Roles:      result.Roles,
+		Service:    result.Service,
+		ExpiresAt:  result.ExpiresAt,
+		RateLimit:  result.RateLimit,
+	}, nil
+}
+
 // ValidateAPIKeyFull validates an API key and returns complete information including subscription data.
 func (v *APIKeyValidator) ValidateAPIKeyFull(ctx context.Context, apiKey string) (*APIKeyValidationResult, error) {
-	// Check cache first
-	if info, ok := v.cache[apiKey]; ok {
-		if time.Now().Before(info.expiresAt) {
-			return &APIKeyValidationResult{
-				ClientID:             info.clientID,
-				TenantID:             info.tenantID,
-				TenantSlug:           info.tenantSlug,
-				Scopes:               info.scopes,
-				Roles:                info.roles,
-				Service:              info.service,
-				SubscriptionPlan:     info.subscriptionPlan,
-				SubscriptionFeatures: info.subscriptionFeatures,
-				SubscriptionLimits:   info.subscriptionLimits,
-				SubscriptionStatus:   info.subscriptionStatus,
-			}, nil
+	// Signed keys are checked locally first so forged keys never reach auth-service, and
+	// verified keys with loaded metadata need no network hop at all.
+	fingerprint := Fingerprint(apiKey)
+	now := time.Now()
+	if result, err := v.checkOffline(apiKey, fingerprint, now); result != nil || err != nil {
+		return result, err
+	}
+
+	// Check cache next (expired entries are dropped by the cache itself)
+	if cached, ok := v.cache.get(fingerprint, now); ok {
+		// Hot entries inside the refresh-ahead window are revalidated in the background so
+		// callers never wait on auth-service while the entry is still servable.
+		if v.refreshAhead > 0 && !now.Before(cached.refreshAt) {
+			v.revalidateAsync(fingerprint, apiKey)
 		}
-		// Cache expired, remove it
-		delete(v.cache, apiKey)
+		result := cached.result
+		return &result, nil
 	}
 
-	// Validate against auth-service
-	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/admin/api-keys/validate", v.authServiceURL), nil)
+	return v.fetchAndCache(ctx, fingerprint, apiKey)
+}
+
+// fetchAndCache validates apiKey against auth-service and stores the result under fingerprint.
+func (v *APIKeyValidator) fetchAndCache(ctx context.Context, fingerprint, apiKey string) (*APIKeyValidationResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", v.backendFor(apiKey)+v.validatePath, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, newAuthError(KindInternal, "api key validation: create request", err)
 	}
-	req.Header.Set("X-API-Key", apiKey)
+	req.Header.Set(v.headerName, apiKey)
 
 	resp, err := v.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		v.logger.Warn("api key validation request failed", "error", err, "key_fingerprint", fingerprint)
+		ae := newAuthError(KindUpstream, "api key validation: request failed", err)
+		v.onError.report(ctx, "apikey.validate", ae)
+		return nil, ae
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= 500 {
+		// An outage is not a verdict on the key; keep it out of the rejected path.
+		v.logger.Warn("api key validation failed upstream", "status", resp.StatusCode, "key_fingerprint", fingerprint)
+		ae := &AuthError{Kind: KindUpstream, StatusCode: resp.StatusCode, Message: "api key validation: auth-service error"}
+		v.onError.report(ctx, "apikey.validate", ae)
+		return nil, ae
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("invalid API key: status %d", resp.StatusCode)
+		attrs := map[string]any{"key_fingerprint": fingerprint, "status": resp.StatusCode}
+		var blocked *BlockedError
+		if resp.StatusCode == http.StatusForbidden {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+			var apiErr Error
+			if json.Unmarshal(body, &apiErr) == nil {
+				apiErr.StatusCode = resp.StatusCode
+				if blocked = parseBlocked(resp.StatusCode, body, &apiErr); blocked != nil {
+					attrs["block_reason"] = blocked.Reason
+				}
+			}
+		}
+		v.logger.Debug("api key rejected", "status", resp.StatusCode, "key_fingerprint", fingerprint)
+		emitAudit(ctx, v.audit, AuditEvent{
+			Type:       AuditAPIKeyRejected,
+			Outcome:    AuditOutcomeFailure,
+			Attributes: attrs,
+		})
+		if blocked != nil {
+			// The key may be fine; the request came from a denied network or country.
+			return nil, newAuthError(KindBlocked, "api key validation: request blocked", blocked)
+		}
+		return nil, newAuthError(KindInvalidCredentials, "invalid API key", fmt.Errorf("auth-service answered %d", resp.StatusCode))
 	}
 
 	var result APIKeyValidationResult
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	data, err := io.ReadAll(resp.Body)
+	if err == nil {
+		err = decodeJSON(data, &result, v.decodeMode)
 	}
-
-	// Cache the result
-	v.cache[apiKey] = &apiKeyInfo{
-		clientID:             result.ClientID,
-		tenantID:             result.TenantID,
-		tenantSlug:           result.TenantSlug,
-		scopes:               result.Scopes,
-		roles:                result.Roles,
-		service:              result.Service,
-		subscriptionPlan:     result.SubscriptionPlan,
-		subscriptionFeatures: result.SubscriptionFeatures,
-		subscriptionLimits:   result.SubscriptionLimits,
-		subscriptionStatus:   result.SubscriptionStatus,
-		expiresAt:            time.Now().Add(v.cacheTTL),
+	if err != nil {
+		v.logger.Warn("api key validation response decode failed", "error", err, "key_fingerprint", fingerprint)
+		return nil, newAuthError(KindUpstream, "api key validation: decode response", err)
 	}
 
+	v.store(fingerprint, result)
+	emitAudit(ctx, v.audit, AuditEvent{
+		Type:       AuditAPIKeyValidated,
+		Outcome:    AuditOutcomeSuccess,
+		Actor:      result.ClientID,
+		TenantID:   result.TenantID,
+		TenantSlug: result.TenantSlug,
+		Attributes: map[string]any{"key_fingerprint": fingerprint, "service": result.Service},
+	})
+
 	return &result, nil
 }
 
+// backendFor returns the auth-service base URL responsible for apiKey.
+func (v *APIKeyValidator) backendFor(apiKey string) string {
+	for _, b := range v.prefixBackends {
+		if strings.HasPrefix(apiKey, b.prefix) {
+			return b.url
+		}
+	}
+	return v.authServiceURL
+}
+
+// store caches a successful validation. Expiry is jittered so entries cached at the same
+// moment on many replicas don't all expire (and hit auth-service) together.
+func (v *APIKeyValidator) store(fingerprint string, result APIKeyValidationResult) {
+	expiresAt := time.Now().Add(v.cacheTTL - jitter(v.cacheTTL/10))
+	if result.ExpiresAt != nil && result.ExpiresAt.Before(expiresAt) {
+		expiresAt = *result.ExpiresAt // never serve a key past its own expiry
+	}
+	v.cache.add(fingerprint, &cachedAPIKey{
+		result:    result,
+		expiresAt: expiresAt,
+		refreshAt: expiresAt.Add(-v.refreshAhead),
+	})
+}
+
+// revalidateAsync refreshes a cached key without blocking the caller. Concurrent triggers for
+// the same fingerprint collapse into one request. A definitive rejection (revoked key) purges
+// the entry; transient failures leave it to expire normally.
+func (v *APIKeyValidator) revalidateAsync(fingerprint, apiKey string) {
+	v.refreshGroup.DoChan(fingerprint, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_, err := v.fetchAndCache(ctx, fingerprint, apiKey)
+		if errors.Is(err, ErrInvalidCredentials) {
+			v.cache.remove(fingerprint)
+		}
+		return nil, err
+	})
+}
+
+// jitter returns a random duration in [0, max).
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max)
+}
+
 // ToClaims converts an API key validation result to Claims for consistent handling.
 func (r *APIKeyValidationResult) ToClaims() *Claims {
 	isPlatformOwner := false
@@ -0,0 +1,169 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultAPIKeyBatchConcurrency bounds concurrent validations when the batch endpoint is unavailable.
+const DefaultAPIKeyBatchConcurrency = 8
+
+// APIKeyBatchResult is the per-key outcome of ValidateAPIKeys, in input order.
+type APIKeyBatchResult struct {
+	Fingerprint string
+	Result      *APIKeyValidationResult
+	Err         error
+}
+
+// WithBatchConcurrency bounds the fan-out used by ValidateAPIKeys when auth-service does not
+// expose the batch endpoint. Defaults to DefaultAPIKeyBatchConcurrency.
+func WithBatchConcurrency(n int) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		if n > 0 {
+			v.batchConcurrency = n
+		}
+	}
+}
+
+// ValidateAPIKeys validates many keys in one pass and populates the cache, e.g. to warm a
+// gateway at startup. Already-cached keys are served locally; the rest go to auth-service's
+// batch endpoint, falling back to bounded concurrent single validations if the batch endpoint
+// is not available. The returned slice is aligned with keys. The error is non-nil only when
+// ctx is cancelled; per-key failures are reported in APIKeyBatchResult.Err.
+func (v *APIKeyValidator) ValidateAPIKeys(ctx context.Context, keys []string) ([]APIKeyBatchResult, error) {
+	results := make([]APIKeyBatchResult, len(keys))
+	var pending []int
+	now := time.Now()
+	for i, key := range keys {
+		fp := Fingerprint(key)
+		results[i].Fingerprint = fp
+		if result, err := v.checkOffline(key, fp, now); result != nil || err != nil {
+			results[i].Result, results[i].Err = result, err
+			continue
+		}
+		if cached, ok := v.cache.get(fp, now); ok {
+			result := cached.result
+			results[i].Result = &result
+			continue
+		}
+		pending = append(pending, i)
+	}
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	// The batch endpoint is per backend; with prefix routing configured, fan out so each key
+	// still reaches the auth-service instance that issued it.
+	if len(v.prefixBackends) == 0 {
+		supported, err := v.validateBatchRemote(ctx, keys, pending, results)
+		if supported || err != nil {
+			return results, err
+		}
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(v.batchConcurrency)
+	for _, i := range pending {
+		g.Go(func() error {
+			if gctx.Err() != nil {
+				results[i].Err = gctx.Err()
+				return nil
+			}
+			results[i].Result, results[i].Err = v.ValidateAPIKeyFull(gctx, keys[i])
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return results, ctx.Err()
+}
+
+// WithAPIKeyProtobuf encodes ValidateAPIKeys batch calls as protobuf instead of JSON. If
+// auth-service answers 415 the validator switches back to JSON.
+func WithAPIKeyProtobuf() APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		v.protobuf = &protobufState{}
+	}
+}
+
+// validateBatchRemote posts the pending keys to the batch endpoint. supported is false when
+// auth-service does not implement it (404/405/501), signalling the caller to fan out instead.
+func (v *APIKeyValidator) validateBatchRemote(ctx context.Context, keys []string, pending []int, results []APIKeyBatchResult) (supported bool, err error) {
+	batch := make([]string, len(pending))
+	for j, i := range pending {
+		batch[j] = keys[i]
+	}
+	useProto := v.protobuf.use()
+	var body []byte
+	contentType := "application/json"
+	if useProto {
+		body = marshalAPIKeyBatchRequest(batch)
+		contentType = ContentTypeProtobuf
+	} else if body, err = json.Marshal(map[string]any{"api_keys": batch}); err != nil {
+		return false, newAuthError(KindInternal, "api key batch: marshal request", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.authServiceURL+v.validatePath+"/batch", bytes.NewReader(body))
+	if err != nil {
+		return false, newAuthError(KindInternal, "api key batch: create request", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if useProto {
+		req.Header.Set("Accept", protobufAccept)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return true, ctx.Err()
+		}
+		v.logger.Warn("api key batch validation request failed, falling back to fan-out", "error", err)
+		return false, nil
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnsupportedMediaType:
+		if !useProto {
+			return false, nil
+		}
+		v.logger.Warn("api key batch validation: protobuf not supported, falling back to JSON")
+		v.protobuf.rejected.Store(true)
+		return v.validateBatchRemote(ctx, keys, pending, results)
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return false, nil
+	default:
+		v.logger.Warn("api key batch validation failed, falling back to fan-out", "status", resp.StatusCode)
+		return false, nil
+	}
+
+	var out struct {
+		Results []apiKeyBatchEntry `json:"results"`
+	}
+	err = decodeBody(resp, &out, v.decodeMode, func(data []byte) (err error) {
+		out.Results, err = unmarshalAPIKeyBatchResponse(data)
+		return err
+	})
+	if err != nil || len(out.Results) != len(pending) {
+		v.logger.Warn("api key batch validation returned an unexpected body, falling back to fan-out")
+		return false, nil
+	}
+
+	for j, i := range pending {
+		r := out.Results[j]
+		if !r.Valid {
+			results[i].Err = newAuthError(KindInvalidCredentials, "invalid API key", errors.New("rejected in batch"))
+			continue
+		}
+		result := r.APIKeyValidationResult
+		v.store(results[i].Fingerprint, result)
+		results[i].Result = &result
+	}
+	return true, nil
+}
@@ -0,0 +1,83 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestValidateAPIKeys(t *testing.T) {
+	for _, batch := range []bool{true, false} {
+		var batchCalls, singleCalls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/batch") {
+				if !batch {
+					http.NotFound(w, r)
+					return
+				}
+				batchCalls.Add(1)
+				var req struct {
+					APIKeys []string `json:"api_keys"`
+				}
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				var results []map[string]any
+				for _, k := range req.APIKeys {
+					if strings.HasPrefix(k, "good") {
+						results = append(results, map[string]any{"valid": true, "client_id": k})
+					} else {
+						results = append(results, map[string]any{"valid": false})
+					}
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{"results": results})
+				return
+			}
+			singleCalls.Add(1)
+			key := r.Header.Get(DefaultAPIKeyHeader)
+			if !strings.HasPrefix(key, "good") {
+				http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"client_id": key})
+		}))
+
+		v := NewAPIKeyValidator(srv.URL, nil)
+		ctx := context.Background()
+		if _, err := v.ValidateAPIKeyFull(ctx, "good-cached"); err != nil {
+			t.Fatal(err)
+		}
+		singleCalls.Store(0)
+
+		keys := []string{"good-1", "bad-1", "good-cached", "good-2"}
+		results, err := v.ValidateAPIKeys(ctx, keys)
+		if err != nil || len(results) != len(keys) {
+			t.Fatalf("batch=%v: ValidateAPIKeys = %d results, %v", batch, len(results), err)
+		}
+		for i, key := range keys {
+			r := results[i]
+			if r.Fingerprint != Fingerprint(key) {
+				t.Errorf("batch=%v: result %d is not aligned with %s", batch, i, key)
+			}
+			if strings.HasPrefix(key, "good") {
+				if r.Err != nil || r.Result.ClientID != key {
+					t.Errorf("batch=%v: %s = %+v, %v", batch, key, r.Result, r.Err)
+				}
+			} else if KindOf(r.Err) != KindInvalidCredentials {
+				t.Errorf("batch=%v: %s err = %v, want invalid credentials", batch, key, r.Err)
+			}
+		}
+		if batch && (batchCalls.Load() != 1 || singleCalls.Load() != 0) {
+			t.Errorf("batch endpoint: %d batch and %d single calls, want 1 and 0", batchCalls.Load(), singleCalls.Load())
+		}
+		if !batch && singleCalls.Load() != 3 {
+			t.Errorf("fan-out: %d single calls, want 3 (cached key served locally)", singleCalls.Load())
+		}
+		if st := v.CacheStats(); st.Entries != 3 {
+			t.Errorf("batch=%v: %d cached keys after warming, want 3", batch, st.Entries)
+		}
+		srv.Close()
+	}
+}
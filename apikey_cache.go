@@ -0,0 +1,120 @@
+package authclient
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DefaultAPIKeyCacheMaxEntries bounds the API key cache when no explicit size is configured.
+const DefaultAPIKeyCacheMaxEntries = 10000
+
+// APIKeyCacheStats reports API key cache occupancy and effectiveness counters.
+type APIKeyCacheStats struct {
+	Entries    int    `json:"entries"`
+	MaxEntries int    `json:"max_entries"`
+	Hits       uint64 `json:"hits"`
+	Misses     uint64 `json:"misses"`
+	Evictions  uint64 `json:"evictions"` // entries dropped to make room (LRU), not expirations
+	Expired    uint64 `json:"expired"`   // entries dropped because their TTL elapsed
+}
+
+// apiKeyCache is a size-bounded LRU of validated API keys, keyed by Fingerprint(key).
+// Garbage keys are never stored (only successful validations are cached), but distinct valid
+// keys are still capped so a long-running gateway cannot grow without bound.
+type apiKeyCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	hits       uint64
+	misses     uint64
+	evictions  uint64
+	expired    uint64
+}
+
+type apiKeyCacheEntry struct {
+	key  string // API key fingerprint, never the plaintext key
+	info *cachedAPIKey
+}
+
+func newAPIKeyCache(maxEntries int) *apiKeyCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultAPIKeyCacheMaxEntries
+	}
+	return &apiKeyCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached entry for key if present and unexpired, marking it most recently used.
+func (c *apiKeyCache) get(key string, now time.Time) (*cachedAPIKey, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	entry := el.Value.(*apiKeyCacheEntry)
+	if !now.Before(entry.info.expiresAt) {
+		c.removeElement(el)
+		c.expired++
+		c.misses++
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	c.hits++
+	return entry.info, true
+}
+
+// add inserts or replaces the entry for key, evicting the least recently used entries
+// when the cache is full.
+func (c *apiKeyCache) add(key string, info *cachedAPIKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*apiKeyCacheEntry).info = info
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&apiKeyCacheEntry{key: key, info: info})
+	for c.ll.Len() > c.maxEntries {
+		c.removeElement(c.ll.Back())
+		c.evictions++
+	}
+}
+
+// remove drops the entry for key if present.
+func (c *apiKeyCache) remove(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if ok {
+		c.removeElement(el)
+	}
+	return ok
+}
+
+func (c *apiKeyCache) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*apiKeyCacheEntry).key)
+}
+
+func (c *apiKeyCache) stats() APIKeyCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return APIKeyCacheStats{
+		Entries:    c.ll.Len(),
+		MaxEntries: c.maxEntries,
+		Hits:       c.hits,
+		Misses:     c.misses,
+		Evictions:  c.evictions,
+		Expired:    c.expired,
+	}
+}
@@ -0,0 +1,131 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newAPIKeyCache(2)
+	now := time.Now()
+	info := func(id string) *cachedAPIKey {
+		return &cachedAPIKey{result: APIKeyValidationResult{ClientID: id}, expiresAt: now.Add(time.Minute)}
+	}
+
+	c.add("a", info("a"))
+	c.add("b", info("b"))
+	if _, ok := c.get("a", now); !ok { // touch a so b becomes LRU
+		t.Fatal("expected a to be cached")
+	}
+	c.add("c", info("c"))
+
+	if _, ok := c.get("b", now); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok := c.get(k, now); !ok {
+			t.Fatalf("expected %s to be cached", k)
+		}
+	}
+	if st := c.stats(); st.Entries != 2 || st.Evictions != 1 {
+		t.Fatalf("stats = %+v, want 2 entries and 1 eviction", st)
+	}
+}
+
+func TestAPIKeyCacheExpiry(t *testing.T) {
+	c := newAPIKeyCache(10)
+	now := time.Now()
+	c.add("k", &cachedAPIKey{expiresAt: now.Add(time.Second)})
+
+	if _, ok := c.get("k", now.Add(2*time.Second)); ok {
+		t.Fatal("expected expired entry to miss")
+	}
+	if st := c.stats(); st.Entries != 0 || st.Expired != 1 || st.Evictions != 0 {
+		t.Fatalf("stats = %+v, want expiry without eviction", st)
+	}
+}
+
+func TestAPIKeyCacheKeyedByFingerprint(t *testing.T) {
+	if got := Fingerprint("abc"); got != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+		t.Fatalf("Fingerprint(abc) = %s", got)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"client_id":"partner-1"}`))
+	}))
+	defer srv.Close()
+	v := NewAPIKeyValidator(srv.URL, nil)
+	if _, err := v.ValidateAPIKeyFull(context.Background(), "sk_live_secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	if _, ok := v.cache.get("sk_live_secret", now); ok {
+		t.Error("cache holds the plaintext key")
+	}
+	if _, ok := v.cache.get(Fingerprint("sk_live_secret"), now); !ok {
+		t.Error("cache has no entry for the key's fingerprint")
+	}
+}
+
+func TestAPIKeyRefreshAhead(t *testing.T) {
+	var calls atomic.Int32
+	var revoked atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if revoked.Load() {
+			http.Error(w, `{"error":"revoked"}`, http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"client_id":"partner-1"}`))
+	}))
+	defer srv.Close()
+
+	waitCalls := func(want int32) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for calls.Load() < want && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		if got := calls.Load(); got != want {
+			t.Fatalf("auth-service calls = %d, want %d", got, want)
+		}
+	}
+	ctx := context.Background()
+
+	// Entries are due for revalidation 10ms after caching, long before they expire.
+	v := NewAPIKeyValidator(srv.URL, nil, WithCacheTTL(time.Second), WithRefreshAhead(990*time.Millisecond))
+	if _, err := v.ValidateAPIKeyFull(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+	waitCalls(1)
+	time.Sleep(20 * time.Millisecond)
+	revoked.Store(true)
+	if res, err := v.ValidateAPIKeyFull(ctx, "key"); err != nil || res.ClientID != "partner-1" {
+		t.Fatalf("hit during refresh-ahead = %+v, %v; want the cached result", res, err)
+	}
+	waitCalls(2)
+	deadline := time.Now().Add(2 * time.Second)
+	for v.CacheStats().Entries != 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if v.CacheStats().Entries != 0 {
+		t.Fatal("key rejected on revalidation is still cached")
+	}
+
+	// Outside the window hits are served without calling auth-service.
+	calls.Store(0)
+	revoked.Store(false)
+	v = NewAPIKeyValidator(srv.URL, nil, WithCacheTTL(time.Hour), WithRefreshAhead(time.Minute))
+	for range 3 {
+		if _, err := v.ValidateAPIKeyFull(ctx, "key"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	waitCalls(1)
+}
@@ -0,0 +1,213 @@
+package authclient
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OfflineAPIKeyPrefix marks API keys that can be verified locally: bk_<keyid>.<secret>, where
+// secret is base64url(HMAC-SHA256(signing material, keyid)).
+const OfflineAPIKeyPrefix = "bk_"
+
+// WithSigningMaterial installs static HMAC signing material for offline API key verification.
+// Multiple secrets may be passed during a rotation (current first, then previous).
+func WithSigningMaterial(secrets ...[]byte) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		v.setSigningMaterial(secrets)
+	}
+}
+
+// parseOfflineAPIKey splits a bk_<keyid>.<secret> key. ok is false for any other format.
+func parseOfflineAPIKey(apiKey string) (keyID, secret string, ok bool) {
+	if !strings.HasPrefix(apiKey, OfflineAPIKeyPrefix) {
+		return "", "", false
+	}
+	keyID, secret, ok = strings.Cut(apiKey[len(OfflineAPIKeyPrefix):], ".")
+	if !ok || keyID == "" || secret == "" {
+		return "", "", false
+	}
+	return keyID, secret, true
+}
+
+func (v *APIKeyValidator) setSigningMaterial(secrets [][]byte) {
+	v.setOfflineMaterial(secrets, nil)
+}
+
+// setOfflineMaterial installs signing secrets and the metadata of the bk_ keys they signed,
+// replacing whatever was installed before.
+func (v *APIKeyValidator) setOfflineMaterial(secrets [][]byte, metadata map[string]APIKeyValidationResult) {
+	keys := make([][]byte, 0, len(secrets))
+	for _, s := range secrets {
+		if len(s) > 0 {
+			keys = append(keys, s)
+		}
+	}
+	v.signingMu.Lock()
+	v.signingKeys = keys
+	v.offlineMeta = metadata
+	v.signingMu.Unlock()
+}
+
+// HasSigningMaterial reports whether offline verification of bk_ keys is enabled.
+func (v *APIKeyValidator) HasSigningMaterial() bool {
+	v.signingMu.RLock()
+	defer v.signingMu.RUnlock()
+	return len(v.signingKeys) > 0
+}
+
+// verifyOffline checks the secret half of a bk_ key against every installed signing secret.
+func (v *APIKeyValidator) verifyOffline(keyID, secret string) bool {
+	sig, err := base64.RawURLEncoding.DecodeString(secret)
+	if err != nil {
+		return false
+	}
+	v.signingMu.RLock()
+	keys := v.signingKeys
+	v.signingMu.RUnlock()
+
+	for _, k := range keys {
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(keyID))
+		if hmac.Equal(mac.Sum(nil), sig) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkOffline verifies a bk_ key locally when signing material is installed. It returns an
+// error for keys whose signature does not check out, and the locally held metadata for keys
+// that do and whose metadata LoadSigningMaterial supplied. (nil, nil) means the key must be
+// validated remotely: it is not a bk_ key, no signing material is installed, or its metadata
+// is missing or past the key's expiry.
+func (v *APIKeyValidator) checkOffline(apiKey, fingerprint string, now time.Time) (*APIKeyValidationResult, error) {
+	keyID, secret, ok := parseOfflineAPIKey(apiKey)
+	if !ok || !v.HasSigningMaterial() {
+		return nil, nil
+	}
+	if !v.verifyOffline(keyID, secret) {
+		return nil, newAuthError(KindInvalidCredentials, "invalid API key", errSignatureMismatch)
+	}
+	v.signingMu.RLock()
+	meta, ok := v.offlineMeta[fingerprint]
+	v.signingMu.RUnlock()
+	if !ok || (meta.ExpiresAt != nil && !now.Before(*meta.ExpiresAt)) {
+		return nil, nil
+	}
+	return &meta, nil
+}
+
+// forgetOfflineKey drops the locally held metadata of the bk_ key with the given fingerprint,
+// so it is validated remotely (and rejected once revoked) from then on.
+func (v *APIKeyValidator) forgetOfflineKey(fingerprint string) bool {
+	v.signingMu.Lock()
+	defer v.signingMu.Unlock()
+	if _, ok := v.offlineMeta[fingerprint]; !ok {
+		return false
+	}
+	delete(v.offlineMeta, fingerprint)
+	return true
+}
+
+// WithAPIKeyCredentials supplies the service API key for LoadSigningMaterial
+// (CredentialAPIKey) when it is called with an empty key.
+func WithAPIKeyCredentials(provider CredentialProvider) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		v.credentials = provider
+	}
+}
+
+// LoadSigningMaterial fetches this service's API key signing material, and the metadata
+// (tenant, scopes, subscription) of the bk_ keys it signed, from auth-service and enables
+// offline verification. serviceAPIKey authenticates the calling service; when it is empty the
+// key comes from WithAPIKeyCredentials.
+//
+// After loading, forged or mistyped bk_ keys are rejected locally and verified keys are
+// answered from the loaded metadata, both without a network hop. Only verified keys without
+// metadata, e.g. issued after the load or past their expiry, go to the validate endpoint, and
+// their results are cached as usual. Call it again periodically to pick up new keys and
+// changed metadata; InvalidateKey and RevocationHandler drop a revoked key's metadata at once.
+//
+// The material is fetched from the backend that WithPrefixBackend routes bk_ keys to, so it
+// comes from the auth-service instance that issues them; remote validations of bk_ keys are
+// routed the same way.
+func (v *APIKeyValidator) LoadSigningMaterial(ctx context.Context, serviceAPIKey string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/admin/api-keys/signing-material", v.backendFor(OfflineAPIKeyPrefix)), nil)
+	if err != nil {
+		return newAuthError(KindInternal, "signing material: create request", err)
+	}
+	provided := serviceAPIKey == "" && v.credentials != nil
+	if provided {
+		if serviceAPIKey, err = v.credentials.Credential(ctx, CredentialAPIKey); err != nil {
+			return newAuthError(KindInternal, "signing material: load API key", err)
+		}
+	}
+	req.Header.Set(v.headerName, serviceAPIKey)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return newAuthError(KindUpstream, "signing material: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if provided && resp.StatusCode == http.StatusUnauthorized {
+			invalidateCredential(v.credentials, CredentialAPIKey)
+		}
+		return &AuthError{Kind: statusKind(resp.StatusCode), StatusCode: resp.StatusCode, Message: "signing material fetch failed"}
+	}
+
+	var body struct {
+		Keys []struct {
+			ID     string `json:"id"`
+			Secret string `json:"secret"` // base64url, unpadded
+		} `json:"keys"`
+		APIKeys []struct {
+			KeyID string `json:"key_id"`
+			APIKeyValidationResult
+		} `json:"api_keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return newAuthError(KindUpstream, "signing material: decode response", err)
+	}
+
+	secrets := make([][]byte, 0, len(body.Keys))
+	for _, k := range body.Keys {
+		secret, err := base64.RawURLEncoding.DecodeString(k.Secret)
+		if err != nil {
+			return newAuthError(KindUpstream, "signing material: decode secret "+k.ID, err)
+		}
+		secrets = append(secrets, secret)
+	}
+	if len(secrets) == 0 {
+		return newAuthError(KindUpstream, "signing material: response has no keys", nil)
+	}
+
+	// Index metadata by the fingerprint of the full key, which is derivable from the key ID and
+	// signing secret, so lookups and revocations use the same fingerprint as the cache.
+	metadata := make(map[string]APIKeyValidationResult, len(body.APIKeys)*len(secrets))
+	for _, k := range body.APIKeys {
+		if k.KeyID == "" {
+			continue
+		}
+		for _, secret := range secrets {
+			metadata[Fingerprint(offlineAPIKey(secret, k.KeyID))] = k.APIKeyValidationResult
+		}
+	}
+	v.setOfflineMaterial(secrets, metadata)
+	return nil
+}
+
+// offlineAPIKey returns the bk_ key for keyID signed with secret.
+func offlineAPIKey(secret []byte, keyID string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(keyID))
+	return OfflineAPIKeyPrefix + keyID + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,138 @@
+package authclient
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOfflineAPIKeyVerification(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"client_id":"partner-1","tenant_id":"t1"}`))
+	}))
+	defer srv.Close()
+
+	secret := []byte("service-signing-secret")
+	v := NewAPIKeyValidator(srv.URL, nil, WithSigningMaterial(secret))
+
+	if _, err := v.ValidateAPIKeyFull(context.Background(), OfflineAPIKeyPrefix+"abc.Zm9yZ2Vk"); err == nil {
+		t.Fatal("expected forged key to be rejected")
+	}
+	if calls.Load() != 0 {
+		t.Fatalf("forged key reached auth-service %d times", calls.Load())
+	}
+
+	res, err := v.ValidateAPIKeyFull(context.Background(), offlineAPIKey(secret, "abc"))
+	if err != nil {
+		t.Fatalf("valid signed key: %v", err)
+	}
+	if res.ClientID != "partner-1" || calls.Load() != 1 {
+		t.Fatalf("got client %q after %d calls, want partner-1 after 1", res.ClientID, calls.Load())
+	}
+}
+
+func TestOfflineAPIKeyMetadata(t *testing.T) {
+	secret := []byte("service-signing-secret")
+	material := `{"keys":[{"id":"k1","secret":"` + base64.RawURLEncoding.EncodeToString(secret) + `"}],` +
+		`"api_keys":[{"key_id":"abc","client_id":"partner-1","tenant_id":"t1","scopes":["orders:read"]},` +
+		`{"key_id":"old","client_id":"partner-2","expires_at":"2020-01-01T00:00:00Z"}]}`
+	var validations atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/admin/api-keys/signing-material" {
+			_, _ = w.Write([]byte(material))
+			return
+		}
+		validations.Add(1)
+		_, _ = w.Write([]byte(`{"client_id":"remote"}`))
+	}))
+	defer srv.Close()
+
+	// bk_ keys route to srv; the default backend must never be called.
+	v := NewAPIKeyValidator("http://127.0.0.1:1", nil, WithPrefixBackend(OfflineAPIKeyPrefix, srv.URL))
+	if err := v.LoadSigningMaterial(context.Background(), "svc-key"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	key := offlineAPIKey(secret, "abc")
+	for range 3 {
+		res, err := v.ValidateAPIKeyFull(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.ClientID != "partner-1" || res.TenantID != "t1" || len(res.Scopes) != 1 {
+			t.Fatalf("offline result = %+v, want loaded metadata", res)
+		}
+	}
+	batch, err := v.ValidateAPIKeys(ctx, []string{key})
+	if err != nil || batch[0].Err != nil || batch[0].Result.ClientID != "partner-1" {
+		t.Fatalf("ValidateAPIKeys = %+v, %v; want loaded metadata", batch, err)
+	}
+	if n := validations.Load(); n != 0 {
+		t.Fatalf("verified key with metadata reached auth-service %d times", n)
+	}
+
+	// Keys without usable metadata are validated remotely.
+	for _, keyID := range []string{"new", "old"} {
+		if res, err := v.ValidateAPIKeyFull(ctx, offlineAPIKey(secret, keyID)); err != nil || res.ClientID != "remote" {
+			t.Fatalf("key %s: got %+v, %v; want remote validation", keyID, res, err)
+		}
+	}
+	if n := validations.Load(); n != 2 {
+		t.Fatalf("remote validations = %d, want 2", n)
+	}
+
+	// Revoking the key drops its metadata, so auth-service decides from then on.
+	if !v.InvalidateKey(Fingerprint(key)) {
+		t.Fatal("InvalidateKey found no metadata to drop")
+	}
+	if res, err := v.ValidateAPIKeyFull(ctx, key); err != nil || res.ClientID != "remote" {
+		t.Fatalf("after revocation got %+v, %v; want remote validation", res, err)
+	}
+}
+
+func TestLoadSigningMaterial(t *testing.T) {
+	secret := []byte("service-signing-secret")
+	tests := []struct {
+		name     string
+		body     string
+		wantKind ErrorKind
+	}{
+		{"keys", `{"keys":[{"id":"k1","secret":"` + base64.RawURLEncoding.EncodeToString(secret) + `"}]}`, ""},
+		{"no keys", `{"keys":[]}`, KindUpstream},
+		{"bad secret", `{"keys":[{"id":"k1","secret":"!!"}]}`, KindUpstream},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-Service-Key") != "svc-key" {
+					http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			v := NewAPIKeyValidator(srv.URL, nil, WithHeaderName("X-Service-Key"))
+			err := v.LoadSigningMaterial(context.Background(), "svc-key")
+			if tt.wantKind == "" {
+				if err != nil {
+					t.Fatalf("LoadSigningMaterial = %v", err)
+				}
+				if !v.HasSigningMaterial() {
+					t.Fatal("signing material not installed")
+				}
+				return
+			}
+			if KindOf(err) != tt.wantKind {
+				t.Fatalf("LoadSigningMaterial = %v, want kind %s", err, tt.wantKind)
+			}
+		})
+	}
+}
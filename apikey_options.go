@@ -0,0 +1,129 @@
+package authclient
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// APIKeyValidatorOption configures an APIKeyValidator.
+type APIKeyValidatorOption func(*APIKeyValidator)
+
+// WithAPIKeyDecodeMode sets how validation responses are decoded. Defaults to
+// DecodeLenient.
+func WithAPIKeyDecodeMode(mode DecodeMode) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		v.decodeMode = mode
+	}
+}
+
+// WithCacheTTL sets how long a successful validation is cached. Defaults to 5 minutes.
+func WithCacheTTL(ttl time.Duration) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		if ttl > 0 {
+			v.cacheTTL = ttl
+		}
+	}
+}
+
+// WithMaxCacheEntries bounds the number of validated keys held in the LRU cache.
+// Values <= 0 fall back to DefaultAPIKeyCacheMaxEntries.
+func WithMaxCacheEntries(n int) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		v.maxEntries = n
+	}
+}
+
+// WithRefreshAhead sets how long before expiry a cache hit triggers background revalidation.
+// Zero disables refresh-ahead; the default is one minute.
+func WithRefreshAhead(window time.Duration) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		v.refreshAhead = window
+	}
+}
+
+// WithValidatePath overrides the auth-service validation endpoint path
+// (default DefaultAPIKeyValidatePath).
+func WithValidatePath(path string) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		if path != "" {
+			v.validatePath = "/" + strings.TrimPrefix(path, "/")
+		}
+	}
+}
+
+// WithHeaderName overrides the header API keys are read from and forwarded in
+// (default DefaultAPIKeyHeader). AuthMiddleware honours the same header.
+func WithHeaderName(name string) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		if name != "" {
+			v.headerName = name
+		}
+	}
+}
+
+// WithLogger logs validation failures to a zap logger named "api-key-validator".
+//
+// Deprecated: Use WithAPIKeyLogger(ZapLogger(logger)).
+func WithLogger(logger *zap.Logger) APIKeyValidatorOption {
+	if logger == nil {
+		return WithAPIKeyLogger(nil)
+	}
+	return WithAPIKeyLogger(ZapLogger(logger.Named("api-key-validator")))
+}
+
+// WithAPIKeySlogLogger logs through a *slog.Logger tagged logger=api-key-validator.
+func WithAPIKeySlogLogger(logger *slog.Logger) APIKeyValidatorOption {
+	return WithAPIKeyLogger(slogLogger(logger, "api-key-validator"))
+}
+
+// WithAPIKeyLogger sets the validator's logger, e.g. a *slog.Logger or ZapLogger(logger).
+// Keys are only ever logged by fingerprint. Defaults to a no-op logger.
+func WithAPIKeyLogger(logger Logger) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		if logger != nil {
+			v.logger = logger
+		}
+	}
+}
+
+// WithAPIKeyOnError reports auth-service outages seen while validating keys (op
+// "apikey.validate").
+func WithAPIKeyOnError(hook ErrorHook) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		v.onError = hook
+	}
+}
+
+// WithAPIKeyAuditSink emits AuditAPIKeyValidated/AuditAPIKeyRejected events for keys checked
+// against auth-service. Cache hits are not audited.
+func WithAPIKeyAuditSink(sink AuditSink) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		v.audit = sink
+	}
+}
+
+// WithPrefixBackend routes keys starting with prefix to a different auth-service instance
+// (e.g. legacy vs new issuers with distinct key prefixes). The longest matching prefix wins;
+// unmatched keys use the URL passed to NewAPIKeyValidator. All backends share one cache,
+// CacheStats and the middleware integration. Routing applies only to remote calls: bk_ keys
+// answered offline (see LoadSigningMaterial) reach no backend, and LoadSigningMaterial itself
+// is fetched from the backend bk_ keys route to.
+func WithPrefixBackend(prefix, authServiceURL string) APIKeyValidatorOption {
+	return func(v *APIKeyValidator) {
+		if prefix == "" || authServiceURL == "" {
+			return
+		}
+		v.prefixBackends = append(v.prefixBackends, prefixBackend{
+			prefix: prefix,
+			url:    strings.TrimSuffix(authServiceURL, "/"),
+		})
+		// Keep longest prefixes first so the first match is the most specific.
+		slices.SortStableFunc(v.prefixBackends, func(a, b prefixBackend) int {
+			return len(b.prefix) - len(a.prefix)
+		})
+	}
+}
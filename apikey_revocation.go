@@ -0,0 +1,71 @@
+package authclient
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/Bengo-Hub/shared-auth-client/webhooks"
+)
+
+// InvalidateKey purges the cached validation for the key with the given fingerprint (see
+// Fingerprint), so a key revoked in auth-service stops working immediately instead of living
+// until its cache entry expires. The metadata LoadSigningMaterial holds for a bk_ key is
+// dropped too. Returns true if an entry was removed.
+func (v *APIKeyValidator) InvalidateKey(fingerprint string) bool {
+	removed := v.cache.remove(fingerprint)
+	if v.forgetOfflineKey(fingerprint) {
+		removed = true
+	}
+	return removed
+}
+
+// APIKeyRevocationEvent is the payload auth-service posts when operators revoke keys.
+type APIKeyRevocationEvent struct {
+	Fingerprints []string `json:"fingerprints"`
+}
+
+// RevocationHandler returns an http.Handler that accepts APIKeyRevocationEvent webhooks from
+// auth-service and invalidates the listed fingerprints. Requests must be POSTs signed with the
+// shared secret the same way as webhook deliveries (webhooks.SignatureHeader over the
+// webhooks.TimestampHeader and raw body, within webhooks.DefaultTolerance), so captured posts
+// cannot be replayed later; anything else is rejected without touching the cache. Mount it on
+// an internal-only route.
+func (v *APIKeyValidator) RevocationHandler(secret []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeAuthError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			writeAuthError(w, http.StatusBadRequest, "read body")
+			return
+		}
+		if err := webhooks.Verify(secret, r.Header, body, webhooks.DefaultTolerance); err != nil {
+			writeAuthError(w, http.StatusUnauthorized, "invalid signature")
+			return
+		}
+
+		var event APIKeyRevocationEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			writeAuthError(w, http.StatusBadRequest, "invalid payload")
+			return
+		}
+
+		invalidated := 0
+		for _, fp := range event.Fingerprints {
+			if v.InvalidateKey(fp) {
+				invalidated++
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"received":    len(event.Fingerprints),
+			"invalidated": invalidated,
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bengo-Hub/shared-auth-client/webhooks"
+)
+
+func TestRevocationHandler(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"client_id":"partner-1"}`))
+	}))
+	defer srv.Close()
+
+	body := `{"fingerprints":["` + Fingerprint("revoked-key") + `"]}`
+	secret := []byte("revocation-secret")
+	now := time.Now()
+	stale := now.Add(-webhooks.DefaultTolerance - time.Minute)
+
+	tests := []struct {
+		name        string
+		secret      []byte
+		signature   string
+		timestamp   time.Time
+		wantStatus  int
+		wantEvicted bool
+	}{
+		{"valid push", secret, webhooks.Sign(secret, now, []byte(body)), now, http.StatusOK, true},
+		{"bad signature", secret, webhooks.Sign([]byte("other-secret"), now, []byte(body)), now, http.StatusUnauthorized, false},
+		{"missing signature", secret, "", now, http.StatusUnauthorized, false},
+		{"empty secret", nil, webhooks.Sign(nil, now, []byte(body)), now, http.StatusUnauthorized, false},
+		{"stale timestamp", secret, webhooks.Sign(secret, stale, []byte(body)), stale, http.StatusUnauthorized, false},
+		{"replayed with fresh timestamp", secret, webhooks.Sign(secret, stale, []byte(body)), now, http.StatusUnauthorized, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewAPIKeyValidator(srv.URL, nil)
+			if _, err := v.ValidateAPIKeyFull(context.Background(), "revoked-key"); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/api-keys/revoked", strings.NewReader(body))
+			req.Header.Set(webhooks.SignatureHeader, tt.signature)
+			req.Header.Set(webhooks.TimestampHeader, strconv.FormatInt(tt.timestamp.Unix(), 10))
+			rec := httptest.NewRecorder()
+			v.RevocationHandler(tt.secret).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if evicted := v.CacheStats().Entries == 0; evicted != tt.wantEvicted {
+				t.Fatalf("evicted = %v, want %v", evicted, tt.wantEvicted)
+			}
+		})
+	}
+}
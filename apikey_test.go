@@ -0,0 +1,97 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithPrefixBackend(t *testing.T) {
+	backend := func(name string) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"client_id":"` + name + `"}`))
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+	def, legacy, legacyV2 := backend("default"), backend("legacy"), backend("legacy-v2")
+	v := NewAPIKeyValidator(def.URL, nil,
+		WithPrefixBackend("lk_", legacy.URL),
+		WithPrefixBackend("lk_v2_", legacyV2.URL+"/"),
+	)
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"sk_abc", "default"},
+		{"lk_abc", "legacy"},
+		{"lk_v2_abc", "legacy-v2"}, // longest prefix wins
+	}
+	for _, tt := range tests {
+		res, err := v.ValidateAPIKeyFull(context.Background(), tt.key)
+		if err != nil || res.ClientID != tt.want {
+			t.Errorf("%s: validated by %+v, %v; want %s", tt.key, res, err, tt.want)
+		}
+	}
+
+	// Batches fan out so each key still reaches its own backend.
+	results, err := NewAPIKeyValidator(def.URL, nil, WithPrefixBackend("lk_", legacy.URL)).ValidateAPIKeys(context.Background(), []string{"sk_abc", "lk_abc"})
+	if err != nil || results[0].Result.ClientID != "default" || results[1].Result.ClientID != "legacy" {
+		t.Fatalf("ValidateAPIKeys = %+v, %v", results, err)
+	}
+}
+
+func TestAPIKeyValidatorCustomEndpoint(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/keys/check" || r.Header.Get("X-Service-Key") != "sk_abc" || r.Header.Get(DefaultAPIKeyHeader) != "" {
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+			return
+		}
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"client_id":"partner-1"}`))
+	}))
+	defer srv.Close()
+
+	v := NewAPIKeyValidator(srv.URL, nil,
+		WithValidatePath("internal/keys/check"),
+		WithHeaderName("X-Service-Key"),
+		WithCacheTTL(50*time.Millisecond),
+		WithRefreshAhead(0),
+	)
+	ctx := context.Background()
+	for range 2 {
+		if res, err := v.ValidateAPIKeyFull(ctx, "sk_abc"); err != nil || res.ClientID != "partner-1" {
+			t.Fatalf("ValidateAPIKeyFull = %+v, %v; want partner-1", res, err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("remote validations = %d, want 1 while cached", n)
+	}
+	time.Sleep(80 * time.Millisecond)
+	if _, err := v.ValidateAPIKeyFull(ctx, "sk_abc"); err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("remote validations = %d, want 2 after the cache TTL", n)
+	}
+
+	// The middleware reads the key from the same custom header.
+	h := NewAuthMiddlewareWithAPIKey(nil, v).RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := ClaimsFromContext(r.Context())
+		_, _ = w.Write([]byte(claims.Subject))
+	}))
+	for header, want := range map[string]int{"X-Service-Key": http.StatusOK, DefaultAPIKeyHeader: http.StatusUnauthorized} {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set(header, "sk_abc")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != want || (want == http.StatusOK && rec.Body.String() != "partner-1") {
+			t.Errorf("key in %s: status = %d, body %q; want %d", header, rec.Code, rec.Body.String(), want)
+		}
+	}
+}
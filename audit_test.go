@@ -0,0 +1,29 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONAuditSinkAndImpersonator(t *testing.T) {
+	var claims Claims
+	if err := json.Unmarshal([]byte(`{"sub":"user-1","act":{"sub":"support-7"}}`), &claims); err != nil {
+		t.Fatal(err)
+	}
+	actor, ok := claims.Impersonator()
+	if !ok || actor != "support-7" {
+		t.Fatalf("Impersonator() = %q, %v", actor, ok)
+	}
+
+	var buf bytes.Buffer
+	emitAudit(context.Background(), NewJSONAuditSink(&buf), AuditEvent{Type: AuditImpersonationUsed, Outcome: AuditOutcomeSuccess, Actor: actor})
+	var got AuditEvent
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("sink wrote %q: %v", buf.String(), err)
+	}
+	if got.Type != AuditImpersonationUsed || got.Actor != "support-7" || got.Time.IsZero() {
+		t.Fatalf("event = %+v", got)
+	}
+}
@@ -0,0 +1,9 @@
+// Package authapi holds typed request/response models and low-level calls generated from
+// auth-service's OpenAPI document (api/openapi.yaml). It tracks the service's endpoints
+// one-to-one; most callers want the hand-written authclient.Client built on top, which adds
+// error classification, redacted logging and auditing.
+//
+// Do not edit authapi.gen.go; update the spec and run `go generate ./authapi`.
+package authapi
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.1 -config oapi-codegen.yaml ../api/openapi.yaml
@@ -0,0 +1,273 @@
+package authclienttest
+
+import (
+	"cmp"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 24 * time.Hour
+)
+
+type devUser struct {
+	id       string
+	password string
+	claims   authclient.Claims
+}
+
+type devSession struct {
+	id        string
+	email     string
+	expiresAt time.Time
+	createdAt time.Time
+	lastSeen  time.Time
+	userAgent string
+	ip        string
+}
+
+// AddUser registers a user the emulator accepts at login. claims seeds every access token
+// issued to the user (roles, permissions, tenant, ...); identity fields are filled in.
+func (d *DevIssuer) AddUser(email, password string, claims authclient.Claims) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	id := uuid.NewString()
+	if u, ok := d.users[email]; ok {
+		id = u.id
+	}
+	claims.Email = email
+	claims.Subject = id
+	d.users[email] = &devUser{id: id, password: password, claims: claims}
+}
+
+// registerEmulator mounts in-memory versions of the auth-service session endpoints used by
+// authclient.Client. Refresh tokens rotate on use and die on logout, like the real service.
+func (d *DevIssuer) registerEmulator(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/v1/auth/login", d.handleLogin)
+	mux.HandleFunc("POST /api/v1/auth/refresh", d.handleRefresh)
+	mux.HandleFunc("POST /api/v1/auth/session/rotate", d.handleRotate)
+	mux.HandleFunc("POST /api/v1/auth/logout", d.handleLogout)
+	mux.HandleFunc("POST /api/v1/auth/logout-all", d.handleLogoutAll)
+	mux.HandleFunc("GET /api/v1/auth/sessions", d.handleListSessions)
+	mux.HandleFunc("DELETE /api/v1/auth/sessions/{id}", d.handleRevokeSession)
+}
+
+func (d *DevIssuer) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var req authclient.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "malformed body")
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	u, ok := d.users[req.Email]
+	if !ok || u.password != req.Password {
+		writeError(w, http.StatusUnauthorized, "invalid_credentials", "invalid email or password")
+		return
+	}
+	d.issueLocked(w, r, req.Email, uuid.NewString(), time.Now())
+}
+
+func (d *DevIssuer) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	var req authclient.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "malformed body")
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s, ok := d.sessions[req.RefreshToken]
+	delete(d.sessions, req.RefreshToken)
+	if !ok || time.Now().After(s.expiresAt) {
+		writeError(w, http.StatusUnauthorized, "invalid_grant", "refresh token invalid or expired")
+		return
+	}
+	d.issueLocked(w, r, s.email, s.id, s.createdAt)
+}
+
+// handleRotate is handleRefresh with a new session ID, as after login or step-up.
+func (d *DevIssuer) handleRotate(w http.ResponseWriter, r *http.Request) {
+	var req authclient.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "malformed body")
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s, ok := d.sessions[req.RefreshToken]
+	delete(d.sessions, req.RefreshToken)
+	if !ok || time.Now().After(s.expiresAt) {
+		writeError(w, http.StatusUnauthorized, "invalid_grant", "refresh token invalid or expired")
+		return
+	}
+	d.issueLocked(w, r, s.email, uuid.NewString(), time.Now())
+}
+
+func (d *DevIssuer) handleLogout(w http.ResponseWriter, r *http.Request) {
+	d.revoke(w, r, func(s *devSession, claims *authclient.Claims, req logoutRequest) bool {
+		return s.email == claims.Email && s.id == cmp.Or(req.SessionID, claims.SessionID)
+	})
+}
+
+func (d *DevIssuer) handleLogoutAll(w http.ResponseWriter, r *http.Request) {
+	d.revoke(w, r, func(s *devSession, claims *authclient.Claims, _ logoutRequest) bool { return s.email == claims.Email })
+}
+
+type logoutRequest struct {
+	SessionID string `json:"session_id"`
+	UserID    string `json:"user_id"`
+}
+
+// revoke authenticates the bearer token and drops the refresh tokens matched by match. The
+// emulator has no admins, so users can only sign themselves out.
+func (d *DevIssuer) revoke(w http.ResponseWriter, r *http.Request, match func(*devSession, *authclient.Claims, logoutRequest) bool) {
+	claims, ok := d.bearerClaims(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid_token", "valid bearer token required")
+		return
+	}
+	var req logoutRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", "malformed body")
+			return
+		}
+	}
+	if req.UserID != "" && req.UserID != claims.Subject {
+		writeError(w, http.StatusForbidden, "forbidden", "cannot sign out another user")
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	result := authclient.LogoutResult{UserID: claims.Subject, SessionID: req.SessionID}
+	sessions := make(map[string]struct{})
+	for token, s := range d.sessions {
+		if match(s, claims, req) {
+			delete(d.sessions, token)
+			sessions[s.id] = struct{}{}
+			result.TokensRevoked++
+		}
+	}
+	result.SessionsRevoked = len(sessions)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (d *DevIssuer) handleListSessions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := d.bearerClaims(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid_token", "valid bearer token required")
+		return
+	}
+	d.mu.Lock()
+	sessions := []authclient.Session{}
+	for _, s := range d.sessions {
+		if s.email == claims.Email && time.Now().Before(s.expiresAt) {
+			sessions = append(sessions, authclient.Session{
+				ID:        s.id,
+				UserAgent: s.userAgent,
+				IP:        s.ip,
+				Current:   s.id == claims.SessionID,
+				CreatedAt: s.createdAt,
+				LastSeen:  s.lastSeen,
+				ExpiresAt: s.expiresAt,
+			})
+		}
+	}
+	d.mu.Unlock()
+	slices.SortFunc(sessions, func(a, b authclient.Session) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"sessions": sessions})
+}
+
+func (d *DevIssuer) handleRevokeSession(w http.ResponseWriter, r *http.Request) {
+	claims, ok := d.bearerClaims(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid_token", "valid bearer token required")
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	found := false
+	for token, s := range d.sessions {
+		if s.email == claims.Email && s.id == r.PathValue("id") {
+			delete(d.sessions, token)
+			found = true
+		}
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "not_found", "no such session")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (d *DevIssuer) bearerClaims(r *http.Request) (*authclient.Claims, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return nil, false
+	}
+	claims := &authclient.Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return d.signer.PublicKey(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+	return claims, err == nil
+}
+
+// issueLocked mints a token pair for the session and writes an AuthResponse. d.mu must be held.
+func (d *DevIssuer) issueLocked(w http.ResponseWriter, r *http.Request, email, sessionID string, createdAt time.Time) {
+	u := d.users[email]
+	claims := u.claims
+	claims.SessionID = sessionID
+	claims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(accessTokenTTL))
+	access, err := d.signer.Sign(claims)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+	refresh := randomToken()
+	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+	d.sessions[refresh] = &devSession{
+		id:        sessionID,
+		email:     email,
+		expiresAt: time.Now().Add(refreshTokenTTL),
+		createdAt: createdAt,
+		lastSeen:  time.Now(),
+		userAgent: r.UserAgent(),
+		ip:        ip,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(authclient.AuthResponse{
+		AccessToken:      access,
+		RefreshToken:     refresh,
+		SessionID:        sessionID,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(accessTokenTTL / time.Second),
+		RefreshExpiresIn: int(refreshTokenTTL / time.Second),
+		User:             &authclient.User{ID: u.id, Email: email},
+	})
+}
+
+func randomToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func writeError(w http.ResponseWriter, status int, code, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(authclient.Error{ErrorField: code, ErrorDescription: description})
+}
@@ -0,0 +1,88 @@
+// Package authclienttest provides an in-process stand-in for auth-service: a dev JWT issuer
+// with a JWKS endpoint and an emulator for the login, refresh, logout and session endpoints,
+// so local stacks and CI can exercise authclient with no external dependencies.
+package authclienttest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+)
+
+const (
+	// DevIssuerName is the iss claim of tokens minted by DevIssuer.
+	DevIssuerName = "authclienttest"
+	// DevAudience is the aud claim of tokens minted by DevIssuer.
+	DevAudience = "authclienttest-api"
+	// JWKSPath is where DevIssuer serves its key set.
+	JWKSPath = "/.well-known/jwks.json"
+	// DevKeyID is the kid of DevIssuer's signing key.
+	DevKeyID = "dev-1"
+)
+
+// DevIssuer is a throwaway auth-service: it owns a freshly generated RSA key, serves its JWKS
+// over HTTP and mints tokens on demand. Close it when done.
+type DevIssuer struct {
+	key    *rsa.PrivateKey
+	signer *authclient.Signer
+	server *httptest.Server
+
+	mu       sync.Mutex
+	users    map[string]*devUser    // by email
+	sessions map[string]*devSession // by refresh token
+}
+
+// NewDevIssuer generates a 2048-bit RSA key and starts the issuer's HTTP server. Like
+// httptest.NewServer, it panics if it cannot start.
+func NewDevIssuer() *DevIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic("authclienttest: generate key: " + err.Error())
+	}
+	signer, err := authclient.NewSigner(key, DevKeyID, authclient.SignerConfig{
+		Issuer:   DevIssuerName,
+		Audience: []string{DevAudience},
+	})
+	if err != nil {
+		panic("authclienttest: " + err.Error())
+	}
+
+	d := &DevIssuer{
+		key:      key,
+		signer:   signer,
+		users:    make(map[string]*devUser),
+		sessions: make(map[string]*devSession),
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET "+JWKSPath, signer.JWKSHandler())
+	d.registerEmulator(mux)
+	d.server = httptest.NewServer(mux)
+	return d
+}
+
+// URL is the issuer's base URL, usable as the auth-service URL of authclient.NewClient.
+func (d *DevIssuer) URL() string { return d.server.URL }
+
+// JWKSURL is the URL of the issuer's key set.
+func (d *DevIssuer) JWKSURL() string { return d.server.URL + JWKSPath }
+
+// Config returns a validator config that accepts this issuer's tokens.
+func (d *DevIssuer) Config() authclient.Config {
+	return authclient.DefaultConfig(d.JWKSURL(), DevIssuerName, DevAudience)
+}
+
+// Mint signs arbitrary claims. Issuer, audience, timestamps and jti are filled in when unset,
+// so tests may set ExpiresAt in the past to produce expired tokens.
+func (d *DevIssuer) Mint(claims authclient.Claims) (string, error) {
+	return d.signer.Sign(claims)
+}
+
+// PrivateKey returns the issuer's signing key, for SignToken.
+func (d *DevIssuer) PrivateKey() *rsa.PrivateKey { return d.key }
+
+// Close shuts down the issuer's HTTP server.
+func (d *DevIssuer) Close() { d.server.Close() }
@@ -0,0 +1,92 @@
+package authclienttest
+
+import (
+	"context"
+	"testing"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"go.uber.org/zap"
+)
+
+func TestEmulatorSessionLifecycle(t *testing.T) {
+	ctx := context.Background()
+	d := NewDevIssuer()
+	defer d.Close()
+	d.AddUser("ada@example.com", "s3cret", authclient.Claims{TenantSlug: "acme", Roles: []string{"admin"}})
+
+	v, err := authclient.NewValidator(d.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Stop()
+	client := authclient.NewClient(d.URL(), zap.NewNop())
+
+	login, err := client.Login(ctx, authclient.LoginRequest{Email: "ada@example.com", Password: "s3cret"})
+	if err != nil {
+		t.Fatalf("Login() = %v", err)
+	}
+	claims, err := v.ValidateToken(login.AccessToken)
+	if err != nil || claims.TenantSlug != "acme" || !claims.HasRole("admin") {
+		t.Fatalf("ValidateToken() = %+v, %v", claims, err)
+	}
+
+	refreshed, err := client.Refresh(ctx, login.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() = %v", err)
+	}
+	if _, err := client.Refresh(ctx, login.RefreshToken); err == nil {
+		t.Fatal("reusing a rotated refresh token should fail")
+	}
+
+	manager := authclient.NewTokenManager(client, refreshed, authclient.TokenManagerConfig{})
+	if err := manager.RotateSession(ctx, client); err != nil {
+		t.Fatalf("RotateSession() = %v", err)
+	}
+	rotated := manager.Tokens()
+	if rotated.SessionID == "" || rotated.SessionID == refreshed.SessionID {
+		t.Fatalf("session ID after rotation = %q, was %q", rotated.SessionID, refreshed.SessionID)
+	}
+	if _, err := client.Refresh(ctx, refreshed.RefreshToken); err == nil {
+		t.Fatal("refresh token of the rotated-away session should fail")
+	}
+	refreshed = &rotated
+
+	second, err := client.Login(ctx, authclient.LoginRequest{Email: "ada@example.com", Password: "s3cret"})
+	if err != nil {
+		t.Fatalf("second Login() = %v", err)
+	}
+	sessions, err := client.ListSessions(ctx, second.AccessToken)
+	if err != nil || len(sessions) != 2 || sessions[0].ID != second.SessionID || !sessions[0].Current || sessions[1].Current {
+		t.Fatalf("ListSessions() = %+v, %v", sessions, err)
+	}
+	third, err := client.Login(ctx, authclient.LoginRequest{Email: "ada@example.com", Password: "s3cret"})
+	if err != nil {
+		t.Fatalf("third Login() = %v", err)
+	}
+	if err := client.RevokeSession(ctx, third.SessionID, second.AccessToken); err != nil {
+		t.Fatalf("RevokeSession() = %v", err)
+	}
+	if _, err := client.Refresh(ctx, third.RefreshToken); err == nil {
+		t.Fatal("refresh of a revoked session should fail")
+	}
+	if err := client.RevokeSession(ctx, third.SessionID, second.AccessToken); authclient.KindOf(err) != authclient.KindNotFound {
+		t.Fatalf("revoking twice: err = %v", err)
+	}
+	if result, err := client.Logout(ctx, "", second.AccessToken); err != nil || result.SessionsRevoked != 1 {
+		t.Fatalf("Logout() = %+v, %v", result, err)
+	}
+	if _, err := client.Refresh(ctx, second.RefreshToken); err == nil {
+		t.Fatal("refresh after Logout should fail")
+	}
+
+	result, err := client.LogoutAll(ctx, "", refreshed.AccessToken)
+	if err != nil {
+		t.Fatalf("LogoutAll() = %v", err)
+	}
+	if result.SessionsRevoked != 1 || result.UserID == "" {
+		t.Fatalf("LogoutAll() = %+v, want one session of the token's user", result)
+	}
+	if _, err := client.Refresh(ctx, refreshed.RefreshToken); err == nil {
+		t.Fatal("refresh after LogoutAll should fail")
+	}
+}
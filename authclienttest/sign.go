@@ -0,0 +1,171 @@
+package authclienttest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// SignOptions controls how SignToken builds a token. The zero value produces a token that a
+// DevIssuer-configured Validator accepts, apart from the key.
+type SignOptions struct {
+	KeyID    string        // kid header; DevKeyID when empty
+	Issuer   string        // iss when the claims have none; DevIssuerName when empty
+	Audience []string      // aud when the claims have none; [DevAudience] when empty
+	TTL      time.Duration // exp = now+TTL when the claims have none; 5 minutes when zero
+
+	// Method signs the token; RS256 when nil. HMAC methods use the DER encoding of the
+	// public key as the secret (the classic algorithm-confusion attack) and
+	// jwt.SigningMethodNone produces an unsigned token, so middleware can be tested against
+	// both.
+	Method jwt.SigningMethod
+
+	// Header entries are added to (or override) the JOSE header; typ defaults to "at+jwt".
+	Header map[string]any
+}
+
+// SignToken signs claims with key, filling iss, aud, iat, nbf, exp and jti when unset. It is
+// the low-level builder behind Fixtures; use it directly for one-off failure modes.
+func SignToken(key *rsa.PrivateKey, claims authclient.Claims, opts SignOptions) (string, error) {
+	now := time.Now()
+	if claims.Issuer == "" {
+		claims.Issuer = opts.Issuer
+		if claims.Issuer == "" {
+			claims.Issuer = DevIssuerName
+		}
+	}
+	if len(claims.Audience) == 0 {
+		claims.Audience = opts.Audience
+		if len(claims.Audience) == 0 {
+			claims.Audience = jwt.ClaimStrings{DevAudience}
+		}
+	}
+	if claims.IssuedAt == nil {
+		claims.IssuedAt = jwt.NewNumericDate(now)
+	}
+	if claims.NotBefore == nil {
+		claims.NotBefore = jwt.NewNumericDate(now)
+	}
+	if claims.RegisteredClaims.ExpiresAt == nil {
+		ttl := opts.TTL
+		if ttl <= 0 {
+			ttl = 5 * time.Minute
+		}
+		claims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+	}
+	if claims.ID == "" {
+		claims.ID = uuid.NewString()
+	}
+
+	method := opts.Method
+	if method == nil {
+		method = jwt.SigningMethodRS256
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = opts.KeyID
+	if opts.KeyID == "" {
+		token.Header["kid"] = DevKeyID
+	}
+	token.Header["typ"] = "at+jwt"
+	for k, v := range opts.Header {
+		token.Header[k] = v
+	}
+
+	var signingKey any = key
+	switch method.(type) {
+	case *jwt.SigningMethodHMAC:
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			return "", fmt.Errorf("authclienttest: %w", err)
+		}
+		signingKey = der
+	default:
+		if method == jwt.SigningMethodNone {
+			signingKey = jwt.UnsafeAllowNoneSignatureType
+		}
+	}
+	signed, err := token.SignedString(signingKey)
+	if err != nil {
+		return "", fmt.Errorf("authclienttest: sign: %w", err)
+	}
+	return signed, nil
+}
+
+// Fixture is a canned token that a DevIssuer-configured Validator must reject.
+type Fixture struct {
+	Name  string
+	Token string
+	Kind  authclient.ErrorKind // the AuthError kind ValidateToken returns for Token
+}
+
+// Fixture names.
+const (
+	FixtureExpired       = "expired"
+	FixtureWrongAudience = "wrong_audience"
+	FixtureWrongIssuer   = "wrong_issuer"
+	FixtureNotYetValid   = "not_yet_valid"
+	FixtureUnknownKeyID  = "unknown_kid"
+	FixtureWrongAlg      = "wrong_alg"
+	FixtureUnsigned      = "unsigned"
+	FixtureForeignKey    = "foreign_key"
+)
+
+// Fixtures returns one token per validation failure mode, each built from claims and
+// signed for this issuer, so middleware tests can assert their response to every way a token
+// can be bad:
+//
+//	for _, f := range issuer.Fixtures(authclient.Claims{TenantSlug: "acme"}) {
+//		t.Run(f.Name, func(t *testing.T) { ... expect 401 for f.Token ... })
+//	}
+//
+// Like NewDevIssuer, it panics if a key cannot be generated.
+func (d *DevIssuer) Fixtures(claims authclient.Claims) []Fixture {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	with := func(edit func(*authclient.Claims)) authclient.Claims {
+		c := claims
+		edit(&c)
+		return c
+	}
+
+	foreign, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic("authclienttest: generate key: " + err.Error())
+	}
+
+	specs := []struct {
+		name   string
+		key    *rsa.PrivateKey
+		claims authclient.Claims
+		opts   SignOptions
+		kind   authclient.ErrorKind
+	}{
+		{FixtureExpired, d.key, with(func(c *authclient.Claims) {
+			c.IssuedAt = jwt.NewNumericDate(past.Add(-time.Minute))
+			c.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(past)
+		}), SignOptions{}, authclient.KindTokenExpired},
+		{FixtureWrongAudience, d.key, with(func(c *authclient.Claims) { c.Audience = jwt.ClaimStrings{"some-other-api"} }), SignOptions{}, authclient.KindClaimsInvalid},
+		{FixtureWrongIssuer, d.key, with(func(c *authclient.Claims) { c.Issuer = "https://issuer.invalid" }), SignOptions{}, authclient.KindClaimsInvalid},
+		{FixtureNotYetValid, d.key, with(func(c *authclient.Claims) { c.NotBefore = jwt.NewNumericDate(future) }), SignOptions{}, authclient.KindTokenExpired},
+		{FixtureUnknownKeyID, d.key, claims, SignOptions{KeyID: "unknown-kid"}, authclient.KindKeyNotFound},
+		{FixtureWrongAlg, d.key, claims, SignOptions{Method: jwt.SigningMethodHS256}, authclient.KindSignatureInvalid},
+		{FixtureUnsigned, d.key, claims, SignOptions{Method: jwt.SigningMethodNone}, authclient.KindSignatureInvalid},
+		{FixtureForeignKey, foreign, claims, SignOptions{}, authclient.KindSignatureInvalid},
+	}
+
+	fixtures := make([]Fixture, 0, len(specs))
+	for _, s := range specs {
+		token, err := SignToken(s.key, s.claims, s.opts)
+		if err != nil {
+			panic(err.Error())
+		}
+		fixtures = append(fixtures, Fixture{Name: s.name, Token: token, Kind: s.kind})
+	}
+	return fixtures
+}
@@ -0,0 +1,36 @@
+package authclienttest
+
+import (
+	"testing"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+)
+
+func TestFixturesFailWithTheirKind(t *testing.T) {
+	d := NewDevIssuer()
+	defer d.Close()
+	v, err := authclient.NewValidator(d.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Stop()
+
+	claims := authclient.Claims{TenantSlug: "acme"}
+	claims.Subject = "user-1"
+	valid, err := SignToken(d.PrivateKey(), claims, SignOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.ValidateToken(valid); err != nil {
+		t.Fatalf("SignToken with zero options: %v", err)
+	}
+
+	for _, f := range d.Fixtures(claims) {
+		t.Run(f.Name, func(t *testing.T) {
+			_, err := v.ValidateToken(f.Token)
+			if got := authclient.KindOf(err); got != f.Kind {
+				t.Errorf("ValidateToken() kind = %q (%v), want %q", got, err, f.Kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package authcore is the dependency-light core of authclient: JWKS parsing and RS256 access
+// token verification using only the standard library and golang-jwt. It builds for js/wasm
+// and TinyGo, so edge workers can validate auth-service tokens without pulling in gin, zap,
+// uuid or go-redis.
+//
+// authcore does no I/O. The host fetches the JWKS (with fetch() in a worker, or net/http
+// elsewhere) and hands the bytes to a Verifier; on ErrUnknownKey it refetches and calls
+// SetJWKS again.
+package authcore
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// ParseJWKS decodes a JSON Web Key Set and returns its RS256 signing keys by kid. Keys of
+// other types or uses are skipped, as are keys whose modulus or exponent do not decode.
+func ParseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			Alg string `json:"alg"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("authcore: decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || jwk.Use != "sig" || jwk.Alg != "RS256" {
+			continue
+		}
+
+		nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+
+		eBytes, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+
+		var eInt int64
+		for _, b := range eBytes {
+			eInt = eInt<<8 | int64(b)
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(nBytes),
+			E: int(eInt),
+		}
+	}
+	return keys, nil
+}
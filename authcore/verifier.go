@@ -0,0 +1,181 @@
+package authcore
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Verification failures. Verify wraps one of these, so callers can branch with errors.Is.
+var (
+	ErrMalformed    = errors.New("authcore: malformed token")
+	ErrExpired      = errors.New("authcore: token expired or not yet valid")
+	ErrSignature    = errors.New("authcore: signature invalid")
+	ErrUnknownKey   = errors.New("authcore: signing key not in JWKS")
+	ErrInvalidClaim = errors.New("authcore: issuer or audience rejected")
+	ErrTokenType    = errors.New("authcore: not an access token")
+)
+
+// Claims is the identity subset of auth-service access tokens: enough for an edge worker to
+// route and authorize. Decode into your own struct with VerifyInto for anything else.
+type Claims struct {
+	SessionID   string   `json:"sid"`
+	TenantID    string   `json:"tenant_id,omitempty"`
+	TenantSlug  string   `json:"tenant_slug,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	IsService   bool     `json:"is_service,omitempty"`
+	ServiceName string   `json:"service_name,omitempty"`
+
+	jwt.RegisteredClaims
+}
+
+// HasRole reports whether the claims carry role.
+func (c *Claims) HasRole(role string) bool { return slices.Contains(c.Roles, role) }
+
+// HasPermission reports whether the claims carry permission.
+func (c *Claims) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
+}
+
+// Verifier checks RS256 tokens against a JWKS and an expected issuer and audience. It is
+// safe for concurrent use.
+type Verifier struct {
+	issuer   string
+	audience string
+	parser   *jwt.Parser
+
+	mu   sync.RWMutex
+	keys map[string]*rsa.PublicKey
+}
+
+// NewVerifier creates a verifier for tokens from issuer intended for audience; either may be
+// empty to skip that check. jwks is the initial key set.
+func NewVerifier(issuer, audience string, jwks []byte) (*Verifier, error) {
+	v := &Verifier{
+		issuer:   issuer,
+		audience: audience,
+		parser:   jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()})),
+	}
+	if err := v.SetJWKS(jwks); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// SetJWKS replaces the verifier's keys, e.g. after a refetch prompted by ErrUnknownKey.
+func (v *Verifier) SetJWKS(jwks []byte) error {
+	keys, err := ParseJWKS(jwks)
+	if err != nil {
+		return err
+	}
+	v.mu.Lock()
+	v.keys = keys
+	v.mu.Unlock()
+	return nil
+}
+
+// Verify validates token and returns its claims.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	var claims Claims
+	if err := v.VerifyInto(token, &claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// VerifyInto validates token and decodes its payload into claims, which must embed or
+// implement jwt.Claims. Refresh and ID tokens (by token_use or typ claim) are rejected with
+// ErrTokenType, as are tokens whose at+jwt header contradicts those claims.
+func (v *Verifier) VerifyInto(token string, claims jwt.Claims) error {
+	parsed, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		v.mu.RLock()
+		key := v.keys[kid]
+		v.mu.RUnlock()
+		if key == nil {
+			return nil, fmt.Errorf("%w: kid %q", ErrUnknownKey, kid)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return classify(err)
+	}
+	if err := checkTokenKind(parsed); err != nil {
+		return err
+	}
+
+	if v.issuer != "" {
+		if iss, _ := claims.GetIssuer(); iss != v.issuer {
+			return fmt.Errorf("%w: issuer %q", ErrInvalidClaim, iss)
+		}
+	}
+	if v.audience != "" {
+		if aud, _ := claims.GetAudience(); !slices.Contains(aud, v.audience) {
+			return fmt.Errorf("%w: audience %q not present", ErrInvalidClaim, v.audience)
+		}
+	}
+	return nil
+}
+
+// checkTokenKind mirrors authclient's access-token check: the token_use (auth-service) or
+// typ (Keycloak) claim must not name a refresh or ID token, and an RFC 9068 at+jwt header
+// must not be contradicted by them.
+func checkTokenKind(t *jwt.Token) error {
+	var kind struct {
+		TokenUse string `json:"token_use"`
+		Type     string `json:"typ"`
+	}
+	parts := strings.Split(t.Raw, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: token is not a JWS", ErrMalformed)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || json.Unmarshal(payload, &kind) != nil {
+		return fmt.Errorf("%w: payload", ErrMalformed)
+	}
+
+	declared := ""
+	switch strings.ToLower(kind.TokenUse) {
+	case "access", "refresh", "id":
+		declared = strings.ToLower(kind.TokenUse)
+	default:
+		switch strings.ToLower(kind.Type) {
+		case "bearer", "access":
+			declared = "access"
+		case "refresh", "offline":
+			declared = "refresh"
+		case "id":
+			declared = "id"
+		}
+	}
+	if declared == "" || declared == "access" {
+		return nil
+	}
+	if typ, _ := t.Header["typ"].(string); strings.EqualFold(typ, "at+jwt") || strings.EqualFold(typ, "application/at+jwt") {
+		return fmt.Errorf("%w: typ %q contradicts token type %s", ErrTokenType, typ, declared)
+	}
+	return fmt.Errorf("%w: %s token", ErrTokenType, declared)
+}
+
+func classify(err error) error {
+	switch {
+	case errors.Is(err, ErrUnknownKey):
+		return err
+	case errors.Is(err, jwt.ErrTokenExpired), errors.Is(err, jwt.ErrTokenNotValidYet):
+		return fmt.Errorf("%w: %v", ErrExpired, err)
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return fmt.Errorf("%w: %v", ErrSignature, err)
+	case errors.Is(err, jwt.ErrTokenInvalidClaims), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return fmt.Errorf("%w: %v", ErrInvalidClaim, err)
+	}
+	return fmt.Errorf("%w: %v", ErrMalformed, err)
+}
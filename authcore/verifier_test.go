@@ -0,0 +1,96 @@
+package authcore_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"github.com/Bengo-Hub/shared-auth-client/authcore"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := authclient.NewSigner(key, "k1", authclient.SignerConfig{Issuer: "https://sso.example.com", Audience: []string{"edge"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := authcore.NewVerifier("https://sso.example.com", "edge", signer.JWKS())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mint := func(edit func(*authclient.Claims)) string {
+		c := authclient.Claims{TenantSlug: "acme", Roles: []string{"admin"}}
+		c.Subject = "user-1"
+		if edit != nil {
+			edit(&c)
+		}
+		token, err := signer.Sign(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	claims, err := v.Verify(mint(nil))
+	if err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if claims.Subject != "user-1" || claims.TenantSlug != "acme" || !claims.HasRole("admin") {
+		t.Fatalf("claims = %+v", claims)
+	}
+
+	if _, err := v.Verify(mint(func(c *authclient.Claims) { c.TokenUse = "access" })); err != nil {
+		t.Fatalf("token_use access: Verify() = %v", err)
+	}
+	idClaims := authclient.Claims{Type: "ID"}
+	idClaims.Issuer, idClaims.Subject, idClaims.Audience = "https://sso.example.com", "user-1", jwt.ClaimStrings{"edge"}
+	idClaims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Hour))
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodRS256, idClaims)
+	unsigned.Header["kid"] = "k1"
+	idToken, err := unsigned.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"expired", mint(func(c *authclient.Claims) {
+			c.IssuedAt = jwt.NewNumericDate(time.Now().Add(-2 * time.Hour))
+			c.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+		}), authcore.ErrExpired},
+		{"wrong audience", mint(func(c *authclient.Claims) { c.Audience = jwt.ClaimStrings{"other"} }), authcore.ErrInvalidClaim},
+		{"wrong issuer", mint(func(c *authclient.Claims) { c.Issuer = "https://evil.example.com" }), authcore.ErrInvalidClaim},
+		{"malformed", "not.a.jwt", authcore.ErrMalformed},
+		{"refresh token", mint(func(c *authclient.Claims) { c.TokenUse = "refresh" }), authcore.ErrTokenType},
+		{"ID token without at+jwt header", idToken, authcore.ErrTokenType},
+	}
+	for _, tt := range tests {
+		if _, err := v.Verify(tt.token); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Verify() = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rotated, _ := authclient.NewSigner(other, "k2", authclient.SignerConfig{Issuer: "https://sso.example.com", Audience: []string{"edge"}})
+	token, _ := rotated.Sign(authclient.Claims{})
+	if _, err := v.Verify(token); !errors.Is(err, authcore.ErrUnknownKey) {
+		t.Fatalf("unknown kid: Verify() = %v, want ErrUnknownKey", err)
+	}
+	if err := v.SetJWKS(rotated.JWKS()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Verify(token); err != nil {
+		t.Fatalf("after SetJWKS: Verify() = %v", err)
+	}
+}
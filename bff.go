@@ -0,0 +1,346 @@
+package authclient
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// flowCookie carries the OAuth state, PKCE verifier and return path between LoginHandler and
+// CallbackHandler. It is sealed like the session cookies when the session has a Keyring.
+const (
+	flowCookie       = "auth_flow"
+	flowCookieMaxAge = 600
+)
+
+// BFFConfig configures BFF. Only Client and Session are required; set ClientID and
+// RedirectURL to enable the browser redirect flow.
+type BFFConfig struct {
+	Client  *Client
+	Session *CookieSession
+	// Store holds the tokens when Session is ReferenceOnly.
+	Store TokenStore
+
+	// OAuth authorization-code flow with PKCE. AuthorizeURL defaults to auth-service's
+	// /api/v1/auth/authorize; RedirectURL is the absolute URL of CallbackHandler.
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthorizeURL string
+	Scope        string
+	// Credentials supplies the client secret (CredentialClientSecret) when ClientSecret is
+	// empty, so confidential clients need not keep it in static config.
+	Credentials CredentialProvider
+
+	// CrossOrigin guards the POST login, logout and refresh handlers against cross-site
+	// request forgery with the browser's Sec-Fetch-Site and Origin headers. Defaults to an
+	// http.CrossOriginProtection trusting only same-origin requests; pass one with
+	// AddTrustedOrigin when the SPA is served from another origin.
+	CrossOrigin *http.CrossOriginProtection
+
+	// Issuer, when set, must match the iss of front-channel logout requests. Revocations,
+	// when set, receives the sid of sessions ended there through unforgeable cookies (see
+	// FrontChannelLogoutHandler).
+	Issuer      string
+	Revocations *RevocationList
+
+	// PostLoginRedirect is where browsers land after login when no return_to was given;
+	// defaults to "/". PostLogoutRedirect, when set, turns logout into a 303 redirect there
+	// instead of a 204.
+	PostLoginRedirect  string
+	PostLogoutRedirect string
+}
+
+// BFF is a drop-in backend-for-frontend auth layer: login (redirect with PKCE, or posted
+// credentials), OAuth callback, logout and refresh handlers that keep tokens in
+// CookieSession cookies and out of browser JavaScript.
+type BFF struct {
+	config BFFConfig
+}
+
+// NewBFF creates the handlers' shared state, filling in defaults.
+func NewBFF(config BFFConfig) *BFF {
+	if config.AuthorizeURL == "" && config.Client != nil {
+		config.AuthorizeURL = config.Client.baseURL + "/api/v1/auth/authorize"
+	}
+	if config.PostLoginRedirect == "" {
+		config.PostLoginRedirect = "/"
+	}
+	if config.CrossOrigin == nil {
+		config.CrossOrigin = newCrossOriginProtection()
+	}
+	return &BFF{config: config}
+}
+
+// newCrossOriginProtection returns the default CSRF check for the state-changing BFF
+// handlers, answering rejected requests with the usual JSON error body.
+func newCrossOriginProtection() *http.CrossOriginProtection {
+	p := http.NewCrossOriginProtection()
+	p.SetDenyHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeAuthError(w, http.StatusForbidden, "cross-origin request rejected")
+	}))
+	return p
+}
+
+// Register mounts the handlers under prefix (e.g. "/auth"): GET and POST login, GET
+// callback, POST logout, POST refresh and GET frontchannel-logout.
+func (b *BFF) Register(mux *http.ServeMux, prefix string) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	mux.Handle(prefix+"/login", b.LoginHandler())
+	mux.Handle("GET "+prefix+"/callback", b.CallbackHandler())
+	mux.Handle("POST "+prefix+"/logout", b.LogoutHandler())
+	mux.Handle("POST "+prefix+"/refresh", b.RefreshHandler())
+	mux.Handle("GET "+prefix+"/frontchannel-logout", b.FrontChannelLogoutHandler())
+}
+
+// LoginHandler starts a session. GET redirects to auth-service's authorize endpoint with a
+// fresh state and PKCE challenge; an optional return_to query parameter (a local path) is
+// honoured after the callback. POST accepts email, password and tenant_slug as JSON or a
+// form, logs in directly and answers the session expiry as JSON (or a 303 to
+// PostLoginRedirect for form posts). Cross-origin POSTs are rejected with 403 (see
+// BFFConfig.CrossOrigin).
+func (b *BFF) LoginHandler() http.Handler {
+	return b.config.CrossOrigin.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			b.startAuthorization(w, r)
+		case http.MethodPost:
+			b.loginWithCredentials(w, r)
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeAuthError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	}))
+}
+
+func (b *BFF) startAuthorization(w http.ResponseWriter, r *http.Request) {
+	if b.config.ClientID == "" || b.config.RedirectURL == "" {
+		writeAuthError(w, http.StatusNotFound, "redirect login not configured")
+		return
+	}
+	state, err := randomURLToken(16)
+	if err != nil {
+		writeAuthError(w, http.StatusInternalServerError, "could not start login")
+		return
+	}
+	pkce, err := NewPKCE()
+	if err != nil {
+		writeAuthError(w, http.StatusInternalServerError, "could not start login")
+		return
+	}
+	returnTo := localPath(r.URL.Query().Get("return_to"))
+	flow := state + "." + pkce.Verifier + "." + base64.RawURLEncoding.EncodeToString([]byte(returnTo))
+	if err := b.config.Session.set(w, flowCookie, flow, b.config.Session.config.Path, flowCookieMaxAge); err != nil {
+		writeAuthError(w, http.StatusInternalServerError, "could not start login")
+		return
+	}
+
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {b.config.ClientID},
+		"redirect_uri":          {b.config.RedirectURL},
+		"state":                 {state},
+		"code_challenge":        {pkce.Challenge},
+		"code_challenge_method": {"S256"},
+	}
+	if b.config.Scope != "" {
+		q.Set("scope", b.config.Scope)
+	}
+	http.Redirect(w, r, b.config.AuthorizeURL+"?"+q.Encode(), http.StatusFound)
+}
+
+func (b *BFF) loginWithCredentials(w http.ResponseWriter, r *http.Request) {
+	var req LoginRequest
+	isForm := strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded")
+	if isForm {
+		if err := r.ParseForm(); err != nil {
+			writeAuthError(w, http.StatusBadRequest, "malformed form")
+			return
+		}
+		req = LoginRequest{
+			Email:      r.PostForm.Get("email"),
+			Password:   r.PostForm.Get("password"),
+			TenantSlug: r.PostForm.Get("tenant_slug"),
+			RememberMe: r.PostForm.Get("remember_me") != "",
+		}
+	} else if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+		writeAuthError(w, http.StatusBadRequest, "malformed body")
+		return
+	}
+
+	resp, err := b.config.Client.Login(r.Context(), req)
+	if err != nil {
+		if KindOf(err) == KindUpstream {
+			writeAuthError(w, http.StatusServiceUnavailable, "auth-service unavailable")
+			return
+		}
+		writeAuthError(w, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+	resp.RememberMe = resp.RememberMe || req.RememberMe
+	expiry, err := b.establish(w, r, resp)
+	if err != nil {
+		writeAuthError(w, http.StatusInternalServerError, "could not write session")
+		return
+	}
+	if isForm {
+		http.Redirect(w, r, b.config.PostLoginRedirect, http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(expiry)
+}
+
+// CallbackHandler completes the redirect flow: it checks state against the flow cookie,
+// redeems the code with the PKCE verifier, writes the session cookies and redirects to the
+// return path.
+func (b *BFF) CallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session := b.config.Session
+		flow := session.value(r, flowCookie)
+		http.SetCookie(w, session.cookie(flowCookie, "", session.config.Path, -1))
+
+		state, rest, _ := strings.Cut(flow, ".")
+		verifier, encodedReturn, _ := strings.Cut(rest, ".")
+		q := r.URL.Query()
+		if q.Get("error") != "" {
+			writeAuthError(w, http.StatusUnauthorized, "login failed: "+q.Get("error"))
+			return
+		}
+		if state == "" || verifier == "" || q.Get("state") != state {
+			writeAuthError(w, http.StatusBadRequest, "invalid or expired login state")
+			return
+		}
+
+		secret := b.config.ClientSecret
+		if secret == "" && b.config.Credentials != nil {
+			var err error
+			if secret, err = b.config.Credentials.Credential(r.Context(), CredentialClientSecret); err != nil {
+				writeAuthError(w, http.StatusServiceUnavailable, "client credentials unavailable")
+				return
+			}
+		}
+		resp, err := b.config.Client.ExchangeCode(r.Context(), AuthorizationCodeRequest{
+			Code:         q.Get("code"),
+			CodeVerifier: verifier,
+			RedirectURI:  b.config.RedirectURL,
+			ClientID:     b.config.ClientID,
+			ClientSecret: secret,
+		})
+		if err != nil {
+			if b.config.ClientSecret == "" && b.config.Credentials != nil && KindOf(err) == KindInvalidCredentials {
+				// Reload a rotated secret for the next login; this code is already spent.
+				invalidateCredential(b.config.Credentials, CredentialClientSecret)
+			}
+			if KindOf(err) == KindUpstream {
+				writeAuthError(w, http.StatusServiceUnavailable, "auth-service unavailable")
+				return
+			}
+			writeAuthError(w, http.StatusUnauthorized, "login failed")
+			return
+		}
+		if _, err := b.establish(w, r, resp); err != nil {
+			writeAuthError(w, http.StatusInternalServerError, "could not write session")
+			return
+		}
+
+		target := b.config.PostLoginRedirect
+		if raw, err := base64.RawURLEncoding.DecodeString(encodedReturn); err == nil && localPath(string(raw)) != "" {
+			target = string(raw)
+		}
+		http.Redirect(w, r, target, http.StatusSeeOther)
+	})
+}
+
+// LogoutHandler revokes the session's refresh token at auth-service (best effort), drops any
+// stored tokens and clears the cookies. Cross-origin requests are rejected with 403, so other
+// sites cannot sign the user out.
+func (b *BFF) LogoutHandler() http.Handler {
+	return b.config.CrossOrigin.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeAuthError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		session := b.config.Session
+
+		var refreshToken string
+		if session.config.ReferenceOnly {
+			if handle := session.SessionID(r); handle != "" && b.config.Store != nil {
+				if stored, err := b.config.Store.Load(r.Context(), handle); err == nil {
+					refreshToken = stored.Tokens.RefreshToken
+				}
+				_ = b.config.Store.Delete(r.Context(), handle)
+			}
+		} else {
+			_, refreshToken = session.Tokens(r)
+		}
+		if refreshToken != "" {
+			if err := b.config.Client.RevokeToken(r.Context(), refreshToken, "refresh_token"); err != nil {
+				b.config.Client.logger.Warn("auth-service: logout revocation failed", "error", err)
+			}
+		}
+		session.Clear(w)
+
+		if b.config.PostLogoutRedirect != "" {
+			http.Redirect(w, r, b.config.PostLogoutRedirect, http.StatusSeeOther)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+// RefreshHandler is RefreshHandler bound to the BFF's client, session, store and
+// CrossOrigin check.
+func (b *BFF) RefreshHandler() http.Handler {
+	return b.config.CrossOrigin.Handler(refreshHandler(b.config.Client, b.config.Session, b.config.Store))
+}
+
+// establish persists a new session (in Store for ReferenceOnly sessions) and writes its
+// cookies.
+func (b *BFF) establish(w http.ResponseWriter, r *http.Request, resp *AuthResponse) (sessionExpiry, error) {
+	stored := NewStoredTokens(resp, time.Now())
+	expiry := newSessionExpiry(stored)
+	if !b.config.Session.config.ReferenceOnly {
+		return expiry, b.config.Session.Write(w, resp)
+	}
+	if b.config.Store == nil {
+		return expiry, errors.New("bff: ReferenceOnly session requires a Store")
+	}
+	// Every session gets a fresh random handle: auth-service's sid is visible in its tokens
+	// and may be reused across rotations, so it must not double as the browser's credential.
+	handle, err := randomURLToken(32)
+	if err != nil {
+		return expiry, err
+	}
+	if err := b.config.Store.Save(r.Context(), handle, stored); err != nil {
+		return expiry, fmt.Errorf("bff: store session: %w", err)
+	}
+	return expiry, b.config.Session.WriteReference(w, handle, resp)
+}
+
+// localPath returns p when it is a same-origin path ("/orders?x=1"), and "" otherwise, so
+// return_to cannot become an open redirect. Backslashes and control characters are rejected
+// anywhere: browsers treat a backslash as "/" and strip tabs and newlines, so "/\t/evil.com"
+// (return_to=/%09/evil.com) would be followed to "//evil.com".
+func localPath(p string) string {
+	if !strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") {
+		return ""
+	}
+	for _, r := range p {
+		if r == '\\' || r < 0x20 || r == 0x7f {
+			return ""
+		}
+	}
+	u, err := url.Parse(p)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.User != nil {
+		return ""
+	}
+	return p
+}
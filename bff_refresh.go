@@ -0,0 +1,127 @@
+package authclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// sessionExpiry is the body the BFF handlers answer with. Tokens stay in cookies; the SPA
+// only learns when to call again.
+type sessionExpiry struct {
+	ExpiresIn        int        `json:"expires_in"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RefreshExpiresAt *time.Time `json:"refresh_expires_at,omitempty"`
+}
+
+func newSessionExpiry(stored *StoredTokens) sessionExpiry {
+	expiry := sessionExpiry{ExpiresIn: stored.Tokens.ExpiresIn, ExpiresAt: stored.ExpiresAt.UTC()}
+	if !stored.RefreshExpiresAt.IsZero() {
+		t := stored.RefreshExpiresAt.UTC()
+		expiry.RefreshExpiresAt = &t
+	}
+	return expiry
+}
+
+// RefreshHandler returns the BFF refresh endpoint: on POST it reads the refresh token from
+// session's cookies, calls refresher.Refresh (usually a *Client), rotates the cookies and
+// answers {"expires_in", "expires_at"} so the SPA can schedule its next call. Mount it at the
+// session's RefreshPath.
+//
+// With a ReferenceOnly session the tokens live in store, keyed by the session cookie's handle;
+// store is unused otherwise and may be nil. A missing or rejected refresh token answers 401
+// and clears the cookies; an auth-service outage answers 503 and leaves them, so the SPA can
+// retry. Cross-origin requests are rejected with 403 (see http.CrossOriginProtection).
+func RefreshHandler(refresher TokenRefresher, session *CookieSession, store TokenStore) http.Handler {
+	return newCrossOriginProtection().Handler(refreshHandler(refresher, session, store))
+}
+
+func refreshHandler(refresher TokenRefresher, session *CookieSession, store TokenStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeAuthError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+
+		var refreshToken, handle, sid string
+		var rememberMe bool
+		if session.config.ReferenceOnly {
+			handle = session.SessionID(r)
+			if handle != "" && store != nil {
+				stored, err := store.Load(r.Context(), handle)
+				switch {
+				case errors.Is(err, ErrTokensNotFound):
+				case err != nil:
+					writeAuthError(w, http.StatusServiceUnavailable, "session store unavailable")
+					return
+				default:
+					refreshToken = stored.Tokens.RefreshToken
+					rememberMe = stored.Tokens.RememberMe
+					sid = stored.Tokens.SessionID
+				}
+			}
+		} else {
+			_, refreshToken = session.Tokens(r)
+		}
+		if refreshToken == "" {
+			session.Clear(w)
+			writeAuthError(w, http.StatusUnauthorized, "no session")
+			return
+		}
+
+		resp, err := refresher.Refresh(r.Context(), refreshToken)
+		if err != nil {
+			if refreshTokenRejected(err) || KindOf(err) == KindInvalidCredentials {
+				if handle != "" {
+					_ = store.Delete(r.Context(), handle)
+				}
+				session.Clear(w)
+				writeAuthError(w, http.StatusUnauthorized, "session expired")
+				return
+			}
+			writeAuthError(w, http.StatusServiceUnavailable, "auth-service unavailable")
+			return
+		}
+		if resp.RefreshToken == "" {
+			resp.RefreshToken = refreshToken
+		}
+		resp.RememberMe = resp.RememberMe || rememberMe
+		if handle != "" && resp.SessionID == "" {
+			resp.SessionID = sid
+		}
+
+		stored := NewStoredTokens(resp, time.Now())
+
+		if handle != "" {
+			// Keep the handle while auth-service keeps the session; a new sid gets a new one.
+			key := handle
+			if resp.SessionID != sid {
+				var err error
+				if key, err = randomURLToken(32); err != nil {
+					writeAuthError(w, http.StatusInternalServerError, "could not write session")
+					return
+				}
+			}
+			if err := store.Save(r.Context(), key, stored); err != nil {
+				writeAuthError(w, http.StatusServiceUnavailable, "session store unavailable")
+				return
+			}
+			if key != handle {
+				_ = store.Delete(r.Context(), handle)
+			}
+			if err := session.WriteReference(w, key, resp); err != nil {
+				writeAuthError(w, http.StatusInternalServerError, "could not write session")
+				return
+			}
+		} else if err := session.Rotate(w, resp); err != nil {
+			writeAuthError(w, http.StatusInternalServerError, "could not write session")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(newSessionExpiry(stored))
+	})
+}
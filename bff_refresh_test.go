@@ -0,0 +1,67 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type refresherFunc func(ctx context.Context, refreshToken string) (*AuthResponse, error)
+
+func (f refresherFunc) Refresh(ctx context.Context, refreshToken string) (*AuthResponse, error) {
+	return f(ctx, refreshToken)
+}
+
+func TestRefreshHandler(t *testing.T) {
+	refresher := refresherFunc(func(_ context.Context, rt string) (*AuthResponse, error) {
+		if rt != "rt-1" {
+			return nil, &Error{StatusCode: http.StatusUnauthorized, ErrorField: "invalid_grant"}
+		}
+		return &AuthResponse{AccessToken: "at-2", RefreshToken: "rt-2", ExpiresIn: 900, RefreshExpiresIn: 3600}, nil
+	})
+	session := NewCookieSession(CookieSessionConfig{})
+	h := RefreshHandler(refresher, session, nil)
+
+	tests := []struct {
+		name        string
+		method      string
+		refresh     string
+		wantStatus  int
+		wantRefresh string // refresh cookie value set in the response; "" when cleared
+	}{
+		{"rotates", http.MethodPost, "rt-1", http.StatusOK, "rt-2"},
+		{"rejected", http.MethodPost, "stale", http.StatusUnauthorized, ""},
+		{"no cookie", http.MethodPost, "", http.StatusUnauthorized, ""},
+		{"get", http.MethodGet, "rt-1", http.StatusMethodNotAllowed, "-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/auth/refresh", nil)
+			if tt.refresh != "" {
+				req.AddCookie(&http.Cookie{Name: "refresh_token", Value: tt.refresh})
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			got := "-"
+			for _, c := range rec.Result().Cookies() {
+				if c.Name == "refresh_token" {
+					got = c.Value
+				}
+			}
+			if got != tt.wantRefresh {
+				t.Errorf("refresh cookie = %q, want %q", got, tt.wantRefresh)
+			}
+			if rec.Code == http.StatusOK {
+				var body sessionExpiry
+				if err := json.NewDecoder(rec.Body).Decode(&body); err != nil || body.ExpiresIn != 900 || body.ExpiresAt.IsZero() {
+					t.Errorf("body = %+v, %v", body, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package authclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestBFFRedirectFlow(t *testing.T) {
+	var challenge, revoked string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/token":
+			var req AuthorizationCodeRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			sum := sha256.Sum256([]byte(req.CodeVerifier))
+			if req.GrantType != "authorization_code" || req.Code != "code-1" || base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			json.NewEncoder(w).Encode(AuthResponse{AccessToken: "at", RefreshToken: "rt", ExpiresIn: 900})
+		case "/api/v1/auth/revoke":
+			r.ParseForm()
+			revoked = r.PostForm.Get("token")
+		}
+	}))
+	defer srv.Close()
+
+	bff := NewBFF(BFFConfig{
+		Client:      NewClient(srv.URL, nil),
+		Session:     NewCookieSession(CookieSessionConfig{}),
+		ClientID:    "web",
+		RedirectURL: "https://app.example.com/auth/callback",
+	})
+	mux := http.NewServeMux()
+	bff.Register(mux, "/auth")
+
+	// Login redirects to the authorize endpoint with state and an S256 challenge.
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/auth/login?return_to=/orders", nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("login status = %d", rec.Code)
+	}
+	loc, _ := url.Parse(rec.Header().Get("Location"))
+	challenge = loc.Query().Get("code_challenge")
+	state := loc.Query().Get("state")
+	if loc.Path != "/api/v1/auth/authorize" || challenge == "" || state == "" {
+		t.Fatalf("authorize redirect = %s", loc)
+	}
+	flow := rec.Result().Cookies()[0]
+
+	callback := func(state string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/auth/callback?code=code-1&state="+state, nil)
+		req.AddCookie(flow)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+	if rec := callback("forged"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("forged state: status = %d", rec.Code)
+	}
+	rec = callback(state)
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/orders" {
+		t.Fatalf("callback = %d → %q", rec.Code, rec.Header().Get("Location"))
+	}
+	var refresh *http.Cookie
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "refresh_token" {
+			refresh = c
+		}
+	}
+	if refresh == nil || refresh.Value != "rt" {
+		t.Fatalf("refresh cookie = %+v", refresh)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	req.AddCookie(refresh)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent || revoked != "rt" {
+		t.Fatalf("logout = %d, revoked %q", rec.Code, revoked)
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	for p, want := range map[string]string{
+		"/orders?x=1":         "/orders?x=1",
+		"//evil.example.com":  "",
+		"/\\evil.example.com": "",
+		"https://evil.com":    "",
+		"//evil.com":          "",
+		"/\t/evil.com":        "",
+		"/\n/evil.com":        "",
+		"/\r/evil.com":        "",
+		"/a\\b":               "",
+		"":                    "",
+	} {
+		if got := localPath(p); got != want {
+			t.Errorf("localPath(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestBFFReferenceOnlySession(t *testing.T) {
+	var refreshes int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/login":
+			json.NewEncoder(w).Encode(AuthResponse{AccessToken: "at-1", RefreshToken: "rt-1", ExpiresIn: 900, SessionID: "sid-1"})
+		case "/api/v1/auth/refresh":
+			refreshes++
+			json.NewEncoder(w).Encode(AuthResponse{AccessToken: "at-2", RefreshToken: "rt-2", ExpiresIn: 900})
+		}
+	}))
+	defer srv.Close()
+
+	store := NewMemoryTokenStore()
+	bff := NewBFF(BFFConfig{
+		Client:  NewClient(srv.URL, nil),
+		Session: NewCookieSession(CookieSessionConfig{ReferenceOnly: true}),
+		Store:   store,
+	})
+	mux := http.NewServeMux()
+	bff.Register(mux, "/auth")
+	sessionCookie := func(rec *httptest.ResponseRecorder) *http.Cookie {
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == "session_id" {
+				return c
+			}
+		}
+		t.Fatalf("no session cookie set (status %d)", rec.Code)
+		return nil
+	}
+	login := func() *http.Cookie {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{"email":"ada@example.com","password":"pw"}`))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return sessionCookie(rec)
+	}
+
+	first, second := login(), login()
+	if first.Value == "sid-1" || first.Value == second.Value {
+		t.Fatalf("session cookies %q, %q: want fresh random handles, not the sid", first.Value, second.Value)
+	}
+	stored, err := store.Load(context.Background(), first.Value)
+	if err != nil || stored.Tokens.SessionID != "sid-1" || stored.Tokens.RefreshToken != "rt-1" {
+		t.Fatalf("stored session = %+v, %v", stored, err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+	req.AddCookie(first)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || refreshes != 1 || sessionCookie(rec).Value != first.Value {
+		t.Fatalf("refresh = %d after %d refreshes; want the same handle kept", rec.Code, refreshes)
+	}
+	if stored, err := store.Load(context.Background(), first.Value); err != nil || stored.Tokens.RefreshToken != "rt-2" || stored.Tokens.SessionID != "sid-1" {
+		t.Fatalf("stored session after refresh = %+v, %v", stored, err)
+	}
+
+	// The sid is not a credential: a cookie carrying it finds no session.
+	req = httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "sid-1"})
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("refresh with sid cookie = %d, want 401", rec.Code)
+	}
+}
+
+func TestBFFCrossOriginProtection(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(AuthResponse{AccessToken: "at", RefreshToken: "rt", ExpiresIn: 900})
+	}))
+	defer srv.Close()
+
+	trusted := http.NewCrossOriginProtection()
+	if err := trusted.AddTrustedOrigin("https://spa.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	handlers := map[string]*http.ServeMux{}
+	for name, crossOrigin := range map[string]*http.CrossOriginProtection{"default": nil, "trusted": trusted} {
+		mux := http.NewServeMux()
+		NewBFF(BFFConfig{Client: NewClient(srv.URL, nil), Session: NewCookieSession(CookieSessionConfig{}), CrossOrigin: crossOrigin}).Register(mux, "/auth")
+		handlers[name] = mux
+	}
+	standalone := RefreshHandler(NewClient(srv.URL, nil), NewCookieSession(CookieSessionConfig{}), nil)
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		path    string
+		headers map[string]string
+		want    int
+	}{
+		{"login, cross-site", handlers["default"], "/auth/login", map[string]string{"Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"login, foreign origin", handlers["default"], "/auth/login", map[string]string{"Origin": "https://evil.example.com"}, http.StatusForbidden},
+		{"login, same origin", handlers["default"], "/auth/login", map[string]string{"Sec-Fetch-Site": "same-origin"}, http.StatusOK},
+		{"login, non-browser client", handlers["default"], "/auth/login", nil, http.StatusOK},
+		{"login, trusted origin", handlers["trusted"], "/auth/login", map[string]string{"Sec-Fetch-Site": "cross-site", "Origin": "https://spa.example.com"}, http.StatusOK},
+		{"logout, cross-site", handlers["default"], "/auth/logout", map[string]string{"Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"logout, same origin", handlers["default"], "/auth/logout", map[string]string{"Origin": "https://app.example.com"}, http.StatusNoContent},
+		{"refresh, cross-site", handlers["default"], "/auth/refresh", map[string]string{"Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"standalone refresh, cross-site", standalone, "/auth/refresh", map[string]string{"Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"standalone refresh, same origin", standalone, "/auth/refresh", map[string]string{"Sec-Fetch-Site": "same-origin"}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "https://app.example.com"+tt.path, strings.NewReader(`{"email":"ada@example.com","password":"pw"}`))
+			req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "rt"})
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBlockedErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/login":
+			http.Error(w, `{"error":"forbidden","error_code":"geo_block","block":{"country":"XX","rule_id":"r7"}}`, http.StatusForbidden)
+		case "/api/v1/auth/register":
+			http.Error(w, `{"error":"forbidden","error_code":"tenant_disabled"}`, http.StatusForbidden)
+		default: // API key validation
+			if r.Header.Get("X-API-Key") == "key-from-bad-ip" {
+				http.Error(w, `{"error":"forbidden","error_code":"ip_reputation"}`, http.StatusForbidden)
+				return
+			}
+			http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	c := NewClient(srv.URL, nil)
+	_, err := c.Login(ctx, LoginRequest{Email: "ada@acme.test", Password: "pw"})
+	block, ok := AsBlocked(err)
+	var apiErr *Error
+	if !errors.Is(err, ErrBlocked) || !ok || block.Reason != BlockReasonGeo || block.Country != "XX" || !errors.As(err, &apiErr) {
+		t.Fatalf("geo-blocked login: err = %v, block = %+v", err, block)
+	}
+	if _, err = c.Register(ctx, RegisterRequest{Email: "ada@acme.test"}); KindOf(err) != KindForbidden {
+		t.Fatalf("ordinary 403: kind %s, want forbidden", KindOf(err))
+	}
+
+	keys := NewAPIKeyValidator(srv.URL, nil)
+	serve := func(mw *AuthMiddleware, key string) (int, map[string]string) {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set(keys.HeaderName(), key)
+		rec := httptest.NewRecorder()
+		mw.RequireAuth(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).ServeHTTP(rec, req)
+		var body map[string]string
+		_ = json.NewDecoder(rec.Body).Decode(&body)
+		return rec.Code, body
+	}
+
+	if code, body := serve(NewAuthMiddlewareWithAPIKey(nil, keys), "key-from-bad-ip"); code != http.StatusForbidden || body["error"] != "access blocked" {
+		t.Fatalf("default: %d %v", code, body)
+	}
+
+	blocks := map[string]int{}
+	mw := NewAuthMiddlewareWithAPIKey(nil, keys, WithBlockedRequests(BlockedRequestConfig{
+		Observe: func(r *http.Request, b *BlockedError) { blocks[b.Reason]++ },
+	}))
+	if code, body := serve(mw, "key-from-bad-ip"); code != http.StatusForbidden || body["code"] != "blocked" || body["reason"] != BlockReasonIPReputation {
+		t.Fatalf("blocked key: %d %v", code, body)
+	}
+	if code, _ := serve(mw, "wrong-key"); code != http.StatusUnauthorized {
+		t.Fatalf("invalid key: status %d, want 401", code)
+	}
+	if blocks[BlockReasonIPReputation] != 1 || len(blocks) != 1 {
+		t.Fatalf("observed blocks = %v", blocks)
+	}
+}
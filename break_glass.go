@@ -0,0 +1,194 @@
+package authclient
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultBreakGlassHeader carries the emergency credential of WithBreakGlass.
+const DefaultBreakGlassHeader = "X-Break-Glass"
+
+// AuditBreakGlassUsed is emitted for every request admitted by WithBreakGlass.
+const AuditBreakGlassUsed = "break_glass.used"
+
+// BreakGlassConfig configures the emergency fallback of WithBreakGlass.
+type BreakGlassConfig struct {
+	// Credential is the pre-shared emergency secret presented in Header. It should be long,
+	// random, and kept out of day-to-day configuration; see CredentialProvider.
+	Credential string
+	Header     string // defaults to DefaultBreakGlassHeader
+	// Claims are attached to admitted requests. Keep them minimal: a read scope or two, no
+	// roles. Subject defaults to "break-glass".
+	Claims Claims
+	// Methods admitted in break-glass mode; defaults to GET, HEAD and OPTIONS.
+	Methods []string
+
+	// Threshold is how long both the JWKS endpoint and auth-service must have been
+	// unreachable before the credential is honoured, counted from the first failure of the
+	// current outage. An upstream that has never been reached since startup does not count
+	// as down. Required.
+	Threshold time.Duration
+	// Client reports auth-service reachability (Client.Health). Required; its health is only
+	// as recent as its last call, so keep something calling it during an outage, e.g.
+	// HealthHandler probes.
+	Client *Client
+	// Logger receives an error for every admitted request, plus entry and exit notices.
+	// Defaults to the middleware logger, which samples repeated messages; pass an unsampled
+	// logger to keep every line.
+	Logger Logger
+}
+
+// WithBreakGlass lets RequireAuth fall back to a pre-shared emergency credential when
+// JWKS and auth-service have both been unreachable for at least config.Threshold, so
+// read-only endpoints stay available through an identity outage. Normal authentication is
+// always tried first, and the credential is refused again the moment either upstream
+// recovers. Every admitted request is logged at error level and audited as
+// AuditBreakGlassUsed; BreakGlassCount reports the running total. Install it only on routes
+// whose minimal claims are safe to grant to whoever holds the credential.
+func WithBreakGlass(config BreakGlassConfig) AuthMiddlewareOption {
+	return func(a *AuthMiddleware) {
+		if config.Header == "" {
+			config.Header = DefaultBreakGlassHeader
+		}
+		if len(config.Methods) == 0 {
+			config.Methods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+		}
+		if config.Claims.Subject == "" {
+			config.Claims.Subject = "break-glass"
+		}
+		config.Claims = *cloneClaims(&config.Claims)
+		a.breakGlass = &breakGlass{config: config, digest: sha256.Sum256([]byte(config.Credential))}
+	}
+}
+
+// BreakGlassCount returns how many requests were admitted by WithBreakGlass.
+func (a *AuthMiddleware) BreakGlassCount() uint64 {
+	if a.breakGlass == nil {
+		return 0
+	}
+	a.breakGlass.mu.Lock()
+	defer a.breakGlass.mu.Unlock()
+	return a.breakGlass.count
+}
+
+type breakGlass struct {
+	config BreakGlassConfig
+	digest [sha256.Size]byte
+
+	mu     sync.Mutex
+	active bool // last evaluation found both upstreams down
+	count  uint64
+}
+
+// admitBreakGlass returns the break-glass claims when r carries the emergency credential
+// and both upstreams are down.
+func (a *AuthMiddleware) admitBreakGlass(r *http.Request) (*Claims, bool) {
+	b := a.breakGlass
+	if b == nil || b.config.Credential == "" || b.config.Threshold <= 0 || b.config.Client == nil {
+		return nil, false
+	}
+	presented := r.Header.Get(b.config.Header)
+	if presented == "" {
+		return nil, false
+	}
+	logger := b.config.Logger
+	if logger == nil {
+		logger = a.logger
+	}
+	digest := sha256.Sum256([]byte(presented))
+	if subtle.ConstantTimeCompare(digest[:], b.digest[:]) != 1 {
+		logger.Warn("break-glass: invalid emergency credential", "method", r.Method, "path", r.URL.Path)
+		return nil, false
+	}
+
+	down, since := a.upstreamsDown(b.config.Threshold)
+	b.mu.Lock()
+	entered, exited := down && !b.active, !down && b.active
+	b.active = down
+	b.mu.Unlock()
+
+	switch {
+	case entered:
+		logger.Error("break-glass: JWKS and auth-service unreachable, honouring emergency credential", "down_since", since)
+	case exited:
+		logger.Warn("break-glass: upstream recovered, emergency credential no longer honoured")
+	}
+	if !down {
+		return nil, false
+	}
+	if !slices.Contains(b.config.Methods, r.Method) {
+		logger.Error("break-glass: method not allowed in break-glass mode", "method", r.Method, "path", r.URL.Path)
+		return nil, false
+	}
+
+	b.mu.Lock()
+	b.count++
+	b.mu.Unlock()
+	logger.Error("break-glass: request admitted with emergency credential", "method", r.Method, "path", r.URL.Path, "remote_addr", r.RemoteAddr)
+	emitAudit(r.Context(), a.audit, AuditEvent{
+		Type:       AuditBreakGlassUsed,
+		Outcome:    AuditOutcomeSuccess,
+		Actor:      b.config.Claims.Subject,
+		TenantID:   b.config.Claims.TenantID,
+		Attributes: map[string]any{"method": r.Method, "path": r.URL.Path, "remote_addr": r.RemoteAddr},
+	})
+	return cloneClaims(&b.config.Claims), true
+}
+
+// upstreamsDown reports whether JWKS (of every registered issuer, with a
+// ValidatorRegistry) and auth-service have all been failing for at least threshold, and
+// since when (the latest start of their outages). Upstreams that never succeeded are not
+// down: their outage has no start to measure from.
+func (a *AuthMiddleware) upstreamsDown(threshold time.Duration) (bool, time.Time) {
+	var jwks []JWKSHealth
+	if a.registry != nil {
+		for _, h := range a.registry.Health() {
+			jwks = append(jwks, h)
+		}
+	} else {
+		jwks = append(jwks, a.validator.Health())
+	}
+	if len(jwks) == 0 {
+		return false, time.Time{}
+	}
+	svc := a.breakGlass.config.Client.Health()
+	if !outageExceeds(svc.LastSuccess, svc.FailingSince, threshold) {
+		return false, time.Time{}
+	}
+	since := svc.FailingSince
+	for _, h := range jwks {
+		if !outageExceeds(h.LastFetch, h.FailingSince, threshold) {
+			return false, time.Time{}
+		}
+		if h.FailingSince.After(since) {
+			since = h.FailingSince
+		}
+	}
+	return true, since
+}
+
+// outageExceeds reports whether an upstream last reached at lastSuccess has been failing
+// since failingSince for at least threshold.
+func outageExceeds(lastSuccess, failingSince time.Time, threshold time.Duration) bool {
+	return !lastSuccess.IsZero() && !failingSince.IsZero() && time.Since(failingSince) >= threshold
+}
+
+// cloneClaims copies c with its own slices and maps, so break-glass claims handed to one
+// request cannot be changed by another or by the caller of WithBreakGlass.
+func cloneClaims(c *Claims) *Claims {
+	clone := *c
+	clone.Scope = slices.Clone(c.Scope)
+	clone.AMR = slices.Clone(c.AMR)
+	clone.Roles = slices.Clone(c.Roles)
+	clone.Permissions = slices.Clone(c.Permissions)
+	clone.SubscriptionFeatures = slices.Clone(c.SubscriptionFeatures)
+	clone.SubscriptionLimits = maps.Clone(c.SubscriptionLimits)
+	clone.Extra = maps.Clone(c.Extra)
+	clone.Audience = slices.Clone(c.Audience)
+	return &clone
+}
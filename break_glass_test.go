@@ -0,0 +1,104 @@
+package authclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWithBreakGlass(t *testing.T) {
+	v, token := newBenchValidator(t)
+	c := NewClient("http://auth-service.invalid", nil)
+	scopes := ScopeList{"orders:read"}
+	mw := NewAuthMiddleware(v, WithBreakGlass(BreakGlassConfig{
+		Credential: "emergency-secret",
+		Claims:     Claims{Scope: scopes},
+		Threshold:  time.Minute,
+		Client:     c,
+	}))
+	var got *Claims
+	h := mw.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = ClaimsFromContext(r.Context())
+	}))
+	serve := func(method, header, bearer string) int {
+		req := httptest.NewRequest(method, "/orders", nil)
+		if header != "" {
+			req.Header.Set(DefaultBreakGlassHeader, header)
+		}
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		got = nil
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	// setOutage makes both upstreams fail, starting jwksDown and authDown ago after a
+	// success an hour ago; a zero lastSuccess means the upstream was never reached.
+	setOutage := func(jwksDown, authDown time.Duration, lastSuccess time.Time) {
+		v.keysMu.Lock()
+		v.lastFetch = lastSuccess
+		v.lastErr = errors.New("connection refused")
+		v.failingSince = time.Now().Add(-jwksDown)
+		v.keysMu.Unlock()
+		c.reach.record(errors.New("connection refused"))
+		c.reach.mu.Lock()
+		c.reach.health.LastSuccess = lastSuccess
+		c.reach.health.FailingSince = time.Now().Add(-authDown)
+		c.reach.mu.Unlock()
+	}
+	anHourAgo := time.Now().Add(-time.Hour)
+
+	if code := serve(http.MethodGet, "emergency-secret", ""); code != http.StatusUnauthorized {
+		t.Fatalf("healthy upstreams: status %d, want 401", code)
+	}
+
+	// The outage is measured from its first failure, not from the last success.
+	setOutage(2*time.Minute, 30*time.Second, anHourAgo)
+	if code := serve(http.MethodGet, "emergency-secret", ""); code != http.StatusUnauthorized {
+		t.Fatalf("auth-service down below threshold: status %d, want 401", code)
+	}
+	setOutage(2*time.Minute, 2*time.Minute, time.Time{})
+	if code := serve(http.MethodGet, "emergency-secret", ""); code != http.StatusUnauthorized {
+		t.Fatalf("upstreams never reached: status %d, want 401", code)
+	}
+
+	setOutage(2*time.Minute, 2*time.Minute, anHourAgo)
+	tests := []struct {
+		name, method, credential string
+		want                     int
+	}{
+		{"admitted", http.MethodGet, "emergency-secret", http.StatusOK},
+		{"wrong credential", http.MethodGet, "guess", http.StatusUnauthorized},
+		{"write method", http.MethodPost, "emergency-secret", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		if code := serve(tt.method, tt.credential, ""); code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, code, tt.want)
+		}
+	}
+	if code := serve(http.MethodGet, "emergency-secret", ""); code != http.StatusOK || got.Subject != "break-glass" || !got.HasScope("orders:read") {
+		t.Fatalf("break-glass claims = %+v (status %d)", got, code)
+	}
+	// Neither the caller's slice nor a handler's changes reach later requests.
+	scopes[0] = "orders:write"
+	got.Scope[0] = "admin"
+	if serve(http.MethodGet, "emergency-secret", ""); !slices.Equal(got.Scope, ScopeList{"orders:read"}) {
+		t.Fatalf("break-glass scopes = %v, want [orders:read]", got.Scope)
+	}
+	if code := serve(http.MethodGet, "", token); code != http.StatusOK || got.Subject == "break-glass" {
+		t.Fatalf("valid token during outage should use its own claims, got %+v", got)
+	}
+	if n := mw.BreakGlassCount(); n != 3 {
+		t.Fatalf("BreakGlassCount = %d, want 3", n)
+	}
+
+	// Recovery of either upstream ends break-glass mode.
+	c.reach.record(nil)
+	if code := serve(http.MethodGet, "emergency-secret", ""); code != http.StatusUnauthorized {
+		t.Fatalf("after recovery: status %d, want 401", code)
+	}
+}
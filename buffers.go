@@ -0,0 +1,31 @@
+package authclient
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer keeps unusually large bodies (bulk responses, error pages) from pinning
+// memory in the pool.
+const maxPooledBuffer = 64 << 10
+
+var bodyBuffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// readBody reads r into a pooled buffer. Release it with putBuffer once nothing refers to
+// its bytes; everything decoded from them (json, protobuf, redacted logs) is a copy.
+func readBody(r io.Reader) (*bytes.Buffer, error) {
+	buf := bodyBuffers.Get().(*bytes.Buffer)
+	buf.Reset()
+	if _, err := buf.ReadFrom(r); err != nil {
+		putBuffer(buf)
+		return nil, err
+	}
+	return buf, nil
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bodyBuffers.Put(buf)
+	}
+}
@@ -0,0 +1,82 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var syncResponseBody = []byte(`{"user_id":"7d3e9f1a-2b4c-4d5e-8f6a-0b1c2d3e4f5a","email":"ada@example.com","tenant_id":"5b8e2d7c-0f4a-4c1e-9a3b-6d2f8e1c0a7b","created":true,"message":"user synced"}`)
+
+// BenchmarkDecodeSyncResponse compares the previous response handling (io.ReadAll, then a
+// struct and a map decode) with pooled buffers and the single-pass lenient decode.
+func BenchmarkDecodeSyncResponse(b *testing.B) {
+	b.Run("readall", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			data, err := io.ReadAll(bytes.NewReader(syncResponseBody))
+			if err != nil {
+				b.Fatal(err)
+			}
+			var out SyncUserResponse
+			var all map[string]json.RawMessage
+			if json.Unmarshal(data, &out) != nil || json.Unmarshal(data, &all) != nil {
+				b.Fatal("decode failed")
+			}
+		}
+	})
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			buf, err := readBody(bytes.NewReader(syncResponseBody))
+			if err != nil {
+				b.Fatal(err)
+			}
+			var out SyncUserResponse
+			if err := decodeJSON(buf.Bytes(), &out, DecodeLenient); err != nil {
+				b.Fatal(err)
+			}
+			putBuffer(buf)
+		}
+	})
+}
+
+func BenchmarkSyncUser(b *testing.B) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write(syncResponseBody)
+	}))
+	defer srv.Close()
+	client := NewClient(srv.URL, nil)
+	req := SyncUserRequest{Email: "ada@example.com", TenantSlug: "acme", Service: "orders"}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.SyncUser(context.Background(), req, "key"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestPooledBodyNotRetained(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"user_id":"u1","email":"ada@example.com","plan":{"tier":2}}`))
+	}))
+	defer srv.Close()
+	client := NewClient(srv.URL, nil)
+	resp, err := client.SyncUser(context.Background(), SyncUserRequest{Email: "ada@example.com"}, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Reuse the pooled buffers; decoded values must be unaffected.
+	for range 10 {
+		buf, _ := readBody(bytes.NewReader(bytes.Repeat([]byte("x"), 128)))
+		putBuffer(buf)
+	}
+	if resp.UserID != "u1" || resp.Email != "ada@example.com" || string(resp.Extra["plan"]) != `{"tier":2}` {
+		t.Fatalf("response corrupted after buffer reuse: %+v extra=%s", resp, resp.Extra["plan"])
+	}
+}
@@ -0,0 +1,246 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BulkImportConfig configures a BulkImporter. Zero values select the defaults noted.
+type BulkImportConfig struct {
+	Client *Client
+	APIKey string // admin API key for the sync endpoints
+
+	BatchSize     int           // users per SyncUsers call, defaults to 100
+	FlushInterval time.Duration // a partial batch is sent after waiting this long, defaults to 1 second
+	Concurrency   int           // batches in flight, defaults to 4
+
+	// MaxRetries bounds retries of a batch after rate limiting or an outage, defaults to 5.
+	// Rate-limited retries wait for auth-service's Retry-After; others back off
+	// exponentially from MinBackoff to MaxBackoff (defaults 1 and 30 seconds).
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// BulkImportResult is the outcome of one imported user.
+type BulkImportResult struct {
+	Request  SyncUserRequest
+	Response *SyncUserResponse
+	Err      error
+}
+
+// BulkImporter syncs a stream of users into auth-service in batches with bounded concurrency.
+// It stops reading its input while every batch slot is busy, and when auth-service answers
+// 429 all workers pause for the Retry-After interval, so a large import slows down instead of
+// failing. Against an auth-service without the batch endpoint it falls back to one SyncUser
+// call per user.
+type BulkImporter struct {
+	config BulkImportConfig
+
+	mu          sync.Mutex
+	pausedUntil time.Time // set from Retry-After; shared by all workers
+	noBatch     bool      // the batch endpoint is not available
+}
+
+// NewBulkImporter creates a BulkImporter, filling in defaults.
+func NewBulkImporter(config BulkImportConfig) *BulkImporter {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 4
+	}
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = 5
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = time.Second
+	}
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = max(30*time.Second, config.MinBackoff)
+	}
+	return &BulkImporter{config: config}
+}
+
+// Run imports users from in until it is closed, sending one BulkImportResult per user on
+// results (in completion order, not input order). Run closes results when it returns. If ctx
+// is cancelled Run stops promptly and returns ctx.Err(); users without a result by then may
+// or may not have been synced and should be retried, which SyncUser makes safe.
+func (b *BulkImporter) Run(ctx context.Context, in <-chan SyncUserRequest, results chan<- BulkImportResult) error {
+	defer close(results)
+	slots := make(chan struct{}, b.config.Concurrency)
+	var wg sync.WaitGroup
+	dispatch := func(batch []SyncUserRequest) {
+		slots <- struct{}{} // blocks, and so stops reading in, while every slot is busy
+		wg.Add(1)
+		go func() {
+			defer func() { <-slots; wg.Done() }()
+			for _, r := range b.importBatch(ctx, batch) {
+				select {
+				case results <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	timer := time.NewTimer(b.config.FlushInterval)
+	timer.Stop()
+	var batch []SyncUserRequest
+	flush := func() {
+		timer.Stop()
+		if len(batch) > 0 {
+			dispatch(batch)
+			batch = nil
+		}
+	}
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case req, ok := <-in:
+			if !ok {
+				flush()
+				break loop
+			}
+			if len(batch) == 0 {
+				timer.Reset(b.config.FlushInterval)
+			}
+			batch = append(batch, req)
+			if len(batch) >= b.config.BatchSize {
+				flush()
+			}
+		case <-timer.C:
+			flush()
+		}
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// importBatch syncs one batch, retrying the whole batch on rate limiting and outages.
+func (b *BulkImporter) importBatch(ctx context.Context, batch []SyncUserRequest) []BulkImportResult {
+	out := make([]BulkImportResult, len(batch))
+	for i, req := range batch {
+		out[i].Request = req
+	}
+
+	if !b.batchUnavailable() {
+		var synced []SyncUserResult
+		err := b.retry(ctx, func() (err error) {
+			synced, err = b.config.Client.SyncUsers(ctx, batch, b.config.APIKey)
+			return err
+		})
+		switch {
+		case err == nil:
+			for i, r := range synced {
+				out[i].Response, out[i].Err = r.Response, r.Err
+			}
+			return out
+		case batchEndpointMissing(err):
+			b.mu.Lock()
+			b.noBatch = true
+			b.mu.Unlock()
+			b.config.Client.logger.Warn("auth-service: user sync batch endpoint unavailable, importing one user at a time")
+		default:
+			for i := range out {
+				out[i].Err = err
+			}
+			return out
+		}
+	}
+
+	for i, req := range batch {
+		out[i].Err = b.retry(ctx, func() (err error) {
+			out[i].Response, err = b.config.Client.SyncUser(ctx, req, b.config.APIKey)
+			return err
+		})
+	}
+	return out
+}
+
+func (b *BulkImporter) batchUnavailable() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.noBatch
+}
+
+// batchEndpointMissing reports whether err means auth-service has no batch sync endpoint.
+func batchEndpointMissing(err error) bool {
+	var ae *AuthError
+	if !errors.As(err, &ae) {
+		return false
+	}
+	switch ae.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// retry calls fn until it succeeds, fails permanently, or MaxRetries is exhausted. Rate
+// limiting pauses every worker of the importer, not just this one.
+func (b *BulkImporter) retry(ctx context.Context, fn func() error) error {
+	backoff := b.config.MinBackoff
+	for attempt := 0; ; attempt++ {
+		if err := b.waitPause(ctx); err != nil {
+			return err
+		}
+		err := fn()
+		if err == nil || attempt >= b.config.MaxRetries || ctx.Err() != nil {
+			return err
+		}
+		var ae *AuthError
+		if !errors.As(err, &ae) {
+			return err
+		}
+		switch {
+		case ae.Kind == KindRateLimited:
+			wait := ae.RetryAfter
+			if wait <= 0 {
+				wait = backoff
+			}
+			b.pause(wait)
+		case ae.Kind == KindUpstream:
+			b.sleep(ctx, backoff)
+		default:
+			return err
+		}
+		backoff = min(backoff*2, b.config.MaxBackoff)
+	}
+}
+
+func (b *BulkImporter) pause(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until := time.Now().Add(d); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+func (b *BulkImporter) waitPause(ctx context.Context) error {
+	b.mu.Lock()
+	wait := time.Until(b.pausedUntil)
+	b.mu.Unlock()
+	if wait > 0 {
+		b.sleep(ctx, wait)
+	}
+	return ctx.Err()
+}
+
+func (b *BulkImporter) sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
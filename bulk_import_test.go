@@ -0,0 +1,109 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBulkImporter(t *testing.T) {
+	tests := []struct {
+		name      string
+		batch     bool // server implements the batch endpoint
+		wantCalls int32
+	}{
+		{"batched", true, 3 + 1}, // 7 users in batches of 3, plus one rate-limited attempt
+		{"single-call fallback", false, 1 + 7 + 1},
+	}
+	for _, tt := range tests {
+		var calls, limited atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if limited.CompareAndSwap(0, 1) {
+				http.Error(w, `{"error":"rate_limited"}`, http.StatusTooManyRequests)
+				return
+			}
+			switch r.URL.Path {
+			case "/api/v1/admin/users/sync/batch":
+				if !tt.batch {
+					http.NotFound(w, r)
+					return
+				}
+				var in struct{ Users []SyncUserRequest }
+				json.NewDecoder(r.Body).Decode(&in)
+				var results []map[string]any
+				for _, u := range in.Users {
+					results = append(results, map[string]any{"user_id": "u-" + u.Email})
+				}
+				json.NewEncoder(w).Encode(map[string]any{"results": results})
+			case "/api/v1/admin/users/sync":
+				var u SyncUserRequest
+				json.NewDecoder(r.Body).Decode(&u)
+				json.NewEncoder(w).Encode(map[string]any{"user_id": "u-" + u.Email})
+			}
+		}))
+
+		importer := NewBulkImporter(BulkImportConfig{
+			Client:        NewClient(srv.URL, nil),
+			APIKey:        "key",
+			BatchSize:     3,
+			FlushInterval: 10 * time.Millisecond,
+			Concurrency:   1,
+			MinBackoff:    time.Millisecond,
+		})
+		in := make(chan SyncUserRequest)
+		results := make(chan BulkImportResult)
+		done := make(chan error, 1)
+		go func() { done <- importer.Run(context.Background(), in, results) }()
+		go func() {
+			for _, email := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+				in <- SyncUserRequest{Email: email, TenantSlug: "acme"}
+			}
+			close(in)
+		}()
+
+		got := map[string]string{}
+		for r := range results {
+			if r.Err != nil {
+				t.Errorf("%s: %s: %v", tt.name, r.Request.Email, r.Err)
+				continue
+			}
+			got[r.Request.Email] = r.Response.UserID
+		}
+		if err := <-done; err != nil {
+			t.Errorf("%s: Run = %v", tt.name, err)
+		}
+		srv.Close()
+		if len(got) != 7 || got["g"] != "u-g" {
+			t.Errorf("%s: results = %v", tt.name, got)
+		}
+		if calls.Load() != tt.wantCalls {
+			t.Errorf("%s: %d calls to auth-service, want %d", tt.name, calls.Load(), tt.wantCalls)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"7", 7 * time.Second},
+		{"-1", 0},
+		{"soon", 0},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0}, // in the past
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+	if got := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)); got < 58*time.Second || got > time.Minute {
+		t.Errorf("parseRetryAfter(HTTP-date) = %v, want ~1m", got)
+	}
+}
@@ -0,0 +1,174 @@
+// Package casbinauth enforces Casbin policies for requests authenticated by authclient. It maps
+// Claims (subject, roles, tenant) onto Casbin request values, so teams standardized on Casbin
+// models keep their policies while auth-service remains the identity source.
+//
+// The package depends only on the two enforcer methods it calls, so *casbin.Enforcer and
+// *casbin.SyncedEnforcer both work without authclient importing Casbin. Use a SyncedEnforcer
+// when policies are reloaded while serving.
+package casbinauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+)
+
+// Enforcer is the subset of a Casbin enforcer used by Authorizer.
+type Enforcer interface {
+	Enforce(rvals ...any) (bool, error)
+	LoadPolicy() error
+}
+
+// Authorizer checks Claims against a Casbin enforcer. A request is allowed when the subject,
+// or any of its roles, is allowed by the policy.
+type Authorizer struct {
+	enforcer   Enforcer
+	subject    func(*authclient.Claims) string
+	domain     func(*authclient.Claims) string
+	rolePrefix string
+	object     func(*http.Request) (obj, act string)
+	logger     authclient.Logger
+}
+
+// Option configures an Authorizer.
+type Option func(*Authorizer)
+
+// WithSubject sets how the Casbin subject is derived from claims. The default is the token
+// subject (user ID), or the service name for service accounts.
+func WithSubject(fn func(*authclient.Claims) string) Option {
+	return func(a *Authorizer) { a.subject = fn }
+}
+
+// WithTenantDomain enforces with a domain argument (sub, dom, obj, act) for models using RBAC
+// with domains. The domain is the tenant slug, or the tenant ID when no slug is set.
+func WithTenantDomain() Option {
+	return func(a *Authorizer) {
+		a.domain = func(c *authclient.Claims) string {
+			if c.TenantSlug != "" {
+				return c.TenantSlug
+			}
+			return c.TenantID
+		}
+	}
+}
+
+// WithRolePrefix prefixes role names before enforcement (e.g. "role:"), for policies that
+// keep users and roles in separate namespaces.
+func WithRolePrefix(prefix string) Option {
+	return func(a *Authorizer) { a.rolePrefix = prefix }
+}
+
+// WithRequestMapper sets how Middleware derives the Casbin object and action from a request.
+// The default is the URL path and the HTTP method, for keyMatch/regexMatch models.
+func WithRequestMapper(fn func(*http.Request) (obj, act string)) Option {
+	return func(a *Authorizer) { a.object = fn }
+}
+
+// WithLogger logs enforcement and policy reload errors.
+func WithLogger(logger authclient.Logger) Option {
+	return func(a *Authorizer) { a.logger = logger }
+}
+
+// New creates an Authorizer for enforcer.
+func New(enforcer Enforcer, opts ...Option) *Authorizer {
+	a := &Authorizer{
+		enforcer: enforcer,
+		subject: func(c *authclient.Claims) string {
+			if c.IsService && c.ServiceName != "" {
+				return c.ServiceName
+			}
+			return c.Subject
+		},
+		object: func(r *http.Request) (string, string) { return r.URL.Path, r.Method },
+		logger: authclient.NopLogger(),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Enforce reports whether claims may perform act on obj.
+func (a *Authorizer) Enforce(claims *authclient.Claims, obj, act string) (bool, error) {
+	subjects := make([]string, 0, 1+len(claims.Roles))
+	if sub := a.subject(claims); sub != "" {
+		subjects = append(subjects, sub)
+	}
+	for _, role := range claims.Roles {
+		subjects = append(subjects, a.rolePrefix+role)
+	}
+	for _, sub := range subjects {
+		rvals := []any{sub, obj, act}
+		if a.domain != nil {
+			rvals = []any{sub, a.domain(claims), obj, act}
+		}
+		ok, err := a.enforcer.Enforce(rvals...)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Middleware enforces the policy for each request using the mapped object and action. It
+// answers 403 on deny and 500 when enforcement fails. Mount it after RequireAuth.
+func (a *Authorizer) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := authclient.ClaimsFromContext(r.Context())
+			if !ok {
+				writeError(w, http.StatusUnauthorized, "missing claims", "unauthorized")
+				return
+			}
+			obj, act := a.object(r)
+			allowed, err := a.Enforce(claims, obj, act)
+			if err != nil {
+				a.logger.Error("casbin enforce failed", "object", obj, "action", act, "error", err)
+				writeError(w, http.StatusInternalServerError, "authorization failed", "internal_error")
+				return
+			}
+			if !allowed {
+				writeError(w, http.StatusForbidden, "forbidden by policy", "policy_denied")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ReloadPolicy reloads policies from the enforcer's adapter.
+func (a *Authorizer) ReloadPolicy() error {
+	return a.enforcer.LoadPolicy()
+}
+
+// AutoReload reloads policies every interval until ctx is cancelled. Failed reloads are logged
+// and the previous policy stays in effect.
+func (a *Authorizer) AutoReload(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.ReloadPolicy(); err != nil {
+				a.logger.Warn("casbin policy reload failed", "error", err)
+			}
+		}
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"error": message,
+		"code":  code,
+	})
+}
@@ -0,0 +1,60 @@
+package casbinauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// policyEnforcer allows the listed "sub dom obj act" (or "sub obj act") tuples.
+type policyEnforcer map[string]bool
+
+func (p policyEnforcer) Enforce(rvals ...any) (bool, error) {
+	key := ""
+	for i, v := range rvals {
+		if i > 0 {
+			key += " "
+		}
+		key += v.(string)
+	}
+	return p[key], nil
+}
+
+func (p policyEnforcer) LoadPolicy() error { return nil }
+
+func TestAuthorizerMiddleware(t *testing.T) {
+	enforcer := policyEnforcer{
+		"role:manager acme /orders DELETE": true,
+		"u1 acme /orders GET":              true,
+	}
+	authz := New(enforcer, WithTenantDomain(), WithRolePrefix("role:"))
+	handler := authz.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	user := func(roles ...string) *authclient.Claims {
+		return &authclient.Claims{TenantSlug: "acme", Roles: roles, RegisteredClaims: jwt.RegisteredClaims{Subject: "u1"}}
+	}
+	tests := []struct {
+		name   string
+		method string
+		claims *authclient.Claims
+		want   int
+	}{
+		{"subject policy", http.MethodGet, user(), http.StatusOK},
+		{"role policy", http.MethodDelete, user("manager"), http.StatusOK},
+		{"no policy", http.MethodDelete, user("cashier"), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/orders", nil)
+			req = req.WithContext(authclient.ContextWithClaims(req.Context(), tt.claims))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
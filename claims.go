@@ -1,6 +1,10 @@
 package authclient
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -21,12 +25,19 @@ func (c *Claims) ExpiresAt() *time.Time {
 // Includes subscription data for feature gating without per-request lookups.
 type Claims struct {
 	// Core identity
-	SessionID       string   `json:"sid"`
-	TenantID        string   `json:"tenant_id,omitempty"`
-	TenantSlug      string   `json:"tenant_slug,omitempty"`
-	Scope           []string `json:"scope,omitempty"`
-	Email           string   `json:"email,omitempty"`
-	IsPlatformOwner bool     `json:"is_platform_owner,omitempty"`
+	SessionID       string    `json:"sid"`
+	TokenUse        string    `json:"token_use,omitempty"` // "access", "refresh" or "id" (see TokenKind)
+	Type            string    `json:"typ,omitempty"`       // Keycloak-style token type claim ("Bearer", "Refresh", "ID")
+	TenantID        string    `json:"tenant_id,omitempty"`
+	TenantSlug      string    `json:"tenant_slug,omitempty"`
+	Scope           ScopeList `json:"scope,omitempty"`
+	Email           string    `json:"email,omitempty"`
+	IsPlatformOwner bool      `json:"is_platform_owner,omitempty"`
+
+	// Authentication context (OIDC) — inputs for step-up and session-age policies
+	AMR      []string         `json:"amr,omitempty"`       // authentication methods, e.g. ["pwd","otp"] or ["mfa"]
+	ACR      string           `json:"acr,omitempty"`       // authentication context class reference
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"` // when the user actually authenticated
 
 	// Outlet / branch context — set when a single outlet is selected at login or via select-outlet.
 	// Empty for HQ/admin users who can see all outlets and use X-Outlet-ID header instead.
@@ -61,6 +72,10 @@ type Claims struct {
 	Permissions []string `json:"permissions,omitempty"`  // Canonical permission codes
 	IsService   bool     `json:"is_service,omitempty"`   // true if this is a service account, not a user
 
+	// Extra holds claims this struct does not model (plan, org_id, feature flags, ...).
+	// Read them with GetString, GetBool or Decode.
+	Extra map[string]json.RawMessage `json:"-"`
+
 	jwt.RegisteredClaims
 }
 
@@ -99,6 +114,25 @@ func (c *Claims) GetTenantSlug() string {
 	return c.TenantSlug
 }
 
+// TenantRef identifies a tenant by both its UUID and its slug.
+type TenantRef struct {
+	ID   string
+	Slug string
+}
+
+// Matches reports whether identifier names this tenant, by ID or (case-insensitively) by slug.
+func (t TenantRef) Matches(identifier string) bool {
+	if identifier == "" {
+		return false
+	}
+	return identifier == t.ID || (t.Slug != "" && strings.EqualFold(identifier, t.Slug))
+}
+
+// TenantRef returns the token's tenant ID and slug together.
+func (c *Claims) TenantRef() TenantRef {
+	return TenantRef{ID: c.TenantID, Slug: c.TenantSlug}
+}
+
 // GetOutletID returns the outlet ID from claims, or empty string if not set.
 // HQ/admin users may have an empty OutletID; they use X-Outlet-ID header for drill-down.
 func (c *Claims) GetOutletID() string {
@@ -111,6 +145,65 @@ func (c *Claims) CanAccessAllOutlets() bool {
 	return c.IsPlatformOwner || c.IsAdmin() || c.IsHQUser
 }
 
+// HasAMR checks if the user authenticated with a specific method (RFC 8176 value, e.g. "otp").
+func (c *Claims) HasAMR(method string) bool {
+	for _, m := range c.AMR {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthenticatedWithMFA reports whether the session was established with multiple factors:
+// either amr carries "mfa", or it combines a knowledge factor with a possession/inherence one.
+func (c *Claims) AuthenticatedWithMFA() bool {
+	if c.HasAMR("mfa") {
+		return true
+	}
+	knowledge, other := false, false
+	for _, m := range c.AMR {
+		switch m {
+		case "pwd", "pin", "kba":
+			knowledge = true
+		case "otp", "sms", "tel", "hwk", "swk", "sc", "fpt", "face", "iris", "retina", "vbm", "pop":
+			other = true
+		}
+	}
+	return knowledge && other
+}
+
+// AuthAge returns how long ago the user authenticated (auth_time). ok is false when the token
+// carries no auth_time, in which case callers should treat the age as unknown.
+func (c *Claims) AuthAge() (time.Duration, bool) {
+	if c.AuthTime == nil {
+		return 0, false
+	}
+	return time.Since(c.AuthTime.Time), true
+}
+
+// ScopeList is the token's scopes. It unmarshals from either a JSON array or an OAuth2-style
+// space-delimited string ("read:orders write:orders") and always marshals as an array.
+type ScopeList []string
+
+// UnmarshalJSON accepts both the array and the space-delimited string representations.
+func (s *ScopeList) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimLeft(data, " \t\r\n"); len(trimmed) == 0 || trimmed[0] != '[' {
+		var single string
+		if err := json.Unmarshal(data, &single); err != nil {
+			return fmt.Errorf("scope must be a string or an array of strings: %w", err)
+		}
+		*s = strings.Fields(single)
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("scope must be a string or an array of strings: %w", err)
+	}
+	*s = list
+	return nil
+}
+
 // HasScope checks if the token has a specific scope.
 func (c *Claims) HasScope(scope string) bool {
 	for _, s := range c.Scope {
@@ -175,6 +268,30 @@ func (c *Claims) HasAllPermissions(permissions ...string) bool {
 	return true
 }
 
+// Can reports whether the token grants action on resource via a "resource:action" permission.
+// Wildcards are honoured on either side: "orders:*", "*:read" and "*" (or "*:*"). This covers
+// common fine-grained checks locally without a remote authorization call.
+func (c *Claims) Can(resource, action string) bool {
+	for _, p := range c.Permissions {
+		if permissionGrants(p, resource, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// permissionGrants matches a single granted "resource:action" code against a request.
+func permissionGrants(granted, resource, action string) bool {
+	if granted == "*" {
+		return true
+	}
+	gr, ga, ok := strings.Cut(granted, ":")
+	if !ok {
+		return false
+	}
+	return (gr == "*" || gr == resource) && (ga == "*" || ga == action)
+}
+
 // ============================================================================
 // RBAC Role Helpers
 // ============================================================================
@@ -199,6 +316,16 @@ func (c *Claims) HasAnyRole(roles ...string) bool {
 	return false
 }
 
+// HasAllRoles checks if the token has all of the provided roles.
+func (c *Claims) HasAllRoles(roles ...string) bool {
+	for _, required := range roles {
+		if !c.HasRole(required) {
+			return false
+		}
+	}
+	return true
+}
+
 // IsSuperuser checks if the token has the superuser role (bypasses all RBAC).
 func (c *Claims) IsSuperuser() bool {
 	return c.HasRole("superuser")
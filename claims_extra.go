@@ -0,0 +1,233 @@
+package authclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// claimsJSON has Claims' fields without its methods, so (un)marshalling it does not recurse.
+type claimsJSON Claims
+
+var (
+	knownClaimKeysOnce sync.Once
+	knownClaimKeys     map[string]struct{}
+)
+
+// modelledClaimKeys returns the JSON names of every field Claims models (including the
+// embedded registered claims), derived from the struct tags so new fields are picked up.
+func modelledClaimKeys() map[string]struct{} {
+	knownClaimKeysOnce.Do(func() {
+		knownClaimKeys = make(map[string]struct{})
+		collectJSONKeys(reflect.TypeOf(Claims{}), knownClaimKeys)
+	})
+	return knownClaimKeys
+}
+
+func collectJSONKeys(t reflect.Type, keys map[string]struct{}) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if f.Anonymous && tag == "" {
+			collectJSONKeys(f.Type, keys)
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		keys[name] = struct{}{}
+	}
+}
+
+// UnmarshalJSON decodes the modelled claims and keeps every other claim in Extra.
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*claimsJSON)(c)); err != nil {
+		return err
+	}
+	c.Extra = nil
+	if !hasUnmodelledKey(data, modelledClaimKeys()) {
+		return nil // the common case; skip decoding the token a second time
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	known := modelledClaimKeys()
+	for k, v := range all {
+		if _, ok := known[k]; ok {
+			continue
+		}
+		if c.Extra == nil {
+			c.Extra = make(map[string]json.RawMessage)
+		}
+		c.Extra[k] = v
+	}
+	return nil
+}
+
+// MarshalJSON encodes the modelled claims plus Extra, so claims round-trip through caches
+// (e.g. the Redis session cache) without losing custom claims. Modelled fields win on conflict.
+func (c Claims) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(claimsJSON(c))
+	if err != nil || len(c.Extra) == 0 {
+		return data, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for k, v := range c.Extra {
+		if _, ok := all[k]; !ok {
+			all[k] = v
+		}
+	}
+	return json.Marshal(all)
+}
+
+// HasClaim reports whether an unmodelled claim is present.
+func (c *Claims) HasClaim(key string) bool {
+	_, ok := c.Extra[key]
+	return ok
+}
+
+// GetString returns an unmodelled string claim (e.g. "org_id"). ok is false when the claim is
+// missing or not a string.
+func (c *Claims) GetString(key string) (string, bool) {
+	var s string
+	if err := c.Decode(key, &s); err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// GetBool returns an unmodelled boolean claim (e.g. a feature flag). ok is false when the
+// claim is missing or not a boolean.
+func (c *Claims) GetBool(key string) (bool, bool) {
+	var b bool
+	if err := c.Decode(key, &b); err != nil {
+		return false, false
+	}
+	return b, true
+}
+
+// Decode unmarshals an unmodelled claim into v, for structured tenant-specific claims.
+func (c *Claims) Decode(key string, v any) error {
+	raw, ok := c.Extra[key]
+	if !ok {
+		return fmt.Errorf("claim %q not present", key)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("decode claim %q: %w", key, err)
+	}
+	return nil
+}
+
+// hasUnmodelledKey reports whether the JSON object data has a top-level key outside known,
+// without allocating. It reports true for anything it does not fully understand (escaped
+// keys, malformed input), sending the caller down the general path.
+func hasUnmodelledKey(data []byte, known map[string]struct{}) bool {
+	i := skipJSONSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return true
+	}
+	i++
+	for {
+		i = skipJSONSpace(data, i)
+		if i >= len(data) {
+			return true
+		}
+		if data[i] == '}' {
+			return false
+		}
+		if data[i] != '"' {
+			return true
+		}
+		end := i + 1
+		for end < len(data) && data[end] != '"' {
+			if data[end] == '\\' {
+				return true
+			}
+			end++
+		}
+		if end >= len(data) {
+			return true
+		}
+		if _, ok := known[string(data[i+1:end])]; !ok {
+			return true
+		}
+		i = skipJSONSpace(data, end+1)
+		if i >= len(data) || data[i] != ':' {
+			return true
+		}
+		if i = skipJSONValue(data, i+1); i < 0 {
+			return true
+		}
+		i = skipJSONSpace(data, i)
+		if i < len(data) && data[i] == ',' {
+			i++
+		}
+	}
+}
+
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// skipJSONValue returns the index just past the JSON value starting at or after i, or -1.
+func skipJSONValue(data []byte, i int) int {
+	i = skipJSONSpace(data, i)
+	if i >= len(data) {
+		return -1
+	}
+	switch data[i] {
+	case '"':
+		return skipJSONString(data, i)
+	case '{', '[':
+		depth := 0
+		for i < len(data) {
+			switch data[i] {
+			case '"':
+				if i = skipJSONString(data, i); i < 0 {
+					return -1
+				}
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+			i++
+		}
+		return -1
+	default: // number, true, false or null
+		for i < len(data) && data[i] != ',' && data[i] != '}' && data[i] != ']' && skipJSONSpace(data, i) == i {
+			i++
+		}
+		return i
+	}
+}
+
+// skipJSONString returns the index just past the string starting at data[i], or -1.
+func skipJSONString(data []byte, i int) int {
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
@@ -0,0 +1,158 @@
+package authclient
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// DefaultClaimsHeader carries gateway-validated claims to internal services.
+const DefaultClaimsHeader = "X-Auth-Claims"
+
+// EncodeClaimsHeader serialises claims as unsigned base64url JSON for DefaultClaimsHeader.
+// Only use unsigned headers on networks where the header cannot be injected; prefer
+// SignClaimsHeader.
+func EncodeClaimsHeader(claims *Claims) string {
+	data, _ := json.Marshal(claims) // Claims always marshals
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// SignClaimsHeader serialises claims as "<base64url JSON>.<base64url HMAC-SHA256>".
+func SignClaimsHeader(claims *Claims, secret []byte) string {
+	payload := EncodeClaimsHeader(claims)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(claimsHeaderMAC(payload, secret))
+}
+
+// DecodeClaimsHeader parses a header produced by EncodeClaimsHeader or SignClaimsHeader.
+// It does NOT verify a signature; use VerifyClaimsHeader when a secret is shared.
+func DecodeClaimsHeader(value string) (*Claims, error) {
+	payload, _, _ := strings.Cut(value, ".")
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("decode claims header: %w", err)
+	}
+	var claims Claims
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, fmt.Errorf("unmarshal claims header: %w", err)
+	}
+	return &claims, nil
+}
+
+// VerifyClaimsHeader checks the HMAC of a SignClaimsHeader value and decodes it.
+func VerifyClaimsHeader(value string, secret []byte) (*Claims, error) {
+	payload, sig, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, fmt.Errorf("claims header is not signed")
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, claimsHeaderMAC(payload, secret)) {
+		return nil, fmt.Errorf("claims header signature invalid")
+	}
+	return DecodeClaimsHeader(payload)
+}
+
+func claimsHeaderMAC(payload string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// ClaimsHeaderConfig configures the trusted claims-header middleware.
+type ClaimsHeaderConfig struct {
+	Header          string   // defaults to DefaultClaimsHeader
+	TrustedNetworks []string // CIDRs of gateways allowed to assert claims, e.g. "10.0.0.0/8"
+	Secret          []byte   // if set, only signed headers (SignClaimsHeader) are accepted
+}
+
+// NewClaimsHeaderMiddleware returns middleware that accepts propagated claims from the
+// configured internal networks, so services behind the gateway need not revalidate the JWT.
+// The peer is taken from the connection (RemoteAddr), never from X-Forwarded-For. Headers from
+// untrusted peers, with bad signatures, or with expired or missing "exp" claims are stripped and
+// the request continues unauthenticated, so a following AuthMiddleware.RequireAuth still
+// validates a bearer token. Requiring "exp" bounds how long a captured header can be replayed.
+func NewClaimsHeaderMiddleware(cfg ClaimsHeaderConfig) (func(http.Handler) http.Handler, error) {
+	header := cfg.Header
+	if header == "" {
+		header = DefaultClaimsHeader
+	}
+	networks, err := parseNetworks(cfg.TrustedNetworks)
+	if err != nil {
+		return nil, err
+	}
+	trusted := func(remoteAddr string) bool {
+		addr, ok := remoteIP(remoteAddr)
+		return ok && inNetworks(addr, networks)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := r.Header.Get(header)
+			if value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			r.Header.Del(header)
+			if !trusted(r.RemoteAddr) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var claims *Claims
+			var err error
+			if len(cfg.Secret) > 0 {
+				claims, err = VerifyClaimsHeader(value, cfg.Secret)
+			} else {
+				claims, err = DecodeClaimsHeader(value)
+			}
+			if err != nil || !claimsHeaderUnexpired(claims, time.Now()) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
+		})
+	}, nil
+}
+
+// claimsHeaderUnexpired reports whether propagated claims carry an "exp" that is still ahead
+// of now. Claims without one are never trusted.
+func claimsHeaderUnexpired(claims *Claims, now time.Time) bool {
+	exp := claims.RegisteredClaims.ExpiresAt
+	return exp != nil && now.Before(exp.Time)
+}
+
+// parseNetworks parses CIDRs such as "10.0.0.0/8".
+func parseNetworks(cidrs []string) ([]netip.Prefix, error) {
+	networks := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		p, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("trusted network %q: %w", cidr, err)
+		}
+		networks = append(networks, p.Masked())
+	}
+	return networks, nil
+}
+
+func inNetworks(addr netip.Addr, networks []netip.Prefix) bool {
+	for _, p := range networks {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP returns the peer address of an http.Request.RemoteAddr.
+func remoteIP(remoteAddr string) (netip.Addr, bool) {
+	ap, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ap.Addr().Unmap(), true
+}
@@ -0,0 +1,97 @@
+package authclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestClaimsHeaderMiddleware(t *testing.T) {
+	secret := []byte("gateway-secret")
+	claimsMW, err := NewClaimsHeaderMiddleware(ClaimsHeaderConfig{TrustedNetworks: []string{"192.0.2.0/24"}, Secret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _ := newBenchValidator(t)
+	revoked := NewRevocationList(0)
+	revoked.RevokeSession("sess-revoked")
+	v.config.RevocationChecker = revoked
+	tenants := NewTenantStatusList()
+	tenants.Set("t-suspended", TenantStatusSuspended)
+	auth := NewAuthMiddleware(v, WithAllowedServices("ordering-service"), WithTenantStatus(tenants))
+
+	var gotSubject string
+	var headerSeen bool
+	h := claimsMW(auth.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := ClaimsFromContext(r.Context())
+		gotSubject = claims.Subject
+		headerSeen = r.Header.Get(DefaultClaimsHeader) != ""
+	})))
+
+	valid := func() *Claims {
+		return &Claims{RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}}
+	}
+	expired := valid()
+	expired.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	noExpiry := valid()
+	noExpiry.RegisteredClaims.ExpiresAt = nil
+	revokedSession := valid()
+	revokedSession.SessionID = "sess-revoked"
+	billing := valid()
+	billing.IsService, billing.ServiceName = true, "billing-service"
+	suspended := valid()
+	suspended.TenantID = "t-suspended"
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     string
+		want       int
+	}{
+		{"trusted peer, signed", "192.0.2.10:4000", SignClaimsHeader(valid(), secret), http.StatusOK},
+		{"untrusted peer", "203.0.113.5:4000", SignClaimsHeader(valid(), secret), http.StatusUnauthorized},
+		{"bad signature", "192.0.2.10:4000", SignClaimsHeader(valid(), []byte("other")), http.StatusUnauthorized},
+		{"unsigned", "192.0.2.10:4000", EncodeClaimsHeader(valid()), http.StatusUnauthorized},
+		{"expired claims", "192.0.2.10:4000", SignClaimsHeader(expired, secret), http.StatusUnauthorized},
+		{"missing exp", "192.0.2.10:4000", SignClaimsHeader(noExpiry, secret), http.StatusUnauthorized},
+		{"revoked session", "192.0.2.10:4000", SignClaimsHeader(revokedSession, secret), http.StatusUnauthorized},
+		{"service not allowed", "192.0.2.10:4000", SignClaimsHeader(billing, secret), http.StatusForbidden},
+		{"tenant suspended", "192.0.2.10:4000", SignClaimsHeader(suspended, secret), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSubject, headerSeen = "", false
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(DefaultClaimsHeader, tt.header)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusOK && (gotSubject != "user-1" || headerSeen) {
+				t.Fatalf("subject = %q, header passed on = %v; want user-1 and header stripped", gotSubject, headerSeen)
+			}
+		})
+	}
+
+	// Headers from untrusted peers never reach the handler, even when it runs without
+	// RequireAuth.
+	h = claimsMW(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, authenticated := ClaimsFromContext(r.Context())
+		headerSeen = authenticated || r.Header.Get(DefaultClaimsHeader) != ""
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.RemoteAddr = "203.0.113.5:4000"
+	req.Header.Set(DefaultClaimsHeader, SignClaimsHeader(valid(), secret))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if headerSeen {
+		t.Fatal("claims header from an untrusted peer was passed on")
+	}
+}
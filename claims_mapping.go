@@ -0,0 +1,141 @@
+package authclient
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// ClaimsMapper normalizes claims from a third-party identity provider into this package's
+// Claims shape (roles, tenant, audience) after signature verification and before the
+// issuer, audience and token-type checks, so RequireRole and friends work unchanged behind
+// Keycloak, Auth0 or Cognito. Set it as Config.ClaimsMapper.
+type ClaimsMapper interface {
+	MapClaims(c *Claims) error
+}
+
+// ClaimsMapperFunc adapts a function to ClaimsMapper.
+type ClaimsMapperFunc func(c *Claims) error
+
+// MapClaims calls f.
+func (f ClaimsMapperFunc) MapClaims(c *Claims) error { return f(c) }
+
+// MappersByIssuer picks the mapper for a token's iss claim; tokens from other issuers pass
+// through unchanged. Use it when one validator accepts tokens from several providers.
+type MappersByIssuer map[string]ClaimsMapper
+
+// MapClaims applies the mapper registered for c.Issuer.
+func (m MappersByIssuer) MapClaims(c *Claims) error {
+	if mapper, ok := m[c.Issuer]; ok {
+		return mapper.MapClaims(c)
+	}
+	return nil
+}
+
+// KeycloakProfile maps Keycloak tokens: realm_access.roles and, for ClientID,
+// resource_access.<ClientID>.roles become Roles; TenantClaim (e.g. "tenant_id" from a
+// protocol mapper) fills TenantID when set; and azp stands in for a missing audience, since
+// Keycloak only adds aud through an audience mapper.
+type KeycloakProfile struct {
+	ClientID    string
+	TenantClaim string
+}
+
+// MapClaims implements ClaimsMapper.
+func (p KeycloakProfile) MapClaims(c *Claims) error {
+	var realm struct {
+		Roles []string `json:"roles"`
+	}
+	decodeExtra(c, "realm_access", &realm)
+	c.Roles = appendUnique(c.Roles, realm.Roles...)
+
+	if p.ClientID != "" {
+		var resources map[string]struct {
+			Roles []string `json:"roles"`
+		}
+		decodeExtra(c, "resource_access", &resources)
+		c.Roles = appendUnique(c.Roles, resources[p.ClientID].Roles...)
+	}
+
+	if p.TenantClaim != "" && c.TenantID == "" {
+		c.TenantID, _ = c.GetString(p.TenantClaim)
+	}
+	if len(c.Audience) == 0 {
+		if azp, ok := c.GetString("azp"); ok {
+			c.Audience = []string{azp}
+		}
+	}
+	return nil
+}
+
+// Auth0Profile maps Auth0 tokens. Auth0 requires custom claims to be namespaced, so
+// Namespace+"roles", Namespace+"tenant_id" and Namespace+"tenant_slug" (e.g.
+// "https://codevertex.example/roles") become Roles, TenantID and TenantSlug. An Auth0
+// Organization (org_id, org_name) fills the tenant when the namespaced claims are absent.
+// RBAC permissions already arrive in the standard permissions claim.
+type Auth0Profile struct {
+	Namespace string
+}
+
+// MapClaims implements ClaimsMapper.
+func (p Auth0Profile) MapClaims(c *Claims) error {
+	var roles []string
+	decodeExtra(c, p.Namespace+"roles", &roles)
+	c.Roles = appendUnique(c.Roles, roles...)
+
+	if c.TenantID == "" {
+		c.TenantID, _ = c.GetString(p.Namespace + "tenant_id")
+	}
+	if c.TenantID == "" {
+		c.TenantID, _ = c.GetString("org_id")
+	}
+	if c.TenantSlug == "" {
+		c.TenantSlug, _ = c.GetString(p.Namespace + "tenant_slug")
+	}
+	if c.TenantSlug == "" {
+		c.TenantSlug, _ = c.GetString("org_name")
+	}
+	return nil
+}
+
+// CognitoProfile maps Amazon Cognito tokens: cognito:groups become Roles,
+// custom:tenant_id and custom:tenant_slug the tenant, and client_id the audience of access
+// tokens, which Cognito issues without aud. token_use is already understood natively.
+type CognitoProfile struct{}
+
+// MapClaims implements ClaimsMapper.
+func (CognitoProfile) MapClaims(c *Claims) error {
+	var groups []string
+	decodeExtra(c, "cognito:groups", &groups)
+	c.Roles = appendUnique(c.Roles, groups...)
+
+	if c.TenantID == "" {
+		c.TenantID, _ = c.GetString("custom:tenant_id")
+	}
+	if c.TenantSlug == "" {
+		c.TenantSlug, _ = c.GetString("custom:tenant_slug")
+	}
+	if len(c.Audience) == 0 {
+		if clientID, ok := c.GetString("client_id"); ok {
+			c.Audience = []string{clientID}
+		}
+	}
+	return nil
+}
+
+// decodeExtra decodes an unmodelled claim into v, leaving v untouched when the claim is
+// missing or has another shape: a provider omitting a claim is not an error.
+func decodeExtra(c *Claims, key string, v any) {
+	if raw, ok := c.Extra[key]; ok {
+		_ = json.Unmarshal(raw, v)
+	}
+}
+
+func appendUnique(dst []string, values ...string) []string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" && !slices.Contains(dst, v) {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,77 @@
+package authclient
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestClaimsMappingProfiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		mapper     ClaimsMapper
+		payload    string
+		roles      []string
+		tenantID   string
+		tenantSlug string
+		audience   string
+	}{
+		{
+			name:     "keycloak",
+			mapper:   KeycloakProfile{ClientID: "orders", TenantClaim: "tenant"},
+			payload:  `{"iss":"kc","azp":"orders","realm_access":{"roles":["admin","offline_access"]},"resource_access":{"orders":{"roles":["orders:write"]},"other":{"roles":["x"]}},"tenant":"t-1"}`,
+			roles:    []string{"admin", "offline_access", "orders:write"},
+			tenantID: "t-1",
+			audience: "orders",
+		},
+		{
+			name:       "auth0",
+			mapper:     Auth0Profile{Namespace: "https://codevertex.example/"},
+			payload:    `{"iss":"a0","aud":["api"],"https://codevertex.example/roles":["viewer"],"org_id":"org_1","org_name":"acme","permissions":["read:orders"]}`,
+			roles:      []string{"viewer"},
+			tenantID:   "org_1",
+			tenantSlug: "acme",
+			audience:   "api",
+		},
+		{
+			name:       "cognito",
+			mapper:     CognitoProfile{},
+			payload:    `{"iss":"cg","token_use":"access","client_id":"app-client","cognito:groups":["admin"],"custom:tenant_id":"t-2","custom:tenant_slug":"beta"}`,
+			roles:      []string{"admin"},
+			tenantID:   "t-2",
+			tenantSlug: "beta",
+			audience:   "app-client",
+		},
+		{
+			name:     "by issuer, unknown issuer untouched",
+			mapper:   MappersByIssuer{"cg": CognitoProfile{}},
+			payload:  `{"iss":"other","cognito:groups":["admin"]}`,
+			roles:    nil,
+			audience: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Claims
+			if err := json.Unmarshal([]byte(tt.payload), &c); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.mapper.MapClaims(&c); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(c.Roles, tt.roles) {
+				t.Errorf("Roles = %v, want %v", c.Roles, tt.roles)
+			}
+			if c.TenantID != tt.tenantID || c.TenantSlug != tt.tenantSlug {
+				t.Errorf("tenant = %q/%q, want %q/%q", c.TenantID, c.TenantSlug, tt.tenantID, tt.tenantSlug)
+			}
+			var aud string
+			if len(c.Audience) > 0 {
+				aud = c.Audience[0]
+			}
+			if aud != tt.audience {
+				t.Errorf("Audience = %v, want %q", c.Audience, tt.audience)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package authclient
+
+import "testing"
+
+func TestClaimsCan(t *testing.T) {
+	cases := []struct {
+		name     string
+		perms    []string
+		resource string
+		action   string
+		want     bool
+	}{
+		{"exact match", []string{"orders:delete"}, "orders", "delete", true},
+		{"other action", []string{"orders:read"}, "orders", "delete", false},
+		{"other resource", []string{"invoices:delete"}, "orders", "delete", false},
+		{"action wildcard", []string{"orders:*"}, "orders", "delete", true},
+		{"resource wildcard", []string{"*:read"}, "orders", "read", true},
+		{"resource wildcard wrong action", []string{"*:read"}, "orders", "delete", false},
+		{"global wildcard", []string{"*"}, "orders", "delete", true},
+		{"double wildcard", []string{"*:*"}, "orders", "delete", true},
+		{"legacy code without colon", []string{"orders.delete"}, "orders", "delete", false},
+		{"no permissions", nil, "orders", "read", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Claims{Permissions: tc.perms}
+			if got := c.Can(tc.resource, tc.action); got != tc.want {
+				t.Fatalf("Can(%q, %q) with %v = %v, want %v", tc.resource, tc.action, tc.perms, got, tc.want)
+			}
+		})
+	}
+}
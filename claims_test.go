@@ -0,0 +1,103 @@
+package authclient
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestClaimsHasAllRoles(t *testing.T) {
+	cases := []struct {
+		name  string
+		roles []string
+		want  []string
+		ok    bool
+	}{
+		{"all present", []string{"manager", "billing", "viewer"}, []string{"manager", "billing"}, true},
+		{"one missing", []string{"manager"}, []string{"manager", "billing"}, false},
+		{"none required", []string{"manager"}, nil, true},
+		{"no roles", nil, []string{"manager"}, false},
+		{"superuser is not every role", []string{"superuser"}, []string{"manager"}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Claims{Roles: tc.roles}
+			if got := c.HasAllRoles(tc.want...); got != tc.ok {
+				t.Fatalf("HasAllRoles(%v) with %v = %v, want %v", tc.want, tc.roles, got, tc.ok)
+			}
+		})
+	}
+}
+
+func TestScopeListUnmarshal(t *testing.T) {
+	cases := []struct {
+		name    string
+		payload string
+		want    []string
+		wantErr bool
+	}{
+		{"array", `{"scope":["orders:read","orders:write"]}`, []string{"orders:read", "orders:write"}, false},
+		{"space-delimited", `{"scope":"orders:read  orders:write"}`, []string{"orders:read", "orders:write"}, false},
+		{"single string", `{"scope":"orders:read"}`, []string{"orders:read"}, false},
+		{"empty string", `{"scope":""}`, []string{}, false},
+		{"number", `{"scope":42}`, nil, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var c Claims
+			err := json.Unmarshal([]byte(tc.payload), &c)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unmarshal(%s) err = %v, wantErr %v", tc.payload, err, tc.wantErr)
+			}
+			if !tc.wantErr && !slices.Equal(c.Scope, tc.want) {
+				t.Fatalf("Scope = %q, want %q", c.Scope, tc.want)
+			}
+			if !tc.wantErr && len(tc.want) > 0 && !c.HasAllScopes(tc.want...) {
+				t.Fatalf("HasAllScopes(%q) = false", tc.want)
+			}
+		})
+	}
+
+	// Scopes always marshal as an array, whatever form they arrived in.
+	data, err := json.Marshal(Claims{Scope: ScopeList{"a", "b"}})
+	if err != nil || !strings.Contains(string(data), `"scope":["a","b"]`) {
+		t.Fatalf("Marshal = %s, %v", data, err)
+	}
+}
+
+func TestClaimsAuthenticationContext(t *testing.T) {
+	cases := []struct {
+		name string
+		amr  []string
+		mfa  bool
+	}{
+		{"explicit mfa", []string{"mfa"}, true},
+		{"password and otp", []string{"pwd", "otp"}, true},
+		{"password and hardware key", []string{"pwd", "hwk"}, true},
+		{"password only", []string{"pwd"}, false},
+		{"two knowledge factors", []string{"pwd", "pin"}, false},
+		{"possession only", []string{"otp"}, false},
+		{"none", nil, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Claims{AMR: tc.amr}
+			if got := c.AuthenticatedWithMFA(); got != tc.mfa {
+				t.Fatalf("AuthenticatedWithMFA() with amr %v = %v, want %v", tc.amr, got, tc.mfa)
+			}
+		})
+	}
+
+	var c Claims
+	if _, ok := c.AuthAge(); ok {
+		t.Error("AuthAge without auth_time reported a known age")
+	}
+	c.AuthTime = jwt.NewNumericDate(time.Now().Add(-10 * time.Minute))
+	if age, ok := c.AuthAge(); !ok || age < 10*time.Minute || age > 11*time.Minute {
+		t.Errorf("AuthAge() = %v, %v; want about 10m", age, ok)
+	}
+}
@@ -3,10 +3,12 @@ package authclient
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -16,18 +18,133 @@ import (
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
-	logger     *zap.Logger
+	logger     Logger
+	redact     redactor
+	debug      atomic.Bool // see SetDebug
+	reach      reachability
+	onError    ErrorHook
+	audit      AuditSink
+
+	decodeMode    DecodeMode         // see WithDecodeMode
+	apiVersion    string             // see WithAPIVersion
+	onDeprecation DeprecationHook    // see WithDeprecationHook
+	signer        *requestSigner     // see WithRequestSigning
+	protobuf      *protobufState     // see WithProtobuf
+	http2         *HTTP2Options      // see WithHTTP2
+	discovery     *discovery         // see WithEndpointResolver
+	credentials   CredentialProvider // see WithCredentialProvider
+
+	profileSchemas  *profileSchemaCache // see WithProfileValidation
+	onTokensRotated TokensRotatedFunc   // see WithOnTokensRotated
+
+	userBatchUnsupported atomic.Bool // auth-service has no user batch endpoint; see GetUsers
+
+	tokenSource func(ctx context.Context) (string, error) // see SetTokenSource
 }
 
-// NewClient creates a new auth-service client.
-func NewClient(baseURL string, logger *zap.Logger) *Client {
-	return &Client{
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithClientLogger sets the client's logger, e.g. a *slog.Logger or ZapLogger(logger).
+// Without it the client does not log.
+func WithClientLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithStrictRedaction omits emails and response bodies from logs and error strings entirely,
+// for regulated deployments. By default emails are pseudonymised (hashed) and bodies are
+// truncated with tokens, passwords and secrets masked.
+func WithStrictRedaction() ClientOption {
+	return func(c *Client) {
+		c.redact.strict = true
+	}
+}
+
+// WithOnError reports auth-service outages (transport errors and 5xx responses) to hook.
+// op is the request's method and path, e.g. "POST /api/v1/auth/login".
+func WithOnError(hook ErrorHook) ClientOption {
+	return func(c *Client) {
+		c.onError = hook
+	}
+}
+
+// WithAuditSink emits AuditUserSynced and AuditTenantCreated events to sink.
+func WithAuditSink(sink AuditSink) ClientOption {
+	return func(c *Client) {
+		c.audit = sink
+	}
+}
+
+// WithSlogLogger logs through a *slog.Logger with the same attribute keys as the zap logger
+// ("error", "status", "url", ...), tagged logger=auth-service-client.
+func WithSlogLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = slogLogger(logger, "auth-service-client")
+	}
+}
+
+// WithTLSConfig dials auth-service with config, e.g. a private CA or a client certificate
+// for clusters that require mTLS. The rest of the transport matches http.DefaultTransport.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = config
+		c.httpClient.Transport = transport
+	}
+}
+
+// New creates a new auth-service client. It logs through WithClientLogger or
+// WithSlogLogger, and not at all without either.
+func New(baseURL string, opts ...ClientOption) *Client {
+	c := &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		logger: logger.Named("auth-service-client"),
+		logger:     NopLogger(),
+		apiVersion: DefaultAPIVersion,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	base := c.httpClient.Transport
+	if c.http2 != nil {
+		base = c.http2.transport(base)
+	}
+	if base == nil {
+		base = http.DefaultTransport
 	}
+	if c.discovery != nil {
+		c.discovery.logger = c.logger
+		base = &discoveryTransport{base: base, state: c.discovery}
+	}
+	base = &apiVersionTransport{base: base, version: c.apiVersion, logger: c.logger, hook: c.onDeprecation}
+	base = &clientContextTransport{base: base}
+	base = &reachabilityTransport{base: base, state: &c.reach, onError: c.onError}
+	c.httpClient.Transport = &debugTransport{base: base, enabled: &c.debug, logger: c.logger, redact: c.redact}
+	return c
+}
+
+// NewClient creates a new auth-service client logging to logger, named
+// "auth-service-client". logger may be nil; a WithClientLogger or WithSlogLogger option
+// replaces it.
+//
+// Deprecated: Use New with WithClientLogger(ZapLogger(logger)), which does not tie callers
+// to zap.
+func NewClient(baseURL string, logger *zap.Logger, opts ...ClientOption) *Client {
+	return New(baseURL, append([]ClientOption{withZapLogger(logger)}, opts...)...)
+}
+
+// withZapLogger logs to logger named "auth-service-client", as NewClient always has.
+func withZapLogger(logger *zap.Logger) ClientOption {
+	if logger != nil {
+		logger = logger.Named("auth-service-client")
+	}
+	return WithClientLogger(ZapLogger(logger))
 }
 
 // LoginRequest represents a login request to auth-service.
@@ -35,6 +152,18 @@ type LoginRequest struct {
 	Email      string `json:"email"`
 	Password   string `json:"password"`
 	TenantSlug string `json:"tenant_slug"`
+
+	// RememberMe asks for a long-lived ("keep me signed in") refresh token. SessionDuration,
+	// in seconds, requests a specific refresh-token lifetime instead; auth-service caps both
+	// by tenant policy and reports what it granted in AuthResponse.RefreshExpiresIn.
+	RememberMe      bool `json:"remember_me,omitempty"`
+	SessionDuration int  `json:"session_duration,omitempty"`
+
+	// Resource and Audience narrow the access token to the APIs it will be used against
+	// (RFC 8707 resource indicators). Resource values are absolute URIs; Audience is a
+	// logical service name. auth-service rejects targets the user may not obtain.
+	Resource []string `json:"resource,omitempty"`
+	Audience string   `json:"audience,omitempty"`
 }
 
 // RegisterRequest represents a registration request to auth-service.
@@ -48,18 +177,29 @@ type RegisterRequest struct {
 // RefreshRequest represents a token refresh request.
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token"`
+
+	// Resource and Audience narrow the new access token, as in LoginRequest. They may only
+	// narrow the targets granted at login, never widen them.
+	Resource []string `json:"resource,omitempty"`
+	Audience string   `json:"audience,omitempty"`
 }
 
 // AuthResponse represents the response from auth-service.
 type AuthResponse struct {
-	AccessToken      string                 `json:"access_token"`
-	RefreshToken     string                 `json:"refresh_token"`
-	SessionID        string                 `json:"session_id"`
-	TokenType        string                 `json:"token_type"`
-	ExpiresIn        int                    `json:"expires_in"`
-	RefreshExpiresIn int                    `json:"refresh_expires_in"`
-	Tenant           map[string]interface{} `json:"tenant"`
-	User             map[string]interface{} `json:"user"`
+	AccessToken      string          `json:"access_token"`
+	RefreshToken     string          `json:"refresh_token"`
+	SessionID        string          `json:"session_id"`
+	TokenType        string          `json:"token_type"`
+	ExpiresIn        int             `json:"expires_in"`
+	RefreshExpiresIn int             `json:"refresh_expires_in"`
+	RememberMe       bool            `json:"remember_me,omitempty"` // session outlives the browser
+	Risk             *RiskAssessment `json:"risk,omitempty"`        // login anomaly hints, when evaluated
+	Tenant           *Tenant         `json:"tenant,omitempty"`      // nil when auth-service omits it
+	User             *User           `json:"user,omitempty"`        // nil when auth-service omits it
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modelled above; see DecodeMode
+
+	receivedAt time.Time // when the client decoded the response; see ExpiresAt
 }
 
 // Error represents an error response from auth-service.
@@ -68,6 +208,7 @@ type Error struct {
 	ErrorCode        string `json:"error_code,omitempty"`
 	ErrorDescription string `json:"error_description,omitempty"`
 	Message          string `json:"message,omitempty"`
+	StatusCode       int    `json:"-"` // HTTP status of the failed response
 }
 
 func (e *Error) Error() string {
@@ -80,18 +221,59 @@ func (e *Error) Error() string {
 	return e.ErrorField
 }
 
-// Login authenticates a user via auth-service.
+// responseError converts a non-success auth-service response into an *AuthError. When the
+// body is an auth-service error document it becomes the Cause, so errors.As(err, &*Error)
+// keeps working; otherwise the (redacted) body is kept for diagnosis.
+func (c *Client) responseError(op string, status int, body []byte) error {
+	ae := &AuthError{
+		Kind:       statusKind(status),
+		StatusCode: status,
+		Message:    "auth-service: " + op + " failed",
+	}
+	var apiErr Error
+	if err := json.Unmarshal(body, &apiErr); err == nil {
+		apiErr.StatusCode = status
+		ae.Code = apiErr.ErrorCode
+		if ae.Code == "" {
+			ae.Code = apiErr.ErrorField
+		}
+		if tokenReuseCodes[ae.Code] {
+			ae.Kind = KindTokenReused
+		}
+		ae.Cause = &apiErr
+		if blocked := parseBlocked(status, body, &apiErr); blocked != nil {
+			ae.Kind, ae.Cause = KindBlocked, blocked
+		}
+		if ae.Code == "mfa_required" {
+			ae.Kind = KindMFARequired
+			if challenge := c.parseMFAChallenge(body); challenge != nil {
+				challenge.apiErr = &apiErr
+				ae.Cause = challenge
+			}
+		}
+		return ae
+	}
+	ae.Cause = fmt.Errorf("status %d: %s", status, c.redact.body(body))
+	return ae
+}
+
+// Login authenticates a user via auth-service. When the account has MFA enabled, Login
+// fails with KindMFARequired (errors.Is(err, ErrMFARequired)); AsMFAChallenge returns the
+// challenge to complete with VerifyMFAChallenge.
 func (c *Client) Login(ctx context.Context, req LoginRequest) (*AuthResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/auth/login", c.baseURL)
 
+	if err := validateResources(req.Resource); err != nil {
+		return nil, err
+	}
 	body, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: marshal request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: create request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -99,33 +281,34 @@ func (c *Client) Login(ctx context.Context, req LoginRequest) (*AuthResponse, er
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		c.logger.Error("auth-service: login request failed", zap.Error(err), zap.String("url", url), zap.String("email", req.Email))
-		return nil, fmt.Errorf("auth-service: request failed: %w", err)
+		c.logger.Error("auth-service: login request failed", "error", err, "url", url, "email", c.redact.email(req.Email))
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	buf, err := readBody(resp.Body)
 	if err != nil {
-		c.logger.Error("auth-service: failed to read login response", zap.Error(err), zap.Int("status", resp.StatusCode))
-		return nil, fmt.Errorf("auth-service: read response: %w", err)
+		c.logger.Error("auth-service: failed to read login response", "error", err, "status", resp.StatusCode)
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
 	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
 
 	if resp.StatusCode != http.StatusOK {
 		c.logger.Warn("auth-service: login failed",
-			zap.Int("status", resp.StatusCode),
-			zap.String("response", string(respBody)),
-			zap.String("url", url),
-			zap.String("email", req.Email))
-		var authErr Error
-		if err := json.Unmarshal(respBody, &authErr); err == nil {
-			return nil, &authErr
-		}
-		return nil, fmt.Errorf("auth-service: login failed with status %d: %s", resp.StatusCode, string(respBody))
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody),
+			"url", url,
+			"email", c.redact.email(req.Email))
+		return nil, c.responseError("login", resp.StatusCode, respBody)
+	}
+	if challenge := c.parseMFAChallenge(respBody); challenge != nil {
+		return nil, mfaRequired(resp.StatusCode, challenge)
 	}
 
 	var authResp AuthResponse
-	if err := json.Unmarshal(respBody, &authResp); err != nil {
-		return nil, fmt.Errorf("auth-service: unmarshal response: %w", err)
+	if err := c.decode(respBody, &authResp); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
 	}
 
 	return &authResp, nil
@@ -133,16 +316,19 @@ func (c *Client) Login(ctx context.Context, req LoginRequest) (*AuthResponse, er
 
 // Register registers a new user via auth-service.
 func (c *Client) Register(ctx context.Context, req RegisterRequest) (*AuthResponse, error) {
+	if err := c.validateProfile(ctx, req.TenantSlug, req.Profile); err != nil {
+		return nil, err
+	}
 	url := fmt.Sprintf("%s/api/v1/auth/register", c.baseURL)
 
 	body, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: marshal request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: create request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -150,53 +336,119 @@ func (c *Client) Register(ctx context.Context, req RegisterRequest) (*AuthRespon
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		c.logger.Error("auth-service: register request failed", zap.Error(err), zap.String("url", url))
-		return nil, fmt.Errorf("auth-service: request failed: %w", err)
+		c.logger.Error("auth-service: register request failed", "error", err, "url", url)
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	buf, err := readBody(resp.Body)
 	if err != nil {
-		c.logger.Error("auth-service: failed to read register response", zap.Error(err), zap.Int("status", resp.StatusCode))
-		return nil, fmt.Errorf("auth-service: read response: %w", err)
+		c.logger.Error("auth-service: failed to read register response", "error", err, "status", resp.StatusCode)
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
 	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		c.logger.Warn("auth-service: register failed",
-			zap.Int("status", resp.StatusCode),
-			zap.String("response", string(respBody)),
-			zap.String("url", url))
-		var authErr Error
-		if err := json.Unmarshal(respBody, &authErr); err == nil {
-			return nil, &authErr
-		}
-		return nil, fmt.Errorf("auth-service: register failed with status %d: %s", resp.StatusCode, string(respBody))
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody),
+			"url", url)
+		return nil, c.responseError("register", resp.StatusCode, respBody)
 	}
 
 	var authResp AuthResponse
-	if err := json.Unmarshal(respBody, &authResp); err != nil {
-		return nil, fmt.Errorf("auth-service: unmarshal response: %w", err)
+	if err := c.decode(respBody, &authResp); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
 	}
 
 	return &authResp, nil
 }
 
-// Refresh refreshes an access token via auth-service.
+// Refresh refreshes an access token via auth-service. A refresh token that was already
+// rotated fails with KindTokenReused (errors.Is(err, ErrTokenReused)): auth-service has
+// revoked the session, which must be treated as compromised rather than retried.
 func (c *Client) Refresh(ctx context.Context, refreshToken string) (*AuthResponse, error) {
+	return c.RefreshWith(ctx, RefreshRequest{RefreshToken: refreshToken})
+}
+
+// RefreshWith refreshes an access token, requesting the resource indicators or audience in
+// req for the new token.
+func (c *Client) RefreshWith(ctx context.Context, req RefreshRequest) (*AuthResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/auth/refresh", c.baseURL)
 
-	req := RefreshRequest{
-		RefreshToken: refreshToken,
+	if err := validateResources(req.Resource); err != nil {
+		return nil, err
 	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
 
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.responseError("refresh", resp.StatusCode, respBody)
+	}
+
+	var authResp AuthResponse
+	if err := c.decode(respBody, &authResp); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+
+	c.tokensRotated(req.RefreshToken, &authResp)
+	return &authResp, nil
+}
+
+// ClientCredentialsRequest requests a machine token for a service (OAuth2 client_credentials).
+type ClientCredentialsRequest struct {
+	GrantType    string `json:"grant_type"` // set by ClientCredentials
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope,omitempty"` // space-separated
+
+	// Resource and Audience narrow the service token to the APIs it will call, as in
+	// LoginRequest.
+	Resource []string `json:"resource,omitempty"`
+	Audience string   `json:"audience,omitempty"`
+}
+
+// ClientCredentials obtains a service access token via the client_credentials grant.
+// Prefer ServiceTokenSource, which caches and shares the token.
+func (c *Client) ClientCredentials(ctx context.Context, req ClientCredentialsRequest) (*AuthResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/auth/token", c.baseURL)
+
+	if err := validateResources(req.Resource); err != nil {
+		return nil, err
+	}
+	req.GrantType = "client_credentials"
 	body, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: marshal request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: create request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -204,38 +456,39 @@ func (c *Client) Refresh(ctx context.Context, refreshToken string) (*AuthRespons
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: request failed: %w", err)
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	buf, err := readBody(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: read response: %w", err)
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
 	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
 
 	if resp.StatusCode != http.StatusOK {
-		var authErr Error
-		if err := json.Unmarshal(respBody, &authErr); err == nil {
-			return nil, &authErr
-		}
-		return nil, fmt.Errorf("auth-service: refresh failed with status %d: %s", resp.StatusCode, string(respBody))
+		c.logger.Warn("auth-service: client credentials grant failed",
+			"status", resp.StatusCode,
+			"client_id", req.ClientID)
+		return nil, c.responseError("client credentials", resp.StatusCode, respBody)
 	}
 
 	var authResp AuthResponse
-	if err := json.Unmarshal(respBody, &authResp); err != nil {
-		return nil, fmt.Errorf("auth-service: unmarshal response: %w", err)
+	if err := c.decode(respBody, &authResp); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
 	}
 
 	return &authResp, nil
 }
 
 // GetUser retrieves user details from auth-service.
-func (c *Client) GetUser(ctx context.Context, userID string, accessToken string) (map[string]interface{}, error) {
+func (c *Client) GetUser(ctx context.Context, userID string, accessToken string) (*User, error) {
 	url := fmt.Sprintf("%s/api/v1/users/%s", c.baseURL, userID)
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: create request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
 	}
 
 	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
@@ -243,29 +496,27 @@ func (c *Client) GetUser(ctx context.Context, userID string, accessToken string)
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: request failed: %w", err)
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	buf, err := readBody(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: read response: %w", err)
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
 	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
 
 	if resp.StatusCode != http.StatusOK {
-		var authErr Error
-		if err := json.Unmarshal(respBody, &authErr); err == nil {
-			return nil, &authErr
-		}
-		return nil, fmt.Errorf("auth-service: get user failed with status %d: %s", resp.StatusCode, string(respBody))
+		return nil, c.responseError("get user", resp.StatusCode, respBody)
 	}
 
-	var userData map[string]interface{}
-	if err := json.Unmarshal(respBody, &userData); err != nil {
-		return nil, fmt.Errorf("auth-service: unmarshal response: %w", err)
+	var user User
+	if err := c.decode(respBody, &user); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
 	}
 
-	return userData, nil
+	return &user, nil
 }
 
 // TenantRequest represents a tenant creation request to auth-service.
@@ -283,12 +534,14 @@ type TenantResponse struct {
 	ID           string                 `json:"id"`
 	Slug         string                 `json:"slug"`
 	Name         string                 `json:"name"`
-	Status       string                 `json:"status"`
+	Status       TenantStatus           `json:"status"`
 	ContactEmail string                 `json:"contact_email,omitempty"`
 	ContactPhone string                 `json:"contact_phone,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 	CreatedAt    string                 `json:"created_at"`
 	UpdatedAt    string                 `json:"updated_at"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modelled above; see DecodeMode
 }
 
 // SyncUserRequest represents the request to sync a user with auth-service.
@@ -298,6 +551,18 @@ type SyncUserRequest struct {
 	TenantSlug string                 `json:"tenant_slug"`
 	Profile    map[string]interface{} `json:"profile,omitempty"`
 	Service    string                 `json:"service,omitempty"`
+
+	// PasswordHash imports a credential already hashed by a legacy system, in place of
+	// Password, so migrated users need no password reset. HashAlgorithm names one of the
+	// HashAlgorithm* constants; HashParams carries parameters the hash string does not encode
+	// (salt and iterations for bare PBKDF2 hashes).
+	PasswordHash  string            `json:"password_hash,omitempty"`
+	HashAlgorithm string            `json:"hash_algorithm,omitempty"`
+	HashParams    map[string]string `json:"hash_params,omitempty"`
+
+	// OnConflict settles an email conflict reported by a previous attempt as a
+	// SyncConflictError; leave it empty to have such conflicts reported.
+	OnConflict SyncResolution `json:"on_conflict,omitempty"`
 }
 
 // SyncUserResponse represents the response from auth-service.
@@ -307,67 +572,85 @@ type SyncUserResponse struct {
 	TenantID string `json:"tenant_id"`
 	Created  bool   `json:"created"`
 	Message  string `json:"message"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modelled above; see DecodeMode
 }
 
-// SyncUser syncs a user with auth-service SSO using an API Key.
+// SyncUser syncs a user with auth-service SSO using an API Key. When the email belongs to an
+// account in another tenant, it fails with a SyncConflictError (see AsSyncConflict) listing
+// how the conflict may be settled.
 func (c *Client) SyncUser(ctx context.Context, req SyncUserRequest, apiKey string) (*SyncUserResponse, error) {
-	if apiKey == "" {
-		return nil, fmt.Errorf("auth-service: API key required for user sync")
+	apiKey, provided, err := c.adminAPIKey(ctx, apiKey, "user sync")
+	if err != nil {
+		return nil, err
+	}
+	if err := req.validateCredentials(); err != nil {
+		return nil, err
+	}
+	if err := c.validateProfile(ctx, req.TenantSlug, req.Profile); err != nil {
+		return nil, err
 	}
 
 	url := fmt.Sprintf("%s/api/v1/admin/users/sync", c.baseURL)
 
 	body, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: marshal request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: create request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("X-API-Key", apiKey)
+	c.signAdmin(httpReq, body)
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		c.logger.Error("auth-service: sync user request failed", zap.Error(err), zap.String("url", url), zap.String("email", req.Email))
-		return nil, fmt.Errorf("auth-service: request failed: %w", err)
+		c.logger.Error("auth-service: sync user request failed", "error", err, "url", url, "email", c.redact.email(req.Email))
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	buf, err := readBody(resp.Body)
 	if err != nil {
-		c.logger.Error("auth-service: failed to read sync response", zap.Error(err), zap.Int("status", resp.StatusCode))
-		return nil, fmt.Errorf("auth-service: read response: %w", err)
+		c.logger.Error("auth-service: failed to read sync response", "error", err, "status", resp.StatusCode)
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
 	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		c.logger.Warn("auth-service: user sync failed",
-			zap.Int("status", resp.StatusCode),
-			zap.String("response", string(respBody)),
-			zap.String("email", req.Email))
-
-		var errResp map[string]interface{}
-		if err := json.Unmarshal(respBody, &errResp); err == nil {
-			// Log parsed error for easier debugging
-			c.logger.Debug("auth-service: sync error details", zap.Any("error_response", errResp))
-		}
-
-		return nil, fmt.Errorf("auth-service: user sync failed with status %d: %s", resp.StatusCode, string(respBody))
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody),
+			"email", c.redact.email(req.Email))
+		c.apiKeyRejected(provided, resp.StatusCode)
+		err := withSyncConflict(c.responseError("user sync", resp.StatusCode, respBody), respBody)
+		return nil, withRetryAfter(err, resp.Header)
 	}
 
 	var syncResp SyncUserResponse
-	if err := json.NewDecoder(bytes.NewReader(respBody)).Decode(&syncResp); err != nil {
-		return nil, fmt.Errorf("auth-service: decode sync response: %w", err)
+	if err := c.decode(respBody, &syncResp); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: decode sync response", err)
 	}
 
 	c.logger.Info("auth-service: user synced",
-		zap.String("user_id", syncResp.UserID),
-		zap.String("email", syncResp.Email),
-		zap.Bool("created", syncResp.Created),
+		"user_id", syncResp.UserID,
+		"email", c.redact.email(syncResp.Email),
+		"created", syncResp.Created,
 	)
+	emitAudit(ctx, c.audit, AuditEvent{
+		Type:       AuditUserSynced,
+		Outcome:    AuditOutcomeSuccess,
+		Actor:      req.Service,
+		Subject:    syncResp.UserID,
+		TenantID:   syncResp.TenantID,
+		TenantSlug: req.TenantSlug,
+		Attributes: map[string]any{"created": syncResp.Created},
+	})
 
 	return &syncResp, nil
 }
@@ -379,7 +662,7 @@ func (c *Client) CheckTenantExists(ctx context.Context, tenantSlug string) (bool
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return false, fmt.Errorf("auth-service: create request: %w", err)
+		return false, newAuthError(KindInternal, "auth-service: create request", err)
 	}
 
 	httpReq.Header.Set("Accept", "application/json")
@@ -387,16 +670,18 @@ func (c *Client) CheckTenantExists(ctx context.Context, tenantSlug string) (bool
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		c.logger.Error("auth-service: tenant check request failed", zap.Error(err), zap.String("url", url), zap.String("tenant_slug", tenantSlug))
-		return false, fmt.Errorf("auth-service: request failed: %w", err)
+		c.logger.Error("auth-service: tenant check request failed", "error", err, "url", url, "tenant_slug", tenantSlug)
+		return false, newAuthError(KindUpstream, "auth-service: request failed", err)
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	buf, err := readBody(resp.Body)
 	if err != nil {
-		c.logger.Error("auth-service: failed to read tenant check response", zap.Error(err), zap.Int("status", resp.StatusCode))
-		return false, fmt.Errorf("auth-service: read response: %w", err)
+		c.logger.Error("auth-service: failed to read tenant check response", "error", err, "status", resp.StatusCode)
+		return false, newAuthError(KindUpstream, "auth-service: read response", err)
 	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
 
 	if resp.StatusCode == http.StatusNotFound {
 		return false, nil // Tenant doesn't exist
@@ -404,15 +689,11 @@ func (c *Client) CheckTenantExists(ctx context.Context, tenantSlug string) (bool
 
 	if resp.StatusCode != http.StatusOK {
 		c.logger.Warn("auth-service: tenant check failed",
-			zap.Int("status", resp.StatusCode),
-			zap.String("response", string(respBody)),
-			zap.String("url", url),
-			zap.String("tenant_slug", tenantSlug))
-		var authErr Error
-		if err := json.Unmarshal(respBody, &authErr); err == nil {
-			return false, &authErr
-		}
-		return false, fmt.Errorf("auth-service: tenant check failed with status %d: %s", resp.StatusCode, string(respBody))
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody),
+			"url", url,
+			"tenant_slug", tenantSlug)
+		return false, c.responseError("tenant check", resp.StatusCode, respBody)
 	}
 
 	// Tenant exists
@@ -426,49 +707,55 @@ func (c *Client) CreateTenant(ctx context.Context, req TenantRequest) (*TenantRe
 
 	body, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: marshal request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("auth-service: create request: %w", err)
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Accept", "application/json")
 	// Note: Tenant creation endpoint should be public (no auth required for auto-discovery)
+	c.signAdmin(httpReq, body)
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		c.logger.Error("auth-service: create tenant request failed", zap.Error(err), zap.String("url", url), zap.String("tenant_slug", req.Slug))
-		return nil, fmt.Errorf("auth-service: request failed: %w", err)
+		c.logger.Error("auth-service: create tenant request failed", "error", err, "url", url, "tenant_slug", req.Slug)
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	buf, err := readBody(resp.Body)
 	if err != nil {
-		c.logger.Error("auth-service: failed to read create tenant response", zap.Error(err), zap.Int("status", resp.StatusCode))
-		return nil, fmt.Errorf("auth-service: read response: %w", err)
+		c.logger.Error("auth-service: failed to read create tenant response", "error", err, "status", resp.StatusCode)
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
 	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		c.logger.Warn("auth-service: create tenant failed",
-			zap.Int("status", resp.StatusCode),
-			zap.String("response", string(respBody)),
-			zap.String("url", url),
-			zap.String("tenant_slug", req.Slug))
-		var authErr Error
-		if err := json.Unmarshal(respBody, &authErr); err == nil {
-			return nil, &authErr
-		}
-		return nil, fmt.Errorf("auth-service: create tenant failed with status %d: %s", resp.StatusCode, string(respBody))
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody),
+			"url", url,
+			"tenant_slug", req.Slug)
+		return nil, c.responseError("create tenant", resp.StatusCode, respBody)
 	}
 
 	var tenantResp TenantResponse
-	if err := json.Unmarshal(respBody, &tenantResp); err != nil {
-		return nil, fmt.Errorf("auth-service: unmarshal response: %w", err)
-	}
-
-	c.logger.Info("auth-service: tenant created successfully", zap.String("tenant_slug", req.Slug), zap.String("tenant_id", tenantResp.ID))
+	if err := c.decode(respBody, &tenantResp); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+
+	c.logger.Info("auth-service: tenant created successfully", "tenant_slug", req.Slug, "tenant_id", tenantResp.ID)
+	emitAudit(ctx, c.audit, AuditEvent{
+		Type:       AuditTenantCreated,
+		Outcome:    AuditOutcomeSuccess,
+		Subject:    tenantResp.ID,
+		TenantID:   tenantResp.ID,
+		TenantSlug: tenantResp.Slug,
+	})
 	return &tenantResp, nil
 }
@@ -0,0 +1,124 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// Headers carrying the end user's ClientContext to auth-service.
+const (
+	ClientIPHeader             = "X-Client-IP"
+	ClientUserAgentHeader      = "X-Client-User-Agent"
+	ClientAcceptLanguageHeader = "X-Client-Accept-Language"
+	DeviceIDHeader             = "X-Device-ID"
+)
+
+// ClientContext describes the end user's client, so auth-service's risk engine scores the
+// real user rather than the backend calling it on their behalf.
+type ClientContext struct {
+	IP             string
+	UserAgent      string
+	AcceptLanguage string
+	DeviceID       string // stable device identifier chosen by the app, if any
+}
+
+const clientContextKey contextKey = "client_context"
+
+// ContextWithClientContext attaches cc to ctx. Every Client call made with the returned
+// context (Login, Refresh, Register, ...) forwards it as X-Client-* headers.
+func ContextWithClientContext(ctx context.Context, cc ClientContext) context.Context {
+	return context.WithValue(ctx, clientContextKey, cc)
+}
+
+// ClientContextFromContext returns the ClientContext attached to ctx.
+func ClientContextFromContext(ctx context.Context) (ClientContext, bool) {
+	cc, ok := ctx.Value(clientContextKey).(ClientContext)
+	return cc, ok
+}
+
+// ClientContextConfig configures NewClientContextMiddleware.
+type ClientContextConfig struct {
+	// TrustedProxies are the CIDRs of load balancers whose X-Forwarded-For is believed. The
+	// client IP is the rightmost address not in these networks; without any, RemoteAddr is
+	// used and X-Forwarded-For ignored.
+	TrustedProxies []string
+	// DeviceIDHeader names the request header carrying the app's device ID; defaults to
+	// DeviceIDHeader.
+	DeviceIDHeader string
+}
+
+// NewClientContextMiddleware returns middleware that captures the ClientContext of each
+// incoming request into its context, so auth calls made while handling it carry the end
+// user's IP and device.
+func NewClientContextMiddleware(cfg ClientContextConfig) (func(http.Handler) http.Handler, error) {
+	proxies, err := parseNetworks(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	deviceHeader := cfg.DeviceIDHeader
+	if deviceHeader == "" {
+		deviceHeader = DeviceIDHeader
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cc := ClientContext{
+				IP:             clientIP(r, proxies),
+				UserAgent:      r.UserAgent(),
+				AcceptLanguage: r.Header.Get("Accept-Language"),
+				DeviceID:       r.Header.Get(deviceHeader),
+			}
+			next.ServeHTTP(w, r.WithContext(ContextWithClientContext(r.Context(), cc)))
+		})
+	}, nil
+}
+
+// clientIP walks X-Forwarded-For from the right past trusted proxies; the first other
+// address is the client. Spoofed entries to the left of it are never reached.
+func clientIP(r *http.Request, proxies []netip.Prefix) string {
+	peer, ok := remoteIP(r.RemoteAddr)
+	if !ok {
+		return ""
+	}
+	if !inNetworks(peer, proxies) {
+		return peer.String()
+	}
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		addr = addr.Unmap()
+		if !inNetworks(addr, proxies) {
+			return addr.String()
+		}
+		peer = addr
+	}
+	return peer.String()
+}
+
+// clientContextTransport forwards the request context's ClientContext as headers.
+type clientContextTransport struct {
+	base http.RoundTripper
+}
+
+func (t *clientContextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cc, ok := ClientContextFromContext(req.Context())
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	for header, value := range map[string]string{
+		ClientIPHeader:             cc.IP,
+		ClientUserAgentHeader:      cc.UserAgent,
+		ClientAcceptLanguageHeader: cc.AcceptLanguage,
+		DeviceIDHeader:             cc.DeviceID,
+	} {
+		if value != "" {
+			req.Header.Set(header, value)
+		}
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,49 @@
+package authclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientContextForwarded(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"access_token":"at"}`))
+	}))
+	defer srv.Close()
+	client := NewClient(srv.URL, nil)
+
+	mw, err := NewClientContextMiddleware(ClientContextConfig{TrustedProxies: []string{"10.0.0.0/8"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := client.Login(r.Context(), LoginRequest{Email: "ada@example.com"}); err != nil {
+			t.Fatal(err)
+		}
+	}))
+
+	tests := []struct {
+		name, remote, xff, wantIP string
+	}{
+		{"direct peer ignores XFF", "203.0.113.7:5000", "198.51.100.1", "203.0.113.7"},
+		{"trusted proxy", "10.0.0.2:5000", "198.51.100.1", "198.51.100.1"},
+		{"spoofed left entry", "10.0.0.2:5000", "1.2.3.4, 198.51.100.1, 10.0.0.9", "198.51.100.1"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = tt.remote
+		req.Header.Set("X-Forwarded-For", tt.xff)
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		req.Header.Set(DeviceIDHeader, "dev-42")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if got.Get(ClientIPHeader) != tt.wantIP {
+			t.Errorf("%s: %s = %q, want %q", tt.name, ClientIPHeader, got.Get(ClientIPHeader), tt.wantIP)
+		}
+		if got.Get(ClientUserAgentHeader) != "Mozilla/5.0" || got.Get(DeviceIDHeader) != "dev-42" {
+			t.Errorf("%s: forwarded headers = %v", tt.name, got)
+		}
+	}
+}
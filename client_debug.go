@@ -0,0 +1,112 @@
+package authclient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Bengo-Hub/shared-auth-client/webhooks"
+)
+
+// sensitiveHeaders are masked in debug dumps.
+var sensitiveHeaders = map[string]struct{}{
+	"Authorization": {},
+	"Cookie":        {},
+	"Set-Cookie":    {},
+	http.CanonicalHeaderKey(DefaultAPIKeyHeader):      {},
+	http.CanonicalHeaderKey(webhooks.SignatureHeader): {},
+	http.CanonicalHeaderKey(DefaultClaimsHeader):      {},
+}
+
+// WithDebug starts the client with request/response dumps enabled or disabled; toggle at
+// runtime with SetDebug.
+func WithDebug(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.debug.Store(enabled)
+	}
+}
+
+// SetDebug turns request/response dumps on or off while the client is in use. Each call to
+// auth-service is then logged at Info level with method, URL, status, latency and headers and
+// bodies sanitised by the client's redaction settings.
+func (c *Client) SetDebug(enabled bool) {
+	c.debug.Store(enabled)
+}
+
+// debugTransport dumps round trips while enabled is set. It is always installed so debugging
+// can be switched on without rebuilding the client.
+type debugTransport struct {
+	base    http.RoundTripper
+	enabled *atomic.Bool
+	logger  Logger
+	redact  redactor
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Event streams never end and exports can be huge, so their bodies are not dumped.
+	if accept := req.Header.Get("Accept"); !t.enabled.Load() || accept == "text/event-stream" || accept == ContentTypeNDJSON {
+		return t.base.RoundTrip(req)
+	}
+
+	var reqBody []byte
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	latency := time.Since(start)
+
+	fields := []any{
+		"method", req.Method,
+		"url", sanitizedURL(req),
+		"latency", latency,
+		"request_headers", t.headers(req.Header),
+		"request_body", t.redact.body(reqBody),
+	}
+	if err != nil {
+		t.logger.Info("auth-service: debug dump", append(fields, "error", err)...)
+		return resp, err
+	}
+
+	respBody, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if readErr != nil {
+		fields = append(fields, "read_error", readErr)
+	}
+	t.logger.Info("auth-service: debug dump", append(fields,
+		"status", resp.StatusCode,
+		"response_headers", t.headers(resp.Header),
+		"response_body", t.redact.body(respBody),
+	)...)
+	return resp, nil
+}
+
+func (t *debugTransport) headers(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if _, ok := sensitiveHeaders[http.CanonicalHeaderKey(k)]; ok {
+			out[k] = "[redacted]"
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// sanitizedURL drops the query string, which may carry codes or tokens.
+func sanitizedURL(req *http.Request) string {
+	u := *req.URL
+	if u.RawQuery != "" {
+		u.RawQuery = "[redacted]"
+	}
+	u.User = nil
+	return u.String()
+}
@@ -0,0 +1,41 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientDebugDumpIsSanitised(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"at-secret","refresh_token":"rt-secret","expires_in":900}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	client := NewClient(srv.URL, nil, WithSlogLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	if _, err := client.Refresh(context.Background(), "rt-old"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("dumped while debug was off: %s", buf.String())
+	}
+
+	client.SetDebug(true)
+	if _, err := client.Refresh(context.Background(), "rt-old"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "status=200") {
+		t.Fatalf("dump missing status: %s", out)
+	}
+	for _, secret := range []string{"at-secret", "rt-secret", "rt-old"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("dump leaks %q: %s", secret, out)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package authclient
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// AuthServiceClient is the auth-service API product services call. *Client talks to a single
+// deployment; *ClientSet routes every call to the deployment serving the tenant.
+type AuthServiceClient interface {
+	Login(ctx context.Context, req LoginRequest) (*AuthResponse, error)
+	VerifyMFAChallenge(ctx context.Context, challengeID, code string) (*AuthResponse, error)
+	Register(ctx context.Context, req RegisterRequest) (*AuthResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*AuthResponse, error)
+	RefreshWith(ctx context.Context, req RefreshRequest) (*AuthResponse, error)
+	ClientCredentials(ctx context.Context, req ClientCredentialsRequest) (*AuthResponse, error)
+	Logout(ctx context.Context, sessionID, accessToken string) (*LogoutResult, error)
+	LogoutAll(ctx context.Context, userID, accessToken string) (*LogoutResult, error)
+	GetUser(ctx context.Context, userID string, accessToken string) (*User, error)
+	GetUsers(ctx context.Context, userIDs []string, accessToken string) (map[string]*User, error)
+	SyncUser(ctx context.Context, req SyncUserRequest, apiKey string) (*SyncUserResponse, error)
+	CheckTenantExists(ctx context.Context, tenantSlug string) (bool, error)
+	CreateTenant(ctx context.Context, req TenantRequest) (*TenantResponse, error)
+}
+
+var (
+	_ AuthServiceClient = (*Client)(nil)
+	_ AuthServiceClient = (*ClientSet)(nil)
+)
+
+// TenantClientConfig describes the auth-service deployment serving a tenant.
+type TenantClientConfig struct {
+	BaseURL string
+	// APIKey is used by SyncUser when the caller passes an empty key, so admin calls carry
+	// credentials issued by the tenant's own cluster.
+	APIKey    string
+	TLSConfig *tls.Config    // see WithTLSConfig
+	Options   []ClientOption // applied after ClientSetConfig.Options
+}
+
+// TenantClientResolver looks up the deployment for tenantSlug, e.g. from a database or a
+// service registry. Returning nil and no error routes the tenant to the default deployment.
+type TenantClientResolver func(ctx context.Context, tenantSlug string) (*TenantClientConfig, error)
+
+// ClientSetConfig configures a ClientSet.
+type ClientSetConfig struct {
+	Default  TenantClientConfig            // shared deployment for tenants without a route
+	Tenants  map[string]TenantClientConfig // static routes by tenant slug, checked first
+	Resolver TenantClientResolver          // dynamic routes; results are cached until Forget
+	Options  []ClientOption                // applied to every Client
+
+	// Logger is the zap logger of every Client.
+	//
+	// Deprecated: Pass WithClientLogger in Options instead.
+	Logger *zap.Logger
+}
+
+// ClientSet routes auth-service calls to per-tenant deployments, for enterprise tenants
+// running dedicated clusters. Calls that name a tenant (Login, Register, SyncUser,
+// CheckTenantExists, CreateTenant) route on it; the others route on the tenant attached with
+// ContextWithTenantSlug, falling back to the default deployment.
+type ClientSet struct {
+	config   ClientSetConfig
+	fallback *tenantClient
+
+	mu      sync.Mutex
+	clients map[string]*tenantClient // by tenant slug
+}
+
+type tenantClient struct {
+	*Client
+	apiKey string
+}
+
+// NewClientSet creates a ClientSet. Clients are built lazily, on a tenant's first call.
+func NewClientSet(config ClientSetConfig) *ClientSet {
+	s := &ClientSet{config: config, clients: make(map[string]*tenantClient)}
+	s.fallback = s.build(config.Default)
+	return s
+}
+
+type tenantSlugKey struct{}
+
+// ContextWithTenantSlug routes ClientSet calls that carry no tenant of their own (Refresh,
+// ClientCredentials, VerifyMFAChallenge, Logout, LogoutAll, GetUser, GetUsers) to
+// tenantSlug's deployment.
+func ContextWithTenantSlug(ctx context.Context, tenantSlug string) context.Context {
+	return context.WithValue(ctx, tenantSlugKey{}, tenantSlug)
+}
+
+// TenantSlugFromContext returns the tenant attached with ContextWithTenantSlug.
+func TenantSlugFromContext(ctx context.Context) (string, bool) {
+	slug, ok := ctx.Value(tenantSlugKey{}).(string)
+	return slug, ok && slug != ""
+}
+
+// ClientFor returns the Client serving tenantSlug, or the default Client for an empty slug or
+// an unrouted tenant.
+func (s *ClientSet) ClientFor(ctx context.Context, tenantSlug string) (*Client, error) {
+	tc, err := s.lookup(ctx, tenantSlug)
+	if err != nil {
+		return nil, err
+	}
+	return tc.Client, nil
+}
+
+// Forget drops the cached Client for tenantSlug so the next call resolves it again, e.g.
+// after the tenant moved clusters.
+func (s *ClientSet) Forget(tenantSlug string) {
+	s.mu.Lock()
+	delete(s.clients, tenantSlug)
+	s.mu.Unlock()
+}
+
+func (s *ClientSet) lookup(ctx context.Context, tenantSlug string) (*tenantClient, error) {
+	if tenantSlug == "" {
+		return s.fallback, nil
+	}
+	s.mu.Lock()
+	tc, ok := s.clients[tenantSlug]
+	s.mu.Unlock()
+	if ok {
+		return tc, nil
+	}
+
+	var route *TenantClientConfig
+	if cfg, ok := s.config.Tenants[tenantSlug]; ok {
+		route = &cfg
+	} else if s.config.Resolver != nil {
+		var err error
+		if route, err = s.config.Resolver(ctx, tenantSlug); err != nil {
+			return nil, newAuthError(KindInternal, "auth-service: resolve deployment for tenant "+tenantSlug, err)
+		}
+	}
+	tc = s.fallback
+	if route != nil {
+		tc = s.build(*route)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.clients[tenantSlug]; ok {
+		return existing, nil // a concurrent call resolved it first
+	}
+	s.clients[tenantSlug] = tc
+	return tc, nil
+}
+
+func (s *ClientSet) build(cfg TenantClientConfig) *tenantClient {
+	var opts []ClientOption
+	if s.config.Logger != nil {
+		opts = append(opts, withZapLogger(s.config.Logger))
+	}
+	opts = append(opts, s.config.Options...)
+	if cfg.TLSConfig != nil {
+		opts = append(opts, WithTLSConfig(cfg.TLSConfig))
+	}
+	opts = append(opts, cfg.Options...)
+	return &tenantClient{Client: New(cfg.BaseURL, opts...), apiKey: cfg.APIKey}
+}
+
+func (s *ClientSet) fromContext(ctx context.Context) (*tenantClient, error) {
+	slug, _ := TenantSlugFromContext(ctx)
+	return s.lookup(ctx, slug)
+}
+
+// Login authenticates against req.TenantSlug's deployment.
+func (s *ClientSet) Login(ctx context.Context, req LoginRequest) (*AuthResponse, error) {
+	tc, err := s.lookup(ctx, req.TenantSlug)
+	if err != nil {
+		return nil, err
+	}
+	return tc.Login(ctx, req)
+}
+
+// VerifyMFAChallenge answers a Login challenge on the deployment of the tenant attached to
+// ctx, which must be the one the login went to.
+func (s *ClientSet) VerifyMFAChallenge(ctx context.Context, challengeID, code string) (*AuthResponse, error) {
+	tc, err := s.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tc.VerifyMFAChallenge(ctx, challengeID, code)
+}
+
+// Register registers with req.TenantSlug's deployment.
+func (s *ClientSet) Register(ctx context.Context, req RegisterRequest) (*AuthResponse, error) {
+	tc, err := s.lookup(ctx, req.TenantSlug)
+	if err != nil {
+		return nil, err
+	}
+	return tc.Register(ctx, req)
+}
+
+// Refresh refreshes against the deployment of the tenant attached to ctx.
+func (s *ClientSet) Refresh(ctx context.Context, refreshToken string) (*AuthResponse, error) {
+	tc, err := s.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tc.Refresh(ctx, refreshToken)
+}
+
+// RefreshWith refreshes with req against the deployment of the tenant attached to ctx.
+func (s *ClientSet) RefreshWith(ctx context.Context, req RefreshRequest) (*AuthResponse, error) {
+	tc, err := s.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tc.RefreshWith(ctx, req)
+}
+
+// ClientCredentials obtains a service token from the deployment of the tenant attached to ctx.
+func (s *ClientSet) ClientCredentials(ctx context.Context, req ClientCredentialsRequest) (*AuthResponse, error) {
+	tc, err := s.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tc.ClientCredentials(ctx, req)
+}
+
+// Logout ends the session on the deployment of the tenant attached to ctx.
+func (s *ClientSet) Logout(ctx context.Context, sessionID, accessToken string) (*LogoutResult, error) {
+	tc, err := s.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tc.Logout(ctx, sessionID, accessToken)
+}
+
+// LogoutAll signs the user out everywhere on the deployment of the tenant attached to ctx.
+func (s *ClientSet) LogoutAll(ctx context.Context, userID, accessToken string) (*LogoutResult, error) {
+	tc, err := s.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tc.LogoutAll(ctx, userID, accessToken)
+}
+
+// GetUser fetches the user from the deployment of the tenant attached to ctx.
+func (s *ClientSet) GetUser(ctx context.Context, userID string, accessToken string) (*User, error) {
+	tc, err := s.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tc.GetUser(ctx, userID, accessToken)
+}
+
+// GetUsers fetches the users from the deployment of the tenant attached to ctx.
+func (s *ClientSet) GetUsers(ctx context.Context, userIDs []string, accessToken string) (map[string]*User, error) {
+	tc, err := s.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tc.GetUsers(ctx, userIDs, accessToken)
+}
+
+// SyncUser syncs the user into req.TenantSlug's deployment. An empty apiKey uses the API key
+// configured for that deployment.
+func (s *ClientSet) SyncUser(ctx context.Context, req SyncUserRequest, apiKey string) (*SyncUserResponse, error) {
+	tc, err := s.lookup(ctx, req.TenantSlug)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == "" {
+		apiKey = tc.apiKey
+	}
+	return tc.SyncUser(ctx, req, apiKey)
+}
+
+// CheckTenantExists asks the deployment routed for tenantSlug.
+func (s *ClientSet) CheckTenantExists(ctx context.Context, tenantSlug string) (bool, error) {
+	tc, err := s.lookup(ctx, tenantSlug)
+	if err != nil {
+		return false, err
+	}
+	return tc.CheckTenantExists(ctx, tenantSlug)
+}
+
+// CreateTenant creates the tenant on the deployment routed for req.Slug.
+func (s *ClientSet) CreateTenant(ctx context.Context, req TenantRequest) (*TenantResponse, error) {
+	tc, err := s.lookup(ctx, req.Slug)
+	if err != nil {
+		return nil, err
+	}
+	return tc.CreateTenant(ctx, req)
+}
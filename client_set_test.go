@@ -0,0 +1,76 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientSetRouting(t *testing.T) {
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v1/admin/users/sync" {
+				w.Write([]byte(`{"user_id":"` + name + `","message":"` + r.Header.Get("X-API-Key") + `"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"` + name + `"}`))
+		}))
+	}
+	shared, acme, globex := newServer("shared"), newServer("acme"), newServer("globex")
+	defer shared.Close()
+	defer acme.Close()
+	defer globex.Close()
+
+	resolved := 0
+	set := NewClientSet(ClientSetConfig{
+		Default: TenantClientConfig{BaseURL: shared.URL},
+		Tenants: map[string]TenantClientConfig{"acme": {BaseURL: acme.URL, APIKey: "acme-key"}},
+		Resolver: func(ctx context.Context, slug string) (*TenantClientConfig, error) {
+			resolved++
+			switch slug {
+			case "globex":
+				return &TenantClientConfig{BaseURL: globex.URL}, nil
+			case "broken":
+				return nil, errors.New("registry down")
+			}
+			return nil, nil
+		},
+	})
+	ctx := context.Background()
+
+	tests := []struct{ tenant, want string }{
+		{"acme", "acme"},
+		{"globex", "globex"},
+		{"globex", "globex"},
+		{"initech", "shared"},
+		{"", "shared"},
+	}
+	for _, tt := range tests {
+		resp, err := set.Login(ctx, LoginRequest{TenantSlug: tt.tenant})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.AccessToken != tt.want {
+			t.Errorf("Login(%q) routed to %q, want %q", tt.tenant, resp.AccessToken, tt.want)
+		}
+	}
+	if resolved != 2 {
+		t.Errorf("resolver called %d times, want 2 (globex cached, acme static)", resolved)
+	}
+
+	resp, err := set.Refresh(ContextWithTenantSlug(ctx, "acme"), "rt")
+	if err != nil || resp.AccessToken != "acme" {
+		t.Errorf("Refresh with tenant context = %+v, %v; want acme", resp, err)
+	}
+
+	sync, err := set.SyncUser(ctx, SyncUserRequest{Email: "a@acme.test", TenantSlug: "acme"}, "")
+	if err != nil || sync.UserID != "acme" || sync.Message != "acme-key" {
+		t.Errorf("SyncUser = %+v, %v; want acme deployment with its API key", sync, err)
+	}
+
+	if _, err := set.Login(ctx, LoginRequest{TenantSlug: "broken"}); !errors.Is(err, &AuthError{Kind: KindInternal}) {
+		t.Errorf("resolver failure: err = %v, want internal AuthError", err)
+	}
+}
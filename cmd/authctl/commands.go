@@ -0,0 +1,309 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+)
+
+func (a *app) client() *authclient.Client {
+	return authclient.New(a.url)
+}
+
+func (a *app) login(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	clientID := fs.String("client-id", "authctl", "OAuth client ID")
+	scope := fs.String("scope", "", "space-separated scopes")
+	if err := fs.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	if err := a.requireURL(); err != nil {
+		return err
+	}
+
+	client := a.client()
+	auth, err := client.StartDeviceAuthorization(ctx, *clientID, *scope)
+	if err != nil {
+		return err
+	}
+	uri := auth.VerificationURIComplete
+	if uri == "" {
+		uri = auth.VerificationURI
+	}
+	fmt.Fprintf(a.stderr, "Open %s and enter code %s\nWaiting for approval...\n", uri, auth.UserCode)
+
+	resp, err := client.PollDeviceToken(ctx, *clientID, auth)
+	if err != nil {
+		return err
+	}
+	if err := a.saveTokens(ctx, resp); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Logged in (profile %q).\n", a.profile)
+	return nil
+}
+
+func (a *app) tokenInspect(ctx context.Context, args []string) error {
+	var token string
+	switch {
+	case len(args) == 0:
+		stored, err := a.loadTokens(ctx)
+		if err != nil {
+			return err
+		}
+		token = stored.Tokens.AccessToken
+	case args[0] == "-":
+		line, err := bufio.NewReader(a.stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		token = strings.TrimSpace(line)
+	default:
+		token = args[0]
+	}
+	decoded, err := decodeJWT(token)
+	if err != nil {
+		return err
+	}
+	return a.printJSON(decoded)
+}
+
+// inspectedToken is the output of "token inspect". The signature is not verified.
+type inspectedToken struct {
+	Header    map[string]any `json:"header"`
+	Claims    map[string]any `json:"claims"`
+	ExpiresAt string         `json:"expires_at,omitempty"`
+	Expired   bool           `json:"expired"`
+}
+
+func decodeJWT(token string) (*inspectedToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("not a JWT: expected three dot-separated parts")
+	}
+	var out inspectedToken
+	for i, dst := range []*map[string]any{&out.Header, &out.Claims} {
+		raw, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			return nil, fmt.Errorf("decode part %d: %w", i+1, err)
+		}
+		if err := json.Unmarshal(raw, dst); err != nil {
+			return nil, fmt.Errorf("decode part %d: %w", i+1, err)
+		}
+	}
+	if exp, ok := out.Claims["exp"].(float64); ok {
+		t := time.Unix(int64(exp), 0)
+		out.ExpiresAt = t.UTC().Format(time.RFC3339)
+		out.Expired = time.Now().After(t)
+	}
+	return &out, nil
+}
+
+func (a *app) tokenRefresh(ctx context.Context) error {
+	if err := a.requireURL(); err != nil {
+		return err
+	}
+	stored, err := a.loadTokens(ctx)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client().Refresh(ctx, stored.Tokens.RefreshToken)
+	if err != nil {
+		return err
+	}
+	if err := a.saveTokens(ctx, resp); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Refreshed; access token expires in %ds.\n", resp.ExpiresIn)
+	return nil
+}
+
+func (a *app) jwks(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("jwks", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	jwksURL := fs.String("jwks-url", "", "JWKS URL (default: <url>/api/v1/.well-known/jwks.json)")
+	if err := fs.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	if *jwksURL == "" {
+		if err := a.requireURL(); err != nil {
+			return err
+		}
+		*jwksURL = a.url + "/api/v1/.well-known/jwks.json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *jwksURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("JWKS fetch: status %d", resp.StatusCode)
+	}
+	var doc any
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return fmt.Errorf("JWKS decode: %w", err)
+	}
+	return a.printJSON(doc)
+}
+
+func (a *app) tenantCheck(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return usageError("tenant check: expected SLUG")
+	}
+	if err := a.requireURL(); err != nil {
+		return err
+	}
+	exists, err := a.client().CheckTenantExists(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return a.printJSON(map[string]any{"slug": args[0], "exists": exists})
+}
+
+func (a *app) tenantCreate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("tenant create", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var req authclient.TenantRequest
+	fs.StringVar(&req.Slug, "slug", "", "tenant slug (required)")
+	fs.StringVar(&req.Name, "name", "", "display name")
+	fs.StringVar(&req.ID, "id", "", "tenant UUID, when it must match other services")
+	fs.StringVar(&req.ContactEmail, "email", "", "contact email")
+	if err := fs.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	if req.Slug == "" {
+		return usageError("tenant create: -slug is required")
+	}
+	if err := a.requireURL(); err != nil {
+		return err
+	}
+	tenant, err := a.client().CreateTenant(ctx, req)
+	if err != nil {
+		return err
+	}
+	return a.printJSON(tenant)
+}
+
+func (a *app) apiKeyValidate(ctx context.Context) error {
+	if err := a.requireURL(); err != nil {
+		return err
+	}
+	key, err := a.apiKey()
+	if err != nil {
+		return err
+	}
+	result, err := authclient.NewAPIKeyValidator(a.url, nil).ValidateAPIKeyFull(ctx, key)
+	if err != nil {
+		return err
+	}
+	return a.printJSON(result)
+}
+
+func (a *app) userSync(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("user sync", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var req authclient.SyncUserRequest
+	fs.StringVar(&req.Email, "email", "", "user email (required)")
+	fs.StringVar(&req.TenantSlug, "tenant", "", "tenant slug (required)")
+	fs.StringVar(&req.Service, "service", "", "calling service name")
+	if err := fs.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	if req.Email == "" || req.TenantSlug == "" {
+		return usageError("user sync: -email and -tenant are required")
+	}
+	if err := a.requireURL(); err != nil {
+		return err
+	}
+	key, err := a.apiKey()
+	if err != nil {
+		return err
+	}
+	resp, err := a.client().SyncUser(ctx, req, key)
+	if err != nil {
+		return err
+	}
+	return a.printJSON(resp)
+}
+
+// apiKey reads the key from $AUTHCTL_API_KEY or the first line of stdin, never from flags,
+// so it stays out of shell history and process listings.
+func (a *app) apiKey() (string, error) {
+	if key := os.Getenv("AUTHCTL_API_KEY"); key != "" {
+		return key, nil
+	}
+	line, err := bufio.NewReader(a.stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	if key := strings.TrimSpace(line); key != "" {
+		return key, nil
+	}
+	return "", usageError("no API key: set AUTHCTL_API_KEY or pipe it on stdin")
+}
+
+// tokenStore opens the encrypted store under the user config directory, creating its key on
+// first use.
+func (a *app) tokenStore() (*authclient.FileTokenStore, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(base, "authctl")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	keyPath := filepath.Join(dir, "store.key")
+	key, err := os.ReadFile(keyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(keyPath, key, 0o600); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return authclient.NewFileTokenStore(filepath.Join(dir, "tokens"), key)
+}
+
+func (a *app) saveTokens(ctx context.Context, resp *authclient.AuthResponse) error {
+	store, err := a.tokenStore()
+	if err != nil {
+		return err
+	}
+	return store.Save(ctx, a.profile, authclient.NewStoredTokens(resp, time.Now()))
+}
+
+func (a *app) loadTokens(ctx context.Context) (*authclient.StoredTokens, error) {
+	store, err := a.tokenStore()
+	if err != nil {
+		return nil, err
+	}
+	stored, err := store.Load(ctx, a.profile)
+	if errors.Is(err, authclient.ErrTokensNotFound) {
+		return nil, fmt.Errorf("no tokens for profile %q: run authctl login", a.profile)
+	}
+	return stored, err
+}
@@ -0,0 +1,141 @@
+// Command authctl is an operator CLI for auth-service built on authclient: device-flow login,
+// token inspection and refresh, JWKS dumps, tenant checks, API key validation and user sync.
+//
+//	authctl [-url URL] <command> [flags]
+//
+// The auth-service URL defaults to $AUTH_SERVICE_URL. Tokens from login are kept encrypted
+// under the user config directory (authctl/), keyed by -profile.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+)
+
+const usage = `usage: authctl [-url URL] [-profile NAME] <command> [flags]
+
+commands:
+  login -client-id ID [-scope S]          device-flow login; stores tokens for the profile
+  token inspect [TOKEN|-]                 decode a JWT (default: the profile's access token)
+  token refresh                           refresh the profile's tokens
+  jwks [-jwks-url URL]                    fetch and print the JWKS
+  tenant check SLUG                       report whether a tenant exists
+  tenant create -slug S [-name N] [-id ID] [-email E]
+  apikey validate                         validate the API key in $AUTHCTL_API_KEY or on stdin
+  user sync -email E -tenant SLUG [-service S]   sync a user; admin API key in $AUTHCTL_API_KEY
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		var ue usageError
+		if errors.As(err, &ue) {
+			fmt.Fprintf(os.Stderr, "authctl: %v\n\n%s", err, usage)
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "authctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+// app carries global flags and I/O for the commands.
+type app struct {
+	url     string
+	profile string
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("authctl", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	a := &app{stdin: stdin, stdout: stdout, stderr: stderr}
+	fs.StringVar(&a.url, "url", os.Getenv("AUTH_SERVICE_URL"), "auth-service base URL")
+	fs.StringVar(&a.profile, "profile", "default", "token profile name")
+	if err := fs.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	a.url = strings.TrimSuffix(a.url, "/")
+
+	rest := fs.Args()
+	if len(rest) == 0 {
+		return usageError("missing command")
+	}
+	cmd, rest := rest[0], rest[1:]
+	sub := func() (string, []string, error) {
+		if len(rest) == 0 {
+			return "", nil, usageError(cmd + ": missing subcommand")
+		}
+		return rest[0], rest[1:], nil
+	}
+
+	switch cmd {
+	case "login":
+		return a.login(ctx, rest)
+	case "token":
+		name, args, err := sub()
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "inspect", "decode":
+			return a.tokenInspect(ctx, args)
+		case "refresh":
+			return a.tokenRefresh(ctx)
+		}
+	case "jwks":
+		return a.jwks(ctx, rest)
+	case "tenant":
+		name, args, err := sub()
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "check":
+			return a.tenantCheck(ctx, args)
+		case "create":
+			return a.tenantCreate(ctx, args)
+		}
+	case "apikey":
+		if name, _, err := sub(); err != nil || name != "validate" {
+			return usageError("apikey: unknown subcommand")
+		}
+		return a.apiKeyValidate(ctx)
+	case "user":
+		name, args, err := sub()
+		if err != nil {
+			return err
+		}
+		if name == "sync" {
+			return a.userSync(ctx, args)
+		}
+	}
+	return usageError("unknown command: " + strings.Join(fs.Args(), " "))
+}
+
+// printJSON writes v indented to stdout.
+func (a *app) printJSON(v any) error {
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (a *app) requireURL() error {
+	if a.url == "" {
+		return usageError("auth-service URL not set (use -url or AUTH_SERVICE_URL)")
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTokenInspect(t *testing.T) {
+	// {"alg":"RS256","kid":"k1"}.{"sub":"u1","exp":1}.sig
+	token := "eyJhbGciOiJSUzI1NiIsImtpZCI6ImsxIn0.eyJzdWIiOiJ1MSIsImV4cCI6MX0.c2ln"
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"token", "inspect", "-"}, strings.NewReader(token+"\n"), &out, &out); err != nil {
+		t.Fatal(err)
+	}
+	var got inspectedToken
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Header["kid"] != "k1" || got.Claims["sub"] != "u1" || !got.Expired {
+		t.Fatalf("decoded = %+v", got)
+	}
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	for _, args := range [][]string{nil, {"bogus"}, {"token"}, {"tenant", "check"}} {
+		err := run(context.Background(), args, strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{})
+		var ue usageError
+		if !errors.As(err, &ue) {
+			t.Errorf("run(%q) = %v, want usage error", args, err)
+		}
+	}
+}
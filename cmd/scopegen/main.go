@@ -0,0 +1,125 @@
+// Command scopegen generates Go constants for the scopes in auth-service's catalog, so
+// RequireScope call sites use scopes.OrdersRead instead of a typo-prone "orders:read":
+//
+//	//go:generate go run github.com/Bengo-Hub/shared-auth-client/cmd/scopegen -o scopes.gen.go
+//
+// The catalog is fetched with Client.ListScopes from -url (default $AUTH_SERVICE_URL), or
+// read from -catalog, a JSON file holding ListScopes' response, for builds without network
+// access. The package name defaults to $GOPACKAGE, which go generate sets.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"unicode"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+)
+
+func main() {
+	url := flag.String("url", os.Getenv("AUTH_SERVICE_URL"), "auth-service base URL")
+	catalog := flag.String("catalog", "", "read the catalog from this JSON file instead of auth-service")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated file")
+	out := flag.String("o", "scopes.gen.go", "output file")
+	flag.Parse()
+	if *pkg == "" {
+		*pkg = "scopes"
+	}
+
+	scopes, err := loadScopes(context.Background(), *url, *catalog)
+	if err == nil {
+		var src []byte
+		if src, err = generate(*pkg, scopes); err == nil {
+			err = os.WriteFile(*out, src, 0o644)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "scopegen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func loadScopes(ctx context.Context, url, catalog string) ([]authclient.Scope, error) {
+	if catalog != "" {
+		data, err := os.ReadFile(catalog)
+		if err != nil {
+			return nil, err
+		}
+		var doc struct {
+			Scopes []authclient.Scope `json:"scopes"`
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", catalog, err)
+		}
+		return doc.Scopes, nil
+	}
+	if url == "" {
+		return nil, fmt.Errorf("no catalog: set -url, AUTH_SERVICE_URL or -catalog")
+	}
+	return authclient.New(strings.TrimSuffix(url, "/")).ListScopes(ctx)
+}
+
+// generate renders the constants file. Two scopes mapping to the same identifier are an
+// error rather than a silently dropped constant.
+func generate(pkg string, scopes []authclient.Scope) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by scopegen from auth-service's scope catalog. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("// Scopes in auth-service's catalog, for RequireScope and Claims.HasScope.\nconst (\n")
+	seen := make(map[string]string, len(scopes))
+	for _, s := range scopes {
+		ident := identifier(s.Name)
+		if ident == "" {
+			return nil, fmt.Errorf("scope %q: no identifier can be derived", s.Name)
+		}
+		if prev, ok := seen[ident]; ok {
+			return nil, fmt.Errorf("scopes %q and %q both map to %s", prev, s.Name, ident)
+		}
+		seen[ident] = s.Name
+		fmt.Fprintf(&b, "\t// %s is %q.", ident, s.Name)
+		if doc := strings.Join(strings.Fields(s.Description), " "); doc != "" {
+			b.WriteString(" " + doc)
+		}
+		b.WriteString("\n")
+		if s.Service != "" {
+			fmt.Fprintf(&b, "\t// Defined by %s.\n", s.Service)
+		}
+		if s.Deprecated {
+			b.WriteString("\t//\n\t// Deprecated: auth-service marks this scope deprecated.\n")
+		}
+		fmt.Fprintf(&b, "\t%s = %q\n", ident, s.Name)
+	}
+	b.WriteString(")\n")
+	return format.Source(b.Bytes())
+}
+
+// initialisms are spelled in capitals, as Go names do.
+var initialisms = map[string]bool{"api": true, "id": true, "mfa": true, "sso": true, "url": true, "jwt": true}
+
+// identifier turns a scope name into an exported Go identifier: "orders:read" becomes
+// OrdersRead and "api_keys.write" APIKeysWrite.
+func identifier(scope string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(scope, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if initialisms[strings.ToLower(part)] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	ident := b.String()
+	if ident != "" && unicode.IsDigit([]rune(ident)[0]) {
+		ident = "Scope" + ident
+	}
+	return ident
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("scopes", []authclient.Scope{
+		{Name: "orders:read", Description: "Read orders and their line items.", Service: "orders"},
+		{Name: "api_keys.write"},
+		{Name: "legacy:admin", Deprecated: true},
+		{Name: "2fa:manage"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"package scopes",
+		`// OrdersRead is "orders:read". Read orders and their line items.`,
+		`OrdersRead = "orders:read"`,
+		`APIKeysWrite = "api_keys.write"`,
+		"// Deprecated:",
+		`Scope2faManage = "2fa:manage"`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source lacks %q:\n%s", want, src)
+		}
+	}
+
+	if _, err := generate("scopes", []authclient.Scope{{Name: "orders:read"}, {Name: "orders.read"}}); err == nil {
+		t.Error("colliding scopes: want error")
+	}
+}
@@ -0,0 +1,223 @@
+// Package conformance is a contract test suite for authclient against a running
+// auth-service. Staging pipelines run it to check that a client release and a service
+// release still agree on login, refresh, JWKS, token validation and API key validation:
+//
+//	AUTH_SERVICE_URL=https://sso.staging.example.com \
+//	AUTH_CONFORMANCE_EMAIL=ci@example.com AUTH_CONFORMANCE_PASSWORD=... \
+//	AUTH_CONFORMANCE_TENANT=acme AUTH_CONFORMANCE_API_KEY=... \
+//	go test -tags conformance ./conformance
+//
+// Other modules can call Run from their own tests to pin the versions they deploy.
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+)
+
+// Config points the suite at an auth-service and a test account on it.
+type Config struct {
+	BaseURL  string // auth-service base URL
+	JWKSURL  string // default: BaseURL + "/api/v1/.well-known/jwks.json"
+	Issuer   string // expected iss; default BaseURL
+	Audience string // expected aud; default "codevertex"
+
+	Email      string // login account; its password must be Password
+	Password   string
+	TenantSlug string
+
+	// APIKey is a valid API key for the API key checks, which are skipped when it is empty.
+	APIKey string
+}
+
+// ConfigFromEnv reads the suite configuration from AUTH_SERVICE_URL, AUTH_JWKS_URL,
+// AUTH_ISSUER, AUTH_AUDIENCE and AUTH_CONFORMANCE_{EMAIL,PASSWORD,TENANT,API_KEY}. ok is
+// false when AUTH_SERVICE_URL is unset.
+func ConfigFromEnv() (cfg Config, ok bool) {
+	cfg = Config{
+		BaseURL:    os.Getenv("AUTH_SERVICE_URL"),
+		JWKSURL:    os.Getenv("AUTH_JWKS_URL"),
+		Issuer:     os.Getenv("AUTH_ISSUER"),
+		Audience:   os.Getenv("AUTH_AUDIENCE"),
+		Email:      os.Getenv("AUTH_CONFORMANCE_EMAIL"),
+		Password:   os.Getenv("AUTH_CONFORMANCE_PASSWORD"),
+		TenantSlug: os.Getenv("AUTH_CONFORMANCE_TENANT"),
+		APIKey:     os.Getenv("AUTH_CONFORMANCE_API_KEY"),
+	}
+	return cfg, cfg.BaseURL != ""
+}
+
+func (c *Config) setDefaults() {
+	c.BaseURL = strings.TrimSuffix(c.BaseURL, "/")
+	if c.JWKSURL == "" {
+		c.JWKSURL = c.BaseURL + "/api/v1/.well-known/jwks.json"
+	}
+	if c.Issuer == "" {
+		c.Issuer = c.BaseURL
+	}
+	if c.Audience == "" {
+		c.Audience = "codevertex"
+	}
+}
+
+// Run executes the suite as subtests of t. Each flow is a named subtest (JWKS, Login,
+// Validate, Refresh, InvalidCredentials, APIKey) so pipeline reports show which contract
+// broke.
+func Run(t *testing.T, cfg Config) {
+	t.Helper()
+	cfg.setDefaults()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	client := authclient.New(cfg.BaseURL)
+	validator, err := authclient.NewValidator(authclient.DefaultConfig(cfg.JWKSURL, cfg.Issuer, cfg.Audience))
+	if err != nil {
+		t.Fatalf("NewValidator: %v", err)
+	}
+	t.Cleanup(validator.Stop)
+
+	t.Run("JWKS", func(t *testing.T) { testJWKS(t, ctx, cfg) })
+
+	var login *authclient.AuthResponse
+	t.Run("Login", func(t *testing.T) {
+		if cfg.Email == "" {
+			t.Skip("no test account configured")
+		}
+		var err error
+		login, err = client.Login(ctx, authclient.LoginRequest{Email: cfg.Email, Password: cfg.Password, TenantSlug: cfg.TenantSlug})
+		if err != nil {
+			t.Fatalf("Login: %v", err)
+		}
+		if login.AccessToken == "" || login.RefreshToken == "" {
+			t.Fatalf("Login returned access token %t, refresh token %t", login.AccessToken != "", login.RefreshToken != "")
+		}
+		if !strings.EqualFold(login.TokenType, "bearer") {
+			t.Errorf("token_type = %q, want Bearer", login.TokenType)
+		}
+		if login.ExpiresIn <= 0 {
+			t.Errorf("expires_in = %d, want > 0", login.ExpiresIn)
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		if login == nil {
+			t.Skip("login did not succeed")
+		}
+		claims, err := validator.ValidateToken(login.AccessToken)
+		if err != nil {
+			t.Fatalf("ValidateToken: %v", err)
+		}
+		if claims.Subject == "" {
+			t.Error("access token has no sub")
+		}
+		if cfg.TenantSlug != "" && claims.TenantSlug != cfg.TenantSlug {
+			t.Errorf("tenant_slug = %q, want %q", claims.TenantSlug, cfg.TenantSlug)
+		}
+		if _, err := validator.ValidateToken(tamper(login.AccessToken)); err == nil {
+			t.Error("a token with a modified payload validated")
+		}
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		if login == nil {
+			t.Skip("login did not succeed")
+		}
+		refreshed, err := client.Refresh(ctx, login.RefreshToken)
+		if err != nil {
+			t.Fatalf("Refresh: %v", err)
+		}
+		if _, err := validator.ValidateToken(refreshed.AccessToken); err != nil {
+			t.Fatalf("refreshed access token: %v", err)
+		}
+		if refreshed.RefreshToken != "" && refreshed.RefreshToken != login.RefreshToken {
+			// Rotating servers must reject the superseded refresh token.
+			if _, err := client.Refresh(ctx, login.RefreshToken); err == nil {
+				t.Error("superseded refresh token was accepted after rotation")
+			}
+		}
+	})
+
+	t.Run("InvalidCredentials", func(t *testing.T) {
+		if cfg.Email == "" {
+			t.Skip("no test account configured")
+		}
+		_, err := client.Login(ctx, authclient.LoginRequest{Email: cfg.Email, Password: cfg.Password + "-wrong", TenantSlug: cfg.TenantSlug})
+		if kind := authclient.KindOf(err); kind != authclient.KindInvalidCredentials {
+			t.Errorf("wrong password: kind %v (%v), want invalid credentials", kind, err)
+		}
+	})
+
+	t.Run("APIKey", func(t *testing.T) {
+		if cfg.APIKey == "" {
+			t.Skip("no API key configured")
+		}
+		keys := authclient.NewAPIKeyValidator(cfg.BaseURL, nil)
+		result, err := keys.ValidateAPIKeyFull(ctx, cfg.APIKey)
+		if err != nil {
+			t.Fatalf("ValidateAPIKeyFull: %v", err)
+		}
+		if result.ClientID == "" || result.TenantID == "" {
+			t.Errorf("validation result lacks client_id or tenant_id: %+v", result)
+		}
+		_, err = keys.ValidateAPIKeyFull(ctx, cfg.APIKey+"x")
+		if kind := authclient.KindOf(err); kind != authclient.KindInvalidCredentials {
+			t.Errorf("unknown key: kind %v (%v), want invalid credentials", kind, err)
+		}
+	})
+}
+
+func testJWKS(t *testing.T, ctx context.Context, cfg Config) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.JWKSURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", cfg.JWKSURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s: status %d", cfg.JWKSURL, resp.StatusCode)
+	}
+	var set struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Alg string `json:"alg"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		t.Fatalf("decode JWKS: %v", err)
+	}
+	if len(set.Keys) == 0 {
+		t.Fatal("JWKS has no keys")
+	}
+	for _, k := range set.Keys {
+		if k.Kid == "" || k.Kty != "RSA" {
+			t.Errorf("key %q: kty %q; every key needs a kid and must be RSA", k.Kid, k.Kty)
+		}
+	}
+}
+
+// tamper flips a character in the token's payload so its signature no longer matches.
+func tamper(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[1] == "" {
+		return token + "x"
+	}
+	b := []byte(parts[1])
+	if b[0] == 'A' {
+		b[0] = 'B'
+	} else {
+		b[0] = 'A'
+	}
+	parts[1] = string(b)
+	return strings.Join(parts, ".")
+}
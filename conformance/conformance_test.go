@@ -0,0 +1,25 @@
+package conformance
+
+import (
+	"testing"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"github.com/Bengo-Hub/shared-auth-client/authclienttest"
+)
+
+// The suite must pass against the in-process emulator, which mirrors auth-service.
+func TestRunAgainstDevIssuer(t *testing.T) {
+	d := authclienttest.NewDevIssuer()
+	defer d.Close()
+	d.AddUser("ci@example.com", "s3cret", authclient.Claims{TenantSlug: "acme"})
+
+	Run(t, Config{
+		BaseURL:    d.URL(),
+		JWKSURL:    d.JWKSURL(),
+		Issuer:     authclienttest.DevIssuerName,
+		Audience:   authclienttest.DevAudience,
+		Email:      "ci@example.com",
+		Password:   "s3cret",
+		TenantSlug: "acme",
+	})
+}
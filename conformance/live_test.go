@@ -0,0 +1,13 @@
+//go:build conformance
+
+package conformance
+
+import "testing"
+
+func TestLiveAuthService(t *testing.T) {
+	cfg, ok := ConfigFromEnv()
+	if !ok {
+		t.Fatal("AUTH_SERVICE_URL must be set for the conformance suite")
+	}
+	Run(t, cfg)
+}
@@ -0,0 +1,192 @@
+package authclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CookieSessionConfig configures CookieSession. Zero values are production-safe defaults.
+type CookieSessionConfig struct {
+	AccessCookie  string // defaults to "access_token"
+	RefreshCookie string // defaults to "refresh_token"
+	SessionCookie string // defaults to "session_id"; used when ReferenceOnly is set
+
+	// ReferenceOnly stores only a random session handle in the browser; the BFF keeps the
+	// tokens server-side in a TokenStore keyed by that handle. auth-service's session ID is
+	// never put in the cookie, so it cannot be read or planted through it.
+	ReferenceOnly bool
+
+	Domain string
+	Path   string // defaults to "/"
+	// RefreshPath narrows the refresh cookie to the refresh endpoint (e.g. "/auth/refresh") so
+	// it is not sent with every request. Defaults to Path.
+	RefreshPath string
+	SameSite    http.SameSite // defaults to http.SameSiteLaxMode
+	// Insecure drops the Secure attribute. Only for local development over plain HTTP.
+	Insecure bool
+	// PersistOnlyRemembered writes browser-session cookies (dropped when the browser closes)
+	// unless the session was created with RememberMe, giving "keep me signed in" its usual
+	// meaning. By default cookies always live as long as the tokens.
+	PersistOnlyRemembered bool
+	// Keyring, when set, encrypts every cookie value so tokens are opaque to the browser and
+	// to anything that can read cookies (logs, extensions).
+	Keyring *Keyring
+}
+
+// CookieSession writes auth-service sessions into Secure, HttpOnly, SameSite cookies for
+// backend-for-frontend web apps. Cookie lifetimes follow ExpiresIn/RefreshExpiresIn.
+type CookieSession struct {
+	config CookieSessionConfig
+}
+
+// NewCookieSession creates a CookieSession, filling in defaults.
+func NewCookieSession(config CookieSessionConfig) *CookieSession {
+	if config.AccessCookie == "" {
+		config.AccessCookie = "access_token"
+	}
+	if config.RefreshCookie == "" {
+		config.RefreshCookie = "refresh_token"
+	}
+	if config.SessionCookie == "" {
+		config.SessionCookie = "session_id"
+	}
+	if config.Path == "" {
+		config.Path = "/"
+	}
+	if config.RefreshPath == "" {
+		config.RefreshPath = config.Path
+	}
+	if config.SameSite == 0 {
+		config.SameSite = http.SameSiteLaxMode
+	}
+	return &CookieSession{config: config}
+}
+
+// Write stores a freshly issued session (e.g. after Login). Without a refresh token in resp,
+// any previous refresh cookie is cleared. ReferenceOnly sessions are written with
+// WriteReference instead.
+func (s *CookieSession) Write(w http.ResponseWriter, resp *AuthResponse) error {
+	return s.write(w, resp, false)
+}
+
+// Rotate stores the result of a token refresh. Unlike Write, the refresh cookie is left
+// untouched when auth-service did not rotate the refresh token.
+func (s *CookieSession) Rotate(w http.ResponseWriter, resp *AuthResponse) error {
+	return s.write(w, resp, true)
+}
+
+// WriteReference writes the cookie of a ReferenceOnly session: handle is the random key
+// under which resp is kept in the TokenStore, never resp.SessionID. The cookie lives as long
+// as the refresh token (or the access token without one).
+func (s *CookieSession) WriteReference(w http.ResponseWriter, handle string, resp *AuthResponse) error {
+	if !s.config.ReferenceOnly {
+		return errors.New("cookie session: WriteReference requires ReferenceOnly")
+	}
+	if handle == "" {
+		return errors.New("cookie session: empty session handle")
+	}
+	maxAge := resp.RefreshExpiresIn
+	if maxAge <= 0 {
+		maxAge = resp.ExpiresIn
+	}
+	return s.set(w, s.config.SessionCookie, handle, s.config.Path, s.lifetime(resp, maxAge))
+}
+
+// lifetime is the cookie Max-Age for a token living seconds; see PersistOnlyRemembered.
+func (s *CookieSession) lifetime(resp *AuthResponse, seconds int) int {
+	if s.config.PersistOnlyRemembered && !resp.RememberMe {
+		return 0
+	}
+	return seconds
+}
+
+func (s *CookieSession) write(w http.ResponseWriter, resp *AuthResponse, keepRefresh bool) error {
+	if s.config.ReferenceOnly {
+		return errors.New("cookie session: ReferenceOnly sessions are written with WriteReference")
+	}
+	lifetime := func(seconds int) int { return s.lifetime(resp, seconds) }
+
+	if err := s.set(w, s.config.AccessCookie, resp.AccessToken, s.config.Path, lifetime(resp.ExpiresIn)); err != nil {
+		return err
+	}
+	switch {
+	case resp.RefreshToken != "":
+		return s.set(w, s.config.RefreshCookie, resp.RefreshToken, s.config.RefreshPath, lifetime(resp.RefreshExpiresIn))
+	case !keepRefresh:
+		http.SetCookie(w, s.cookie(s.config.RefreshCookie, "", s.config.RefreshPath, -1))
+	}
+	return nil
+}
+
+// set writes one cookie, sealing its value when a Keyring is configured.
+func (s *CookieSession) set(w http.ResponseWriter, name, value, path string, maxAge int) error {
+	if s.config.Keyring != nil {
+		sealed, err := s.config.Keyring.Seal(name, []byte(value))
+		if err != nil {
+			return fmt.Errorf("cookie session: seal %s: %w", name, err)
+		}
+		value = sealed
+	}
+	http.SetCookie(w, s.cookie(name, value, path, maxAge))
+	return nil
+}
+
+// Tokens returns the access and refresh tokens carried by r, if any. Cookies that fail to
+// decrypt are treated as absent.
+func (s *CookieSession) Tokens(r *http.Request) (accessToken, refreshToken string) {
+	return s.value(r, s.config.AccessCookie), s.value(r, s.config.RefreshCookie)
+}
+
+// SessionID returns the session handle carried by r in ReferenceOnly mode: the TokenStore
+// key written by WriteReference, not auth-service's session ID.
+func (s *CookieSession) SessionID(r *http.Request) string {
+	return s.value(r, s.config.SessionCookie)
+}
+
+// value reads a cookie, opening it when a Keyring is configured. Values sealed with an older
+// key still open; the next Write or Rotate re-seals them with the primary key.
+func (s *CookieSession) value(r *http.Request, name string) string {
+	c, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	if s.config.Keyring == nil {
+		return c.Value
+	}
+	plaintext, err := s.config.Keyring.Open(name, c.Value)
+	if err != nil {
+		return ""
+	}
+	return string(plaintext)
+}
+
+// Clear expires every cookie CookieSession may have set, e.g. on logout.
+func (s *CookieSession) Clear(w http.ResponseWriter) {
+	http.SetCookie(w, s.cookie(s.config.AccessCookie, "", s.config.Path, -1))
+	http.SetCookie(w, s.cookie(s.config.RefreshCookie, "", s.config.RefreshPath, -1))
+	http.SetCookie(w, s.cookie(s.config.SessionCookie, "", s.config.Path, -1))
+}
+
+// cookie builds a hardened cookie. maxAge > 0 sets Max-Age (and Expires for old browsers),
+// 0 makes a browser-session cookie and < 0 deletes the cookie.
+func (s *CookieSession) cookie(name, value, path string, maxAge int) *http.Cookie {
+	c := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   s.config.Domain,
+		MaxAge:   maxAge,
+		Secure:   !s.config.Insecure,
+		HttpOnly: true,
+		SameSite: s.config.SameSite,
+	}
+	switch {
+	case maxAge > 0:
+		c.Expires = time.Now().Add(time.Duration(maxAge) * time.Second)
+	case maxAge < 0:
+		c.Expires = time.Unix(0, 0)
+	}
+	return c
+}
@@ -0,0 +1,173 @@
+package authclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// setCookies returns the cookies written to rec by name.
+func setCookies(rec *httptest.ResponseRecorder) map[string]*http.Cookie {
+	cookies := make(map[string]*http.Cookie)
+	for _, c := range rec.Result().Cookies() {
+		cookies[c.Name] = c
+	}
+	return cookies
+}
+
+func TestCookieSessionAttributes(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       CookieSessionConfig
+		wantSecure   bool
+		wantSameSite http.SameSite
+	}{
+		{"defaults", CookieSessionConfig{}, true, http.SameSiteLaxMode},
+		{"strict", CookieSessionConfig{SameSite: http.SameSiteStrictMode}, true, http.SameSiteStrictMode},
+		{"insecure for local development", CookieSessionConfig{Insecure: true}, false, http.SameSiteLaxMode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			if err := NewCookieSession(tt.config).Write(rec, &AuthResponse{AccessToken: "at", RefreshToken: "rt", ExpiresIn: 900, RefreshExpiresIn: 86400}); err != nil {
+				t.Fatal(err)
+			}
+			cookies := setCookies(rec)
+			if len(cookies) != 2 {
+				t.Fatalf("cookies = %v, want access and refresh", cookies)
+			}
+			for _, c := range cookies {
+				if c.Secure != tt.wantSecure || !c.HttpOnly || c.SameSite != tt.wantSameSite {
+					t.Errorf("%s: Secure=%v HttpOnly=%v SameSite=%v, want %v true %v", c.Name, c.Secure, c.HttpOnly, c.SameSite, tt.wantSecure, tt.wantSameSite)
+				}
+			}
+		})
+	}
+}
+
+func TestCookieSessionMaxAge(t *testing.T) {
+	tests := []struct {
+		name                          string
+		resp                          AuthResponse
+		wantAccessAge, wantRefreshAge int
+	}{
+		{"both lifetimes", AuthResponse{AccessToken: "at", RefreshToken: "rt", ExpiresIn: 900, RefreshExpiresIn: 86400}, 900, 86400},
+		{"no refresh lifetime", AuthResponse{AccessToken: "at", RefreshToken: "rt", ExpiresIn: 900}, 900, 0},
+		{"no lifetimes", AuthResponse{AccessToken: "at", RefreshToken: "rt"}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			if err := NewCookieSession(CookieSessionConfig{}).Write(rec, &tt.resp); err != nil {
+				t.Fatal(err)
+			}
+			cookies := setCookies(rec)
+			// Max-Age 0 is a browser-session cookie: no Max-Age or Expires attribute.
+			for name, want := range map[string]int{"access_token": tt.wantAccessAge, "refresh_token": tt.wantRefreshAge} {
+				c := cookies[name]
+				if c == nil || c.MaxAge != want || (want == 0) != c.Expires.IsZero() {
+					t.Errorf("%s = %+v, want Max-Age %d", name, c, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCookieSessionRotateKeepsRefreshCookie(t *testing.T) {
+	session := NewCookieSession(CookieSessionConfig{})
+	rotated := &AuthResponse{AccessToken: "at-2", ExpiresIn: 900} // refresh token not rotated
+
+	rec := httptest.NewRecorder()
+	if err := session.Rotate(rec, rotated); err != nil {
+		t.Fatal(err)
+	}
+	cookies := setCookies(rec)
+	if cookies["access_token"] == nil || cookies["access_token"].Value != "at-2" {
+		t.Fatalf("Rotate access cookie = %+v, want at-2", cookies["access_token"])
+	}
+	if c, ok := cookies["refresh_token"]; ok {
+		t.Fatalf("Rotate touched the refresh cookie: %+v", c)
+	}
+
+	rec = httptest.NewRecorder()
+	if err := session.Write(rec, rotated); err != nil {
+		t.Fatal(err)
+	}
+	if c := setCookies(rec)["refresh_token"]; c == nil || c.Value != "" || c.MaxAge >= 0 {
+		t.Fatalf("Write refresh cookie = %+v, want it deleted", c)
+	}
+
+	rec = httptest.NewRecorder()
+	if err := session.Rotate(rec, &AuthResponse{AccessToken: "at-3", RefreshToken: "rt-3", ExpiresIn: 900}); err != nil {
+		t.Fatal(err)
+	}
+	if c := setCookies(rec)["refresh_token"]; c == nil || c.Value != "rt-3" {
+		t.Fatalf("Rotate with a new refresh token = %+v, want rt-3", c)
+	}
+}
+
+func TestCookieSessionClear(t *testing.T) {
+	session := NewCookieSession(CookieSessionConfig{
+		AccessCookie: "at", RefreshCookie: "rt", SessionCookie: "sid", Path: "/app", RefreshPath: "/app/auth/refresh",
+	})
+	rec := httptest.NewRecorder()
+	session.Clear(rec)
+
+	cookies := setCookies(rec)
+	for name, path := range map[string]string{"at": "/app", "rt": "/app/auth/refresh", "sid": "/app"} {
+		c := cookies[name]
+		if c == nil || c.MaxAge >= 0 || c.Value != "" || c.Path != path {
+			t.Errorf("%s = %+v, want a deletion on path %s", name, c, path)
+		}
+	}
+}
+
+func TestCookieSessionRefreshPath(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          CookieSessionConfig
+		wantAccessPath  string
+		wantRefreshPath string
+	}{
+		{"defaults", CookieSessionConfig{}, "/", "/"},
+		{"path only", CookieSessionConfig{Path: "/app"}, "/app", "/app"},
+		{"scoped refresh", CookieSessionConfig{RefreshPath: "/auth/refresh"}, "/", "/auth/refresh"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewCookieSession(tt.config)
+			rec := httptest.NewRecorder()
+			if err := session.Write(rec, &AuthResponse{AccessToken: "at", RefreshToken: "rt", ExpiresIn: 900}); err != nil {
+				t.Fatal(err)
+			}
+			cookies := setCookies(rec)
+			if cookies["access_token"].Path != tt.wantAccessPath || cookies["refresh_token"].Path != tt.wantRefreshPath {
+				t.Fatalf("paths = %q, %q; want %q, %q", cookies["access_token"].Path, cookies["refresh_token"].Path, tt.wantAccessPath, tt.wantRefreshPath)
+			}
+		})
+	}
+}
+
+func TestCookieSessionPersistOnlyRemembered(t *testing.T) {
+	session := NewCookieSession(CookieSessionConfig{PersistOnlyRemembered: true})
+	tests := []struct {
+		name       string
+		rememberMe bool
+		wantMaxAge int
+	}{
+		{"browser session", false, 0},
+		{"remembered", true, 86400},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		resp := &AuthResponse{AccessToken: "at", RefreshToken: "rt", ExpiresIn: 900, RefreshExpiresIn: 86400, RememberMe: tt.rememberMe}
+		if err := session.Write(rec, resp); err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == "refresh_token" && c.MaxAge != tt.wantMaxAge {
+				t.Errorf("%s: refresh cookie MaxAge = %d, want %d", tt.name, c.MaxAge, tt.wantMaxAge)
+			}
+		}
+	}
+}
@@ -0,0 +1,410 @@
+package authclient
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Credential names requested from a CredentialProvider.
+const (
+	CredentialAPIKey       = "api_key"       // admin API key for SyncUser, ExportUsers, LoadSigningMaterial
+	CredentialClientSecret = "client_secret" // OAuth client secret for ServiceTokenSource and BFF
+)
+
+// DefaultCredentialTTL is how long remote providers cache a secret before fetching it again.
+const DefaultCredentialTTL = 5 * time.Minute
+
+// ErrCredentialNotFound is returned by providers that hold no value for a credential name.
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialProvider supplies secrets at runtime, so API keys and client secrets need not
+// live in static config. Credential is called whenever a secret is needed; implementations
+// should cache. After auth-service rejects a credential, providers that also implement
+// Invalidate(name string) are told to drop their cached copy, so a rotated secret is picked
+// up on the next call.
+type CredentialProvider interface {
+	Credential(ctx context.Context, name string) (string, error)
+}
+
+// invalidateCredential tells p, when it caches, that name was rejected.
+func invalidateCredential(p CredentialProvider, name string) {
+	if inv, ok := p.(interface{ Invalidate(name string) }); ok {
+		inv.Invalidate(name)
+	}
+}
+
+// WithCredentialProvider fetches the admin API key from provider (CredentialAPIKey) when an
+// admin call (SyncUser, SyncUsers, ExportUsers, SuspendTenant, ActivateTenant,
+// SendVerificationEmail) is made with an empty key.
+func WithCredentialProvider(provider CredentialProvider) ClientOption {
+	return func(c *Client) {
+		c.credentials = provider
+	}
+}
+
+// adminAPIKey returns apiKey, or the provider's API key when apiKey is empty; provided
+// reports the latter.
+func (c *Client) adminAPIKey(ctx context.Context, apiKey, op string) (key string, provided bool, err error) {
+	if apiKey != "" {
+		return apiKey, false, nil
+	}
+	if c.credentials == nil {
+		return "", false, newAuthError(KindInvalidRequest, "auth-service: API key required for "+op, nil)
+	}
+	key, err = c.credentials.Credential(ctx, CredentialAPIKey)
+	if err != nil {
+		return "", false, newAuthError(KindInternal, "auth-service: load API key", err)
+	}
+	return key, true, nil
+}
+
+// apiKeyRejected drops a provided API key that auth-service refused, so the next call
+// fetches the rotated one.
+func (c *Client) apiKeyRejected(provided bool, status int) {
+	if provided && status == http.StatusUnauthorized {
+		c.logger.Warn("auth-service: API key from credential provider rejected; reloading")
+		invalidateCredential(c.credentials, CredentialAPIKey)
+	}
+}
+
+// adminCall sends req, if non-nil, to an admin endpoint with apiKey (resolved by adminAPIKey)
+// and decodes the response into out, if non-nil. 200, 201 and 204 count as success.
+func (c *Client) adminCall(ctx context.Context, method, path, op, apiKey string, provided bool, req, out any) error {
+	var body []byte
+	if req != nil {
+		var err error
+		if body, err = json.Marshal(req); err != nil {
+			return newAuthError(KindInternal, "auth-service: marshal request", err)
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("X-API-Key", apiKey)
+	c.signAdmin(httpReq, body)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.logger.Error("auth-service: "+op+" request failed", "error", err, "url", c.baseURL+path)
+		return newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+	default:
+		c.logger.Warn("auth-service: "+op+" failed",
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody))
+		c.apiKeyRejected(provided, resp.StatusCode)
+		return withRetryAfter(c.responseError(op, resp.StatusCode, respBody), resp.Header)
+	}
+
+	if out == nil || len(bytes.TrimSpace(respBody)) == 0 {
+		return nil
+	}
+	if err := c.decode(respBody, out); err != nil {
+		return newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+	return nil
+}
+
+// EnvCredentials reads credentials from environment variables named Prefix plus the
+// upper-cased credential name (AUTH_API_KEY for prefix "AUTH_"). When <var>_FILE is set
+// instead, the secret is read from that file on every call, which follows rotations of
+// mounted Kubernetes or Vault Agent secrets.
+type EnvCredentials struct {
+	Prefix string
+}
+
+// Credential implements CredentialProvider.
+func (e EnvCredentials) Credential(ctx context.Context, name string) (string, error) {
+	key := e.Prefix + strings.ToUpper(name)
+	if v := os.Getenv(key); v != "" {
+		return v, nil
+	}
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("credential %s: %w", name, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return "", fmt.Errorf("credential %s: %w (set %s or %s_FILE)", name, ErrCredentialNotFound, key, key)
+}
+
+// VaultCredentials reads credentials from a HashiCorp Vault KV version 2 secret whose keys
+// are credential names ("api_key", "client_secret").
+type VaultCredentials struct {
+	Address string // defaults to $VAULT_ADDR
+	Mount   string // KV mount, defaults to "secret"
+	Path    string // secret path within the mount, e.g. "services/orders/auth"
+	// Token authenticates to Vault; it defaults to $VAULT_TOKEN, then to the contents of
+	// TokenFile (re-read on each fetch, e.g. a Vault Agent sink).
+	Token     string
+	TokenFile string
+	Namespace string        // Vault Enterprise namespace, optional
+	TTL       time.Duration // defaults to DefaultCredentialTTL
+
+	HTTPClient *http.Client
+	cache      secretCache
+}
+
+// Credential implements CredentialProvider.
+func (v *VaultCredentials) Credential(ctx context.Context, name string) (string, error) {
+	return v.cache.lookup(ctx, name, v.TTL, v.fetch)
+}
+
+// Invalidate drops the cached secret so the next call reads it from Vault.
+func (v *VaultCredentials) Invalidate(string) { v.cache.invalidate() }
+
+func (v *VaultCredentials) fetch(ctx context.Context) (map[string]string, error) {
+	addr := cmp.Or(v.Address, os.Getenv("VAULT_ADDR"))
+	token := cmp.Or(v.Token, os.Getenv("VAULT_TOKEN"))
+	if token == "" && v.TokenFile != "" {
+		data, err := os.ReadFile(v.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("vault token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	u := strings.TrimSuffix(addr, "/") + "/v1/" + cmp.Or(v.Mount, "secret") + "/data/" + strings.TrimPrefix(v.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("vault: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := doSecretRequest(v.HTTPClient, req, "vault", &body); err != nil {
+		return nil, err
+	}
+	doc := make(map[string]string, len(body.Data.Data))
+	for k, val := range body.Data.Data {
+		if s, ok := val.(string); ok {
+			doc[k] = s
+		}
+	}
+	return doc, nil
+}
+
+// AWSSecretsManagerCredentials reads credentials from an AWS Secrets Manager secret. The
+// SecretString is a JSON object keyed by credential name; a plain (non-JSON) string is
+// returned for every name, for secrets that hold a single credential.
+type AWSSecretsManagerCredentials struct {
+	SecretID string // name or ARN
+	Region   string // defaults to $AWS_REGION, then $AWS_DEFAULT_REGION
+	// Access keys default to $AWS_ACCESS_KEY_ID, $AWS_SECRET_ACCESS_KEY and
+	// $AWS_SESSION_TOKEN, and are read on each fetch so refreshed session credentials apply.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Endpoint        string        // overrides https://secretsmanager.<region>.amazonaws.com
+	TTL             time.Duration // defaults to DefaultCredentialTTL
+
+	HTTPClient *http.Client
+	cache      secretCache
+}
+
+// Credential implements CredentialProvider.
+func (a *AWSSecretsManagerCredentials) Credential(ctx context.Context, name string) (string, error) {
+	return a.cache.lookup(ctx, name, a.TTL, a.fetch)
+}
+
+// Invalidate drops the cached secret so the next call reads it from Secrets Manager.
+func (a *AWSSecretsManagerCredentials) Invalidate(string) { a.cache.invalidate() }
+
+func (a *AWSSecretsManagerCredentials) fetch(ctx context.Context) (map[string]string, error) {
+	region := cmp.Or(a.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+	endpoint := cmp.Or(a.Endpoint, "https://secretsmanager."+region+".amazonaws.com")
+	payload, err := json.Marshal(map[string]string{"SecretId": a.SecretID})
+	if err != nil {
+		return nil, fmt.Errorf("secrets manager: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/", strings.NewReader(string(payload)))
+	if err != nil {
+		return nil, fmt.Errorf("secrets manager: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	signAWSRequest(req, payload, region, "secretsmanager", time.Now().UTC(),
+		cmp.Or(a.AccessKeyID, os.Getenv("AWS_ACCESS_KEY_ID")),
+		cmp.Or(a.SecretAccessKey, os.Getenv("AWS_SECRET_ACCESS_KEY")),
+		cmp.Or(a.SessionToken, os.Getenv("AWS_SESSION_TOKEN")))
+
+	var body struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := doSecretRequest(a.HTTPClient, req, "secrets manager", &body); err != nil {
+		return nil, err
+	}
+	doc := make(map[string]string)
+	if err := json.Unmarshal([]byte(body.SecretString), &doc); err != nil {
+		return map[string]string{"": body.SecretString}, nil
+	}
+	return doc, nil
+}
+
+// signAWSRequest adds AWS Signature Version 4 headers for a request with the given body.
+func signAWSRequest(req *http.Request, body []byte, region, service string, now time.Time, accessKey, secretKey, sessionToken string) {
+	amzDate := now.Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	names := []string{"content-type", "host", "x-amz-date"}
+	if sessionToken != "" {
+		names = append(names, "x-amz-security-token")
+	}
+	names = append(names, "x-amz-target")
+	var canonical strings.Builder
+	for _, name := range names {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonical.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signed := strings.Join(names, ";")
+	bodyHash := sha256.Sum256(body)
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{req.Method, path, req.URL.RawQuery, canonical.String(), signed, hex.EncodeToString(bodyHash[:])}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + secretKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKey+"/"+scope+
+		", SignedHeaders="+signed+", Signature="+hex.EncodeToString(hmacSHA256(key, stringToSign)))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// doSecretRequest sends req and decodes a JSON success response into out.
+func doSecretRequest(client *http.Client, req *http.Request, service string, out any) error {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", service, err)
+	}
+	defer resp.Body.Close()
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: read response: %w", service, err)
+	}
+	defer putBuffer(buf)
+	if resp.StatusCode != http.StatusOK {
+		// Error bodies from both services describe the failure without echoing secrets.
+		msg := buf.Bytes()
+		if len(msg) > 256 {
+			msg = msg[:256]
+		}
+		return fmt.Errorf("%s: %s: %s", service, resp.Status, bytes.TrimSpace(msg))
+	}
+	if err := json.Unmarshal(buf.Bytes(), out); err != nil {
+		return fmt.Errorf("%s: decode response: %w", service, err)
+	}
+	return nil
+}
+
+// secretCache holds one fetched secret document (credential name -> value) for a TTL.
+// Concurrent misses share one fetch; a failed refresh serves the previous document so an
+// outage of the secret store does not take auth calls down with it.
+type secretCache struct {
+	mu      sync.Mutex
+	doc     map[string]string
+	fetched time.Time
+	group   singleflight.Group
+}
+
+func (c *secretCache) lookup(ctx context.Context, name string, ttl time.Duration, fetch func(context.Context) (map[string]string, error)) (string, error) {
+	if ttl <= 0 {
+		ttl = DefaultCredentialTTL
+	}
+	c.mu.Lock()
+	doc, fresh := c.doc, c.doc != nil && time.Since(c.fetched) < ttl
+	c.mu.Unlock()
+
+	if !fresh {
+		v, err, _ := c.group.Do("", func() (any, error) {
+			doc, err := fetch(ctx)
+			if err != nil {
+				return nil, err
+			}
+			c.mu.Lock()
+			c.doc, c.fetched = doc, time.Now()
+			c.mu.Unlock()
+			return doc, nil
+		})
+		switch {
+		case err == nil:
+			doc = v.(map[string]string)
+		case doc == nil:
+			return "", fmt.Errorf("credential %s: %w", name, err)
+		}
+	}
+
+	if v, ok := doc[name]; ok {
+		return v, nil
+	}
+	if v, ok := doc[""]; ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("credential %s: %w", name, ErrCredentialNotFound)
+}
+
+func (c *secretCache) invalidate() {
+	c.mu.Lock()
+	c.doc = nil
+	c.mu.Unlock()
+}
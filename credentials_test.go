@@ -0,0 +1,166 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// rotatingCredentials serves current until Invalidate, then next.
+type rotatingCredentials struct {
+	current, next string
+	invalidated   atomic.Int32
+}
+
+func (r *rotatingCredentials) Credential(ctx context.Context, name string) (string, error) {
+	if r.invalidated.Load() > 0 {
+		return r.next, nil
+	}
+	return r.current, nil
+}
+
+func (r *rotatingCredentials) Invalidate(string) { r.invalidated.Add(1) }
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("AUTH_API_KEY", "from-env")
+	file := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(file, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AUTH_CLIENT_SECRET_FILE", file)
+
+	env := EnvCredentials{Prefix: "AUTH_"}
+	ctx := context.Background()
+	tests := []struct {
+		name, want string
+		err        error
+	}{
+		{CredentialAPIKey, "from-env", nil},
+		{CredentialClientSecret, "from-file", nil},
+		{"webhook_secret", "", ErrCredentialNotFound},
+	}
+	for _, tt := range tests {
+		got, err := env.Credential(ctx, tt.name)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("Credential(%q) = %q, %v; want %q, %v", tt.name, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestVaultCredentials(t *testing.T) {
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/kv/data/services/orders" || r.Header.Get("X-Vault-Token") != "s.token" {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		fetches.Add(1)
+		_, _ = w.Write([]byte(`{"data":{"data":{"api_key":"key-1","client_secret":"secret-1"},"metadata":{"version":3}}}`))
+	}))
+	defer srv.Close()
+
+	vault := &VaultCredentials{Address: srv.URL, Mount: "kv", Path: "services/orders", Token: "s.token"}
+	ctx := context.Background()
+	for _, name := range []string{CredentialAPIKey, CredentialClientSecret, CredentialAPIKey} {
+		if _, err := vault.Credential(ctx, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("fetches = %d, want 1 (cached)", got)
+	}
+	vault.Invalidate(CredentialAPIKey)
+	if got, _ := vault.Credential(ctx, CredentialClientSecret); got != "secret-1" || fetches.Load() != 2 {
+		t.Fatalf("after Invalidate: %q with %d fetches", got, fetches.Load())
+	}
+
+	vault = &VaultCredentials{Address: srv.URL, Path: "services/orders", Token: "s.token"}
+	if _, err := vault.Credential(ctx, CredentialAPIKey); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("wrong mount: err = %v", err)
+	}
+}
+
+func TestAWSSecretsManagerCredentials(t *testing.T) {
+	secretString := `{"api_key":"aws-key"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			!strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(auth, "/eu-west-1/secretsmanager/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature=") {
+			http.Error(w, "bad signature", http.StatusForbidden)
+			return
+		}
+		var body struct{ SecretId string }
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]string{"ARN": "arn:" + body.SecretId, "SecretString": secretString})
+	}))
+	defer srv.Close()
+
+	newProvider := func() *AWSSecretsManagerCredentials {
+		return &AWSSecretsManagerCredentials{
+			SecretID: "orders/auth", Region: "eu-west-1", Endpoint: srv.URL,
+			AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "session",
+		}
+	}
+	ctx := context.Background()
+	if got, err := newProvider().Credential(ctx, CredentialAPIKey); got != "aws-key" || err != nil {
+		t.Fatalf("Credential = %q, %v", got, err)
+	}
+	if _, err := newProvider().Credential(ctx, CredentialClientSecret); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("missing key: err = %v", err)
+	}
+	secretString = "plain-secret"
+	if got, err := newProvider().Credential(ctx, CredentialClientSecret); got != "plain-secret" || err != nil {
+		t.Fatalf("plain SecretString: %q, %v", got, err)
+	}
+}
+
+func TestCredentialProviderRotation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/admin/users/sync":
+			if r.Header.Get("X-API-Key") != "key-2" {
+				http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"user_id":"u1"}`))
+		case "/api/v1/auth/token":
+			var req ClientCredentialsRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.ClientSecret != "secret-2" {
+				http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"svc-token","expires_in":300}`))
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	keys := &rotatingCredentials{current: "key-1", next: "key-2"}
+	c := NewClient(srv.URL, nil, WithCredentialProvider(keys))
+	user := SyncUserRequest{Email: "ada@acme.test", TenantSlug: "acme"}
+	if _, err := c.SyncUser(ctx, user, ""); KindOf(err) != KindInvalidCredentials {
+		t.Fatalf("first sync: err = %v, want invalid credentials", err)
+	}
+	if _, err := c.SyncUser(ctx, user, ""); err != nil {
+		t.Fatalf("sync after rotation: %v", err)
+	}
+
+	// Minting retries once with the reloaded secret.
+	secrets := &rotatingCredentials{current: "secret-1", next: "secret-2"}
+	src := NewServiceTokenSource(c, ServiceTokenConfig{ClientID: "orders", Credentials: secrets})
+	if tok, err := src.Token(ctx); tok != "svc-token" || err != nil {
+		t.Fatalf("Token = %q, %v", tok, err)
+	}
+	if got := secrets.invalidated.Load(); got != 1 {
+		t.Fatalf("client secret invalidated %d times, want 1", got)
+	}
+}
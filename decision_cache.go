@@ -0,0 +1,161 @@
+package authclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Bengo-Hub/shared-auth-client/events"
+)
+
+// DecisionKey identifies a cached authorization decision. Subject is the user (or service)
+// ID and Tenant the tenant the token was issued for, so a user's decisions in one tenant
+// never answer for another.
+type DecisionKey struct {
+	Subject  string
+	Tenant   string
+	Resource string
+	Action   string
+	Object   string
+}
+
+// DecisionCacheConfig configures a DecisionCache.
+type DecisionCacheConfig struct {
+	TTL         time.Duration // how long allow decisions are reused, defaults to 30 seconds
+	NegativeTTL time.Duration // how long deny decisions are reused, defaults to 5 seconds; negative disables
+	MaxEntries  int           // defaults to 10,000
+}
+
+// DecisionCache caches remote authorization decisions so repeated checks do not each call
+// the PDP. Denies are cached for a shorter time than allows, so a freshly granted permission
+// takes effect quickly. Register it on an events.Registry to drop decisions as soon as
+// auth-service reports a change.
+type DecisionCache struct {
+	cfg DecisionCacheConfig
+
+	mu      sync.Mutex
+	entries map[DecisionKey]cachedDecision
+}
+
+type cachedDecision struct {
+	decision *PermissionDecision
+	expires  time.Time
+}
+
+// NewDecisionCache creates a cache with cfg's zero fields defaulted.
+func NewDecisionCache(cfg DecisionCacheConfig) *DecisionCache {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * time.Second
+	}
+	if cfg.NegativeTTL == 0 {
+		cfg.NegativeTTL = 5 * time.Second
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = 10000
+	}
+	return &DecisionCache{cfg: cfg, entries: make(map[DecisionKey]cachedDecision)}
+}
+
+// Get returns the unexpired decision for key.
+func (c *DecisionCache) Get(key DecisionKey) (*PermissionDecision, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.decision, true
+}
+
+// Put stores decision for key with the TTL for its outcome.
+func (c *DecisionCache) Put(key DecisionKey, decision *PermissionDecision) {
+	ttl := c.cfg.TTL
+	if !decision.Allowed {
+		ttl = c.cfg.NegativeTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.cfg.MaxEntries {
+		c.evictLocked(now)
+	}
+	c.entries[key] = cachedDecision{decision: decision, expires: now.Add(ttl)}
+}
+
+// evictLocked drops expired entries and, if the cache is still full, arbitrary entries until
+// a tenth of the capacity is free. Decisions are cheap to re-fetch, so precise LRU order is
+// not worth the bookkeeping.
+func (c *DecisionCache) evictLocked(now time.Time) {
+	for k, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	for k := range c.entries {
+		if len(c.entries) < c.cfg.MaxEntries*9/10 {
+			break
+		}
+		delete(c.entries, k)
+	}
+}
+
+// InvalidateSubject drops every decision for subject, in all tenants.
+func (c *DecisionCache) InvalidateSubject(subject string) {
+	c.invalidate(func(k DecisionKey) bool { return k.Subject == subject })
+}
+
+// InvalidateTenant drops every decision made in tenant.
+func (c *DecisionCache) InvalidateTenant(tenant string) {
+	c.invalidate(func(k DecisionKey) bool { return k.Tenant == tenant })
+}
+
+// InvalidateAll empties the cache.
+func (c *DecisionCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+func (c *DecisionCache) invalidate(match func(DecisionKey) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if match(k) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// Register invalidates cached decisions from registry events: permissions.changed drops the
+// affected user's (or the whole tenant's) decisions, user.deleted the user's, and a
+// "sign out everywhere" session.revoked the user's as well.
+func (c *DecisionCache) Register(registry *events.Registry) {
+	registry.OnPermissionsChanged(func(ctx context.Context, e events.PermissionsChangedEvent) error {
+		switch {
+		case e.Data.UserID != "":
+			c.InvalidateSubject(e.Data.UserID)
+		case e.Data.TenantID != "":
+			c.InvalidateTenant(e.Data.TenantID)
+		default:
+			c.InvalidateAll()
+		}
+		return nil
+	})
+	registry.OnUserDeleted(func(ctx context.Context, e events.UserDeletedEvent) error {
+		c.InvalidateSubject(e.Data.UserID)
+		return nil
+	})
+	registry.OnSessionRevoked(func(ctx context.Context, e events.SessionRevokedEvent) error {
+		if e.Data.AllSessions {
+			c.InvalidateSubject(e.Data.UserID)
+		}
+		return nil
+	})
+}
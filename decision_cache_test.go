@@ -0,0 +1,44 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Bengo-Hub/shared-auth-client/events"
+)
+
+func TestDecisionCacheInvalidation(t *testing.T) {
+	cache := NewDecisionCache(DecisionCacheConfig{NegativeTTL: -1})
+	registry := events.NewRegistry()
+	cache.Register(registry)
+
+	alice := DecisionKey{Subject: "alice", Tenant: "t1", Resource: "orders", Action: "read"}
+	bob := DecisionKey{Subject: "bob", Tenant: "t2", Resource: "orders", Action: "read"}
+	cache.Put(alice, &PermissionDecision{Allowed: true})
+	cache.Put(bob, &PermissionDecision{Allowed: true})
+	cache.Put(DecisionKey{Subject: "carol"}, &PermissionDecision{Allowed: false})
+	if _, ok := cache.Get(DecisionKey{Subject: "carol"}); ok {
+		t.Fatal("deny cached with negative caching disabled")
+	}
+
+	dispatch := func(typ, data string) {
+		t.Helper()
+		err := registry.Dispatch(context.Background(), events.Event{Type: typ, OccurredAt: time.Now(), Data: json.RawMessage(data)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	dispatch(events.TypePermissionsChanged, `{"tenant_id":"t1","user_id":"alice"}`)
+	if _, ok := cache.Get(alice); ok {
+		t.Fatal("alice's decision survived permissions.changed")
+	}
+	if _, ok := cache.Get(bob); !ok {
+		t.Fatal("bob's decision was dropped")
+	}
+	dispatch(events.TypePermissionsChanged, `{"tenant_id":"t2"}`)
+	if _, ok := cache.Get(bob); ok {
+		t.Fatal("tenant-wide change kept bob's decision")
+	}
+}
@@ -0,0 +1,113 @@
+package authclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DecodeMode selects how auth-service responses are decoded.
+type DecodeMode int
+
+const (
+	// DecodeLenient ignores fields this version of authclient does not model, keeping them
+	// in the response's Extra map. It is the default, so auth-service can add fields
+	// without breaking deployed clients.
+	DecodeLenient DecodeMode = iota
+	// DecodeStrict rejects responses carrying unmodelled fields, to catch contract drift
+	// in staging or CI before it reaches production.
+	DecodeStrict
+)
+
+// WithDecodeMode sets how the client decodes auth-service responses. Defaults to
+// DecodeLenient.
+func WithDecodeMode(mode DecodeMode) ClientOption {
+	return func(c *Client) {
+		c.decodeMode = mode
+	}
+}
+
+// extraHolder is implemented by response types that keep unmodelled fields.
+type extraHolder interface {
+	extraFields() *map[string]json.RawMessage
+}
+
+func (r *AuthResponse) extraFields() *map[string]json.RawMessage           { return &r.Extra }
+func (r *SyncUserResponse) extraFields() *map[string]json.RawMessage       { return &r.Extra }
+func (r *TenantResponse) extraFields() *map[string]json.RawMessage         { return &r.Extra }
+func (r *DeviceAuthorization) extraFields() *map[string]json.RawMessage    { return &r.Extra }
+func (r *PermissionDecision) extraFields() *map[string]json.RawMessage     { return &r.Extra }
+func (r *APIKeyValidationResult) extraFields() *map[string]json.RawMessage { return &r.Extra }
+func (r *LogoutResult) extraFields() *map[string]json.RawMessage           { return &r.Extra }
+func (r *VerificationStatus) extraFields() *map[string]json.RawMessage     { return &r.Extra }
+func (r *MFAChallengeResponse) extraFields() *map[string]json.RawMessage   { return &r.Extra }
+
+// decodeJSON decodes data into out according to mode. In lenient mode, unmodelled top-level
+// fields are kept in out's Extra map when it has one.
+func decodeJSON(data []byte, out any, mode DecodeMode) error {
+	if mode == DecodeStrict {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		return dec.Decode(out)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return err
+	}
+	holder, ok := out.(extraHolder)
+	if !ok {
+		return nil
+	}
+	*holder.extraFields() = unmodelledFields(data, reflect.TypeOf(out).Elem())
+	return nil
+}
+
+// unmodelledFields returns the top-level fields of the JSON object data that t, a struct
+// type, has no field for, or nil when there are none.
+func unmodelledFields(data []byte, t reflect.Type) map[string]json.RawMessage {
+	known := modelledKeys(t)
+	if !hasUnmodelledKey(data, known) {
+		return nil // nothing to capture; skip decoding the body a second time
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil // not an object; nothing to capture
+	}
+	var extra map[string]json.RawMessage
+	for k, v := range all {
+		if _, ok := known[k]; ok {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[k] = v
+	}
+	return extra
+}
+
+// modelledKeysByType caches the JSON field names of each response type.
+var modelledKeysByType sync.Map // reflect.Type -> map[string]struct{}
+
+func modelledKeys(t reflect.Type) map[string]struct{} {
+	if keys, ok := modelledKeysByType.Load(t); ok {
+		return keys.(map[string]struct{})
+	}
+	keys := make(map[string]struct{})
+	collectJSONKeys(t, keys)
+	modelledKeysByType.Store(t, keys)
+	return keys
+}
+
+// decode decodes an auth-service response body with the client's DecodeMode. Token
+// responses are stamped with their receipt time for AuthResponse.ExpiresAt.
+func (c *Client) decode(data []byte, out any) error {
+	if err := decodeJSON(data, out, c.decodeMode); err != nil {
+		return err
+	}
+	if resp, ok := out.(*AuthResponse); ok {
+		resp.receivedAt = time.Now()
+	}
+	return nil
+}
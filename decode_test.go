@@ -0,0 +1,44 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecodeModes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"at","expires_in":900,"mfa_hint":"totp","user":{"anything":true}}`))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	resp, err := NewClient(srv.URL, nil).Login(ctx, LoginRequest{})
+	if err != nil {
+		t.Fatalf("lenient: %v", err)
+	}
+	if resp.AccessToken != "at" || len(resp.Extra) != 1 || string(resp.Extra["mfa_hint"]) != `"totp"` {
+		t.Errorf("lenient: resp = %+v, want mfa_hint in Extra only", resp)
+	}
+
+	_, err = NewClient(srv.URL, nil, WithDecodeMode(DecodeStrict)).Login(ctx, LoginRequest{})
+	if KindOf(err) != KindUpstream {
+		t.Errorf("strict: err = %v, want upstream error for unknown field", err)
+	}
+}
+
+func TestDecodeJSONEmbeddedKeys(t *testing.T) {
+	var entry apiKeyBatchEntry
+	data := []byte(`{"valid":true,"client_id":"c1","tier":"gold"}`)
+	if err := decodeJSON(data, &entry, DecodeStrict); err == nil {
+		t.Error("strict: unknown field accepted")
+	}
+	var result APIKeyValidationResult
+	if err := decodeJSON([]byte(`{"client_id":"c1","rate_limit":{"requests_per_minute":60},"tier":"gold"}`), &result, DecodeLenient); err != nil {
+		t.Fatal(err)
+	}
+	if result.ClientID != "c1" || result.RateLimit == nil || len(result.Extra) != 1 || result.Extra["tier"] == nil {
+		t.Errorf("lenient: result = %+v", result)
+	}
+}
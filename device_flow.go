@@ -0,0 +1,175 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DeviceCodeGrantType is the RFC 8628 grant type for exchanging a device code.
+const DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// DeviceAuthorization is auth-service's answer to a device authorization request (RFC 8628):
+// show UserCode and VerificationURI to the user, then poll with PollDeviceToken.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"` // seconds between polls, default 5
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modelled above; see DecodeMode
+}
+
+// StartDeviceAuthorization begins a device-flow login for clientID, for CLIs and devices
+// without a browser.
+func (c *Client) StartDeviceAuthorization(ctx context.Context, clientID, scope string) (*DeviceAuthorization, error) {
+	var auth DeviceAuthorization
+	req := map[string]string{"client_id": clientID, "scope": scope}
+	if err := c.postJSON(ctx, "/api/v1/auth/device/code", "device authorization", req, &auth); err != nil {
+		return nil, err
+	}
+	return &auth, nil
+}
+
+// PollDeviceToken polls until the user approves or denies the device authorization, it
+// expires, or ctx is cancelled. It honours the server's interval and slow_down responses.
+func (c *Client) PollDeviceToken(ctx context.Context, clientID string, auth *DeviceAuthorization) (*AuthResponse, error) {
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(auth.ExpiresIn) * time.Second)
+	req := map[string]string{
+		"grant_type":  DeviceCodeGrantType,
+		"device_code": auth.DeviceCode,
+		"client_id":   clientID,
+	}
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		var authResp AuthResponse
+		err := c.postJSON(ctx, "/api/v1/auth/token", "device token", req, &authResp)
+		if err == nil {
+			return &authResp, nil
+		}
+		var ae *AuthError
+		if !errors.As(err, &ae) {
+			return nil, err
+		}
+		switch ae.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, err
+		}
+		if auth.ExpiresIn > 0 && time.Now().After(deadline) {
+			return nil, newAuthError(KindInvalidCredentials, "auth-service: device authorization expired", nil)
+		}
+	}
+}
+
+// postJSON posts req to path and decodes a 200 response into out. Other responses become
+// AuthErrors via responseError.
+func (c *Client) postJSON(ctx context.Context, path, op string, req, out any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return newAuthError(KindInternal, "auth-service: marshal request", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.baseURL, path), bytes.NewReader(body))
+	if err != nil {
+		return newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.responseError(op, resp.StatusCode, respBody)
+	}
+
+	if err := c.decode(respBody, out); err != nil {
+		return newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+	return nil
+}
+
+// postWithToken posts req, if non-nil, to path on behalf of the user owning accessToken and
+// decodes the response into out, if non-nil. Unlike postJSON it accepts 201 and 204.
+func (c *Client) postWithToken(ctx context.Context, path, op string, req any, accessToken string, out any) error {
+	var reqBody []byte
+	if req != nil {
+		var err error
+		if reqBody, err = json.Marshal(req); err != nil {
+			return newAuthError(KindInternal, "auth-service: marshal request", err)
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+accessToken)
+	if reqBody != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.logger.Error("auth-service: "+op+" request failed", "error", err, "url", c.baseURL+path)
+		return newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+	default:
+		c.logger.Warn("auth-service: "+op+" failed",
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody))
+		return withRetryAfter(c.responseError(op, resp.StatusCode, respBody), resp.Header)
+	}
+
+	if out == nil || len(bytes.TrimSpace(respBody)) == 0 {
+		return nil
+	}
+	if err := c.decode(respBody, out); err != nil {
+		return newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultResolveInterval is how often WithEndpointResolver re-resolves auth-service
+// endpoints when no interval is given.
+const DefaultResolveInterval = 30 * time.Second
+
+// EndpointResolver discovers the auth-service instances to call, as "host:port" addresses.
+// Implement it to use a registry such as Consul; SRVResolver covers DNS SRV records.
+type EndpointResolver interface {
+	Resolve(ctx context.Context) ([]string, error)
+}
+
+// EndpointResolverFunc adapts a function to EndpointResolver.
+type EndpointResolverFunc func(ctx context.Context) ([]string, error)
+
+// Resolve implements EndpointResolver.
+func (f EndpointResolverFunc) Resolve(ctx context.Context) ([]string, error) { return f(ctx) }
+
+// SRVResolver resolves endpoints from DNS SRV records, e.g.
+// _http._tcp.auth-service.auth.svc.cluster.local.
+type SRVResolver struct {
+	Service, Proto, Name string
+	// Resolver defaults to net.DefaultResolver.
+	Resolver *net.Resolver
+}
+
+// NewSRVResolver creates a resolver for _service._proto.name; with empty service and proto,
+// name is looked up directly.
+func NewSRVResolver(service, proto, name string) *SRVResolver {
+	return &SRVResolver{Service: service, Proto: proto, Name: name}
+}
+
+// Resolve implements EndpointResolver. Only the records with the best (lowest) priority are
+// returned; backups with higher priority values are used once those disappear from DNS.
+func (r *SRVResolver) Resolve(ctx context.Context) ([]string, error) {
+	resolver := r.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	_, records, err := resolver.LookupSRV(ctx, r.Service, r.Proto, r.Name)
+	if len(records) == 0 {
+		if err == nil {
+			err = errors.New("no SRV records")
+		}
+		return nil, err
+	}
+	// LookupSRV sorts by priority and shuffles by weight within a priority.
+	var endpoints []string
+	for _, rec := range records {
+		if rec.Priority != records[0].Priority {
+			break
+		}
+		endpoints = append(endpoints, net.JoinHostPort(strings.TrimSuffix(rec.Target, "."), strconv.Itoa(int(rec.Port))))
+	}
+	return endpoints, nil
+}
+
+// WithEndpointResolver sends requests to the auth-service instances found by resolver
+// instead of the base URL's host, round-robin. The base URL still supplies the scheme and
+// path prefix, and is the fallback until the first resolution succeeds. Endpoints are
+// re-resolved every interval (DefaultResolveInterval when zero) in the background of the
+// next request; when the set changes, idle connections are closed so traffic rebalances
+// onto the new instances. A failed resolution keeps the previous endpoints.
+func WithEndpointResolver(resolver EndpointResolver, interval time.Duration) ClientOption {
+	if interval <= 0 {
+		interval = DefaultResolveInterval
+	}
+	return func(c *Client) {
+		c.discovery = &discovery{resolver: resolver, interval: interval}
+	}
+}
+
+// discovery holds the resolved endpoints of a Client.
+type discovery struct {
+	resolver EndpointResolver
+	interval time.Duration
+	logger   Logger
+
+	mu        sync.Mutex
+	endpoints []string
+	resolveAt time.Time // next resolution is due
+	resolving bool
+
+	next atomic.Uint64 // round-robin cursor
+}
+
+// discoveryTransport rewrites each request to one of the resolved endpoints.
+type discoveryTransport struct {
+	base  http.RoundTripper
+	state *discovery
+}
+
+func (t *discoveryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := t.state.pick(req.Context(), t.base)
+	if endpoint == "" {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.URL.Host = endpoint
+	req.Host = ""
+	return t.base.RoundTrip(req)
+}
+
+// pick returns the endpoint for the next request, or "" to use the base URL. The first call
+// resolves synchronously; later ones refresh in the background when the interval is up.
+func (d *discovery) pick(ctx context.Context, base http.RoundTripper) string {
+	d.mu.Lock()
+	due := !d.resolving && time.Now().After(d.resolveAt)
+	if due {
+		d.resolving = true
+	}
+	first := d.endpoints == nil
+	d.mu.Unlock()
+
+	switch {
+	case due && first:
+		d.resolve(ctx, base)
+	case due:
+		go d.resolve(context.WithoutCancel(ctx), base)
+	}
+
+	d.mu.Lock()
+	endpoints := d.endpoints
+	d.mu.Unlock()
+	if len(endpoints) == 0 {
+		return ""
+	}
+	return endpoints[int(d.next.Add(1)-1)%len(endpoints)]
+}
+
+func (d *discovery) resolve(ctx context.Context, base http.RoundTripper) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	endpoints, err := d.resolver.Resolve(ctx)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.resolving = false
+	d.resolveAt = time.Now().Add(d.interval)
+	if err != nil || len(endpoints) == 0 {
+		d.logger.Warn("auth-service: endpoint resolution failed, keeping previous endpoints", "error", err, "endpoints", len(d.endpoints))
+		if d.endpoints == nil {
+			d.endpoints = []string{} // resolved once; fall back to the base URL without blocking
+		}
+		return
+	}
+	endpoints = slices.Clone(endpoints)
+	slices.Sort(endpoints)
+	if slices.Equal(endpoints, d.endpoints) {
+		return
+	}
+	if d.endpoints != nil {
+		// Drop pooled connections so instances that left the set are released and the
+		// connections that replace them spread over the new set.
+		if closer, ok := base.(interface{ CloseIdleConnections() }); ok {
+			closer.CloseIdleConnections()
+		}
+	}
+	d.endpoints = endpoints
+}
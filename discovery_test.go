@@ -0,0 +1,79 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithEndpointResolver(t *testing.T) {
+	var hits [2]atomic.Int32
+	var servers [2]*httptest.Server
+	for i := range servers {
+		servers[i] = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits[i].Add(1)
+		}))
+		defer servers[i].Close()
+	}
+	host := func(i int) string { return strings.TrimPrefix(servers[i].URL, "http://") }
+
+	var mu sync.Mutex
+	endpoints := []string{host(0), host(1)}
+	var failing bool
+	resolver := EndpointResolverFunc(func(context.Context) ([]string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failing {
+			return nil, errors.New("registry down")
+		}
+		return endpoints, nil
+	})
+
+	// The base URL points nowhere; every request must go to a resolved endpoint.
+	c := NewClient("http://auth-service.invalid", nil, WithEndpointResolver(resolver, time.Millisecond))
+	ctx := context.Background()
+	for range 4 {
+		if _, err := c.CheckTenantExists(ctx, "acme"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits[0].Load() == 0 || hits[1].Load() == 0 {
+		t.Fatalf("requests not spread over endpoints: %d, %d", hits[0].Load(), hits[1].Load())
+	}
+
+	mu.Lock()
+	endpoints = []string{host(1)}
+	mu.Unlock()
+	// Re-resolution happens in the background; once it lands only server 1 is called.
+	for deadline := time.Now().Add(2 * time.Second); ; {
+		before := hits[0].Load()
+		for range 4 {
+			if _, err := c.CheckTenantExists(ctx, "acme"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if hits[0].Load() == before {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("endpoints were not re-resolved")
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	mu.Lock()
+	failing = true
+	mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	for range 4 {
+		if _, err := c.CheckTenantExists(ctx, "acme"); err != nil {
+			t.Fatalf("failed resolution should keep previous endpoints: %v", err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// VerificationStatus reports where a user's email address is in the verification lifecycle.
+type VerificationStatus struct {
+	UserID     string     `json:"user_id"`
+	Email      string     `json:"email"`
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	SentAt     *time.Time `json:"sent_at,omitempty"`    // when the latest verification email was sent
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // when that email's link stops working
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modelled above; see DecodeMode
+}
+
+// SendVerificationEmail asks auth-service to (re)send the verification email to userID's
+// address, invalidating links sent before. For an address that is already verified nothing
+// is sent and the returned status has Verified set. auth-service throttles resends; a
+// throttled call fails with KindRateLimited and AuthError.RetryAfter set.
+func (c *Client) SendVerificationEmail(ctx context.Context, userID, apiKey string) (*VerificationStatus, error) {
+	if userID == "" {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: user ID required to send verification email", nil)
+	}
+	apiKey, provided, err := c.adminAPIKey(ctx, apiKey, "send verification email")
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := c.baseURL + "/api/v1/admin/users/" + url.PathEscape(userID) + "/verification-email"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, http.NoBody)
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("X-API-Key", apiKey)
+	c.signAdmin(httpReq, nil)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.logger.Error("auth-service: send verification email request failed", "error", err, "url", endpoint, "user_id", userID)
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		c.logger.Warn("auth-service: send verification email failed",
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody),
+			"user_id", userID)
+		c.apiKeyRejected(provided, resp.StatusCode)
+		return nil, withRetryAfter(c.responseError("send verification email", resp.StatusCode, respBody), resp.Header)
+	}
+
+	var status VerificationStatus
+	if err := c.decode(respBody, &status); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+	return &status, nil
+}
+
+// VerifyEmail redeems the token from a verification email, e.g. in the handler behind the
+// emailed link. Expired, already used and unknown tokens fail with KindInvalidRequest.
+func (c *Client) VerifyEmail(ctx context.Context, verificationToken string) (*VerificationStatus, error) {
+	if verificationToken == "" {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: verification token required", nil)
+	}
+	body, err := json.Marshal(map[string]string{"token": verificationToken})
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/auth/verify-email", bytes.NewReader(body))
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	if resp.StatusCode != http.StatusOK {
+		err := c.responseError("verify email", resp.StatusCode, respBody)
+		// A 404 here means an unknown token, not a missing resource the caller asked for.
+		if ae, ok := err.(*AuthError); ok && ae.Kind == KindNotFound {
+			ae.Kind = KindInvalidRequest
+		}
+		return nil, withRetryAfter(err, resp.Header)
+	}
+
+	var status VerificationStatus
+	if err := c.decode(respBody, &status); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+	c.logger.Info("auth-service: email verified", "user_id", status.UserID)
+	return &status, nil
+}
@@ -0,0 +1,53 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEmailVerification(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/admin/users/u1/verification-email":
+			if r.Header.Get("X-API-Key") != "admin-key" {
+				http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"user_id":"u1","email":"ada@acme.test","verified":false,"sent_at":"2026-10-16T10:00:00Z","expires_at":"2026-10-17T10:00:00Z"}`))
+		case "/api/v1/admin/users/u2/verification-email":
+			w.Header().Set("Retry-After", "60")
+			http.Error(w, `{"error":"too_many_requests"}`, http.StatusTooManyRequests)
+		case "/api/v1/auth/verify-email":
+			var req struct{ Token string }
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Token != "good" {
+				http.Error(w, `{"error":"invalid_token"}`, http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"user_id":"u1","email":"ada@acme.test","verified":true,"verified_at":"2026-10-16T10:05:00Z"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	ctx := context.Background()
+	status, err := c.SendVerificationEmail(ctx, "u1", "admin-key")
+	if err != nil || status.Verified || status.ExpiresAt == nil || !status.ExpiresAt.Equal(time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("SendVerificationEmail = %+v, %v", status, err)
+	}
+	_, err = c.SendVerificationEmail(ctx, "u2", "admin-key")
+	if ae, ok := err.(*AuthError); !ok || ae.Kind != KindRateLimited || ae.RetryAfter != time.Minute {
+		t.Fatalf("throttled resend: err = %#v", err)
+	}
+
+	if status, err := c.VerifyEmail(ctx, "good"); err != nil || !status.Verified || status.VerifiedAt == nil {
+		t.Fatalf("VerifyEmail = %+v, %v", status, err)
+	}
+	if _, err := c.VerifyEmail(ctx, "spent"); KindOf(err) != KindInvalidRequest {
+		t.Fatalf("unknown token: err = %v", err)
+	}
+}
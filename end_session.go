@@ -0,0 +1,93 @@
+package authclient
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// BuildEndSessionURL returns auth-service's OIDC RP-initiated logout URL. Redirecting the
+// browser there ends the SSO session, not just the app's cookies. idTokenHint identifies the
+// session (auth-service then skips its confirmation prompt); postLogoutRedirectURI must be
+// registered for the client; state is echoed back to it. Empty arguments are omitted.
+func (c *Client) BuildEndSessionURL(idTokenHint, postLogoutRedirectURI, state string) string {
+	q := url.Values{}
+	if idTokenHint != "" {
+		q.Set("id_token_hint", idTokenHint)
+	}
+	if postLogoutRedirectURI != "" {
+		q.Set("post_logout_redirect_uri", postLogoutRedirectURI)
+	}
+	if state != "" {
+		q.Set("state", state)
+	}
+	endpoint := c.baseURL + "/api/v1/auth/end-session"
+	if len(q) == 0 {
+		return endpoint
+	}
+	return endpoint + "?" + q.Encode()
+}
+
+// FrontChannelLogoutHandler handles OIDC Front-Channel Logout: auth-service loads it in a
+// hidden iframe with iss and sid when the user signs out elsewhere. The request is not
+// authenticated, so it only acts when the browser sent this app's cookies for that session:
+// the session's stored tokens are dropped, its cookies cleared and, when those cookies cannot
+// be forged (ReferenceOnly sessions or a Keyring), the sid is added to Revocations so
+// validators reject its access tokens at once. A sid without matching cookies changes
+// nothing; revoking sessions globally is left to a signed back-channel logout_token. Stored
+// tokens of that session reached through other browsers are dropped by RefreshHandler once
+// auth-service refuses their refresh token. A mismatched iss (when Issuer is configured) or
+// missing sid answers 400.
+func (b *BFF) FrontChannelLogoutHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache, no-store")
+		q := r.URL.Query()
+		sid := q.Get("sid")
+		if sid == "" || (b.config.Issuer != "" && q.Get("iss") != b.config.Issuer) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if b.sessionMatches(r, sid) {
+			if b.config.Revocations != nil && b.sessionCookiesAuthentic() {
+				b.config.Revocations.RevokeSession(sid)
+			}
+			if handle := b.config.Session.SessionID(r); handle != "" && b.config.Store != nil {
+				_ = b.config.Store.Delete(r.Context(), handle)
+			}
+			b.config.Session.Clear(w)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// sessionCookiesAuthentic reports whether a session cookie proves this app issued it: a
+// ReferenceOnly handle only resolves through Store, and Keyring-encrypted cookies cannot be
+// minted by the browser. A plain access-token cookie is parsed unverified and proves nothing.
+func (b *BFF) sessionCookiesAuthentic() bool {
+	config := b.config.Session.config
+	return config.ReferenceOnly || config.Keyring != nil
+}
+
+// sessionMatches reports whether r carries this app's cookies for auth-service session sid.
+func (b *BFF) sessionMatches(r *http.Request, sid string) bool {
+	session := b.config.Session
+	if session.config.ReferenceOnly {
+		handle := session.SessionID(r)
+		if handle == "" || b.config.Store == nil {
+			return false
+		}
+		stored, err := b.config.Store.Load(r.Context(), handle)
+		return err == nil && stored.Tokens.SessionID == sid
+	}
+	access, _ := session.Tokens(r)
+	if access == "" {
+		return false
+	}
+	// Unverified is fine here: the worst a forged cookie can do is get itself cleared.
+	var claims Claims
+	_, _, err := jwt.NewParser().ParseUnverified(access, &claims)
+	return err == nil && claims.SessionID == sid
+}
@@ -0,0 +1,105 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestBuildEndSessionURL(t *testing.T) {
+	c := NewClient("https://sso.example.com", nil)
+	tests := []struct {
+		hint, redirect, state string
+		want                  string
+	}{
+		{"", "", "", "https://sso.example.com/api/v1/auth/end-session"},
+		{"idt", "https://app.example.com/", "s1", "https://sso.example.com/api/v1/auth/end-session?id_token_hint=idt&post_logout_redirect_uri=https%3A%2F%2Fapp.example.com%2F&state=s1"},
+	}
+	for _, tt := range tests {
+		if got := c.BuildEndSessionURL(tt.hint, tt.redirect, tt.state); got != tt.want {
+			t.Errorf("BuildEndSessionURL() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestFrontChannelLogout(t *testing.T) {
+	store := NewMemoryTokenStore()
+	store.Save(context.Background(), "handle-1", &StoredTokens{Tokens: AuthResponse{RefreshToken: "rt", SessionID: "sid-1"}})
+	revocations := NewRevocationList(0)
+	bff := NewBFF(BFFConfig{
+		Client:      NewClient("https://sso.example.com", nil),
+		Session:     NewCookieSession(CookieSessionConfig{ReferenceOnly: true}),
+		Store:       store,
+		Issuer:      "https://sso.example.com",
+		Revocations: revocations,
+	})
+	h := bff.FrontChannelLogoutHandler()
+
+	// Without this app's cookies for the session, nothing is revoked or dropped.
+	for _, cookie := range []*http.Cookie{nil, {Name: "session_id", Value: "handle-unknown"}} {
+		req := httptest.NewRequest(http.MethodGet, "/auth/frontchannel-logout?iss=https://sso.example.com&sid=sid-1", nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK || revocations.IsRevoked(&Claims{SessionID: "sid-1"}) {
+			t.Fatalf("cookie %v: status = %d, revoked = %v; want 200 without revoking", cookie, rec.Code, revocations.IsRevoked(&Claims{SessionID: "sid-1"}))
+		}
+		if _, err := store.Load(context.Background(), "handle-1"); err != nil {
+			t.Fatalf("cookie %v: stored tokens dropped: %v", cookie, err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/auth/frontchannel-logout?iss=https://evil.example.com&sid=sid-1", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("wrong iss: status = %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/frontchannel-logout?iss=https://sso.example.com&sid=sid-1", nil)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "handle-1"})
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	if _, err := store.Load(context.Background(), "handle-1"); err != ErrTokensNotFound {
+		t.Errorf("stored tokens not dropped: %v", err)
+	}
+	if !revocations.IsRevoked(&Claims{SessionID: "sid-1"}) {
+		t.Error("session not revoked")
+	}
+	if len(rec.Result().Cookies()) == 0 {
+		t.Error("matching session cookies not cleared")
+	}
+}
+
+func TestFrontChannelLogoutIgnoresForgeableCookies(t *testing.T) {
+	revocations := NewRevocationList(0)
+	bff := NewBFF(BFFConfig{
+		Client:      NewClient("https://sso.example.com", nil),
+		Session:     NewCookieSession(CookieSessionConfig{}),
+		Revocations: revocations,
+	})
+
+	// A plain access-token cookie is parsed unverified, so anyone can claim any sid with it.
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodNone, &Claims{SessionID: "victim-sid"}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/auth/frontchannel-logout?sid=victim-sid", nil)
+	req.AddCookie(&http.Cookie{Name: "access_token", Value: forged})
+	rec := httptest.NewRecorder()
+	bff.FrontChannelLogoutHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || len(rec.Result().Cookies()) == 0 {
+		t.Fatalf("status = %d, cookies = %v; want 200 with the cookies cleared", rec.Code, rec.Result().Cookies())
+	}
+	if revocations.IsRevoked(&Claims{SessionID: "victim-sid"}) {
+		t.Fatal("forged cookie revoked the session globally")
+	}
+}
@@ -0,0 +1,169 @@
+package authclient
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// errorMessages maps language -> error code -> user-facing message. Codes are auth-service
+// error codes (AuthError.Code) and ErrorKind values; "unknown" is the last resort.
+var (
+	errorMessagesMu sync.RWMutex
+	errorMessages   = map[string]map[string]string{
+		"en": {
+			string(KindTokenMissing):       "Please sign in to continue.",
+			string(KindTokenMalformed):     "Your session is invalid. Please sign in again.",
+			string(KindSignatureInvalid):   "Your session is invalid. Please sign in again.",
+			string(KindKeyNotFound):        "Your session is invalid. Please sign in again.",
+			string(KindClaimsInvalid):      "Your session is invalid. Please sign in again.",
+			string(KindTokenExpired):       "Your session has expired. Please sign in again.",
+			string(KindTokenRevoked):       "Your session has ended. Please sign in again.",
+			string(KindTokenReused):        "For your security, you have been signed out. Please sign in again.",
+			string(KindInvalidCredentials): "The email or password is incorrect.",
+			string(KindInsufficientScope):  "You don't have permission to do that.",
+			string(KindForbidden):          "You don't have permission to do that.",
+			string(KindBlocked):            "Access from your network or location is not allowed.",
+			string(KindNotFound):           "We couldn't find what you were looking for.",
+			string(KindRateLimited):        "Too many attempts. Please wait a moment and try again.",
+			string(KindInvalidRequest):     "Something about that request wasn't right. Please check and try again.",
+			string(KindUpstream):           "Sign-in is temporarily unavailable. Please try again shortly.",
+			string(KindInternal):           "Something went wrong. Please try again.",
+			"invalid_grant":                "Your session has expired. Please sign in again.",
+			"account_locked":               "Your account is locked. Please contact support.",
+			"email_not_verified":           "Please verify your email address before signing in.",
+			"mfa_required":                 "Additional verification is required.",
+			"user_exists":                  "An account with this email already exists.",
+			"email_conflict":               "An account with this email already exists in another organisation.",
+			"weak_password":                "Please choose a stronger password.",
+			"tenant_not_found":             "This organisation could not be found.",
+			"session_max_age_exceeded":     "For your security, please sign in again.",
+			"session_idle_timeout":         "You were signed out after a period of inactivity. Please sign in again.",
+			"unknown":                      "Something went wrong. Please try again.",
+		},
+		"fr": {
+			string(KindTokenMissing):       "Veuillez vous connecter pour continuer.",
+			string(KindTokenMalformed):     "Votre session n'est pas valide. Veuillez vous reconnecter.",
+			string(KindSignatureInvalid):   "Votre session n'est pas valide. Veuillez vous reconnecter.",
+			string(KindKeyNotFound):        "Votre session n'est pas valide. Veuillez vous reconnecter.",
+			string(KindClaimsInvalid):      "Votre session n'est pas valide. Veuillez vous reconnecter.",
+			string(KindTokenExpired):       "Votre session a expiré. Veuillez vous reconnecter.",
+			string(KindTokenRevoked):       "Votre session a pris fin. Veuillez vous reconnecter.",
+			string(KindTokenReused):        "Par mesure de sécurité, vous avez été déconnecté. Veuillez vous reconnecter.",
+			string(KindInvalidCredentials): "L'adresse e-mail ou le mot de passe est incorrect.",
+			string(KindInsufficientScope):  "Vous n'avez pas l'autorisation d'effectuer cette action.",
+			string(KindForbidden):          "Vous n'avez pas l'autorisation d'effectuer cette action.",
+			string(KindBlocked):            "L'accès depuis votre réseau ou votre emplacement n'est pas autorisé.",
+			string(KindNotFound):           "L'élément demandé est introuvable.",
+			string(KindRateLimited):        "Trop de tentatives. Veuillez patienter un instant puis réessayer.",
+			string(KindInvalidRequest):     "La demande est invalide. Veuillez vérifier puis réessayer.",
+			string(KindUpstream):           "La connexion est momentanément indisponible. Veuillez réessayer sous peu.",
+			string(KindInternal):           "Une erreur est survenue. Veuillez réessayer.",
+			"invalid_grant":                "Votre session a expiré. Veuillez vous reconnecter.",
+			"account_locked":               "Votre compte est verrouillé. Veuillez contacter le support.",
+			"email_not_verified":           "Veuillez vérifier votre adresse e-mail avant de vous connecter.",
+			"mfa_required":                 "Une vérification supplémentaire est requise.",
+			"user_exists":                  "Un compte existe déjà avec cette adresse e-mail.",
+			"email_conflict":               "Un compte avec cette adresse e-mail existe déjà dans une autre organisation.",
+			"weak_password":                "Veuillez choisir un mot de passe plus robuste.",
+			"tenant_not_found":             "Cette organisation est introuvable.",
+			"session_max_age_exceeded":     "Par mesure de sécurité, veuillez vous reconnecter.",
+			"session_idle_timeout":         "Vous avez été déconnecté après une période d'inactivité. Veuillez vous reconnecter.",
+			"unknown":                      "Une erreur est survenue. Veuillez réessayer.",
+		},
+		"sw": {
+			string(KindTokenMissing):       "Tafadhali ingia ili kuendelea.",
+			string(KindTokenMalformed):     "Kipindi chako si halali. Tafadhali ingia tena.",
+			string(KindSignatureInvalid):   "Kipindi chako si halali. Tafadhali ingia tena.",
+			string(KindKeyNotFound):        "Kipindi chako si halali. Tafadhali ingia tena.",
+			string(KindClaimsInvalid):      "Kipindi chako si halali. Tafadhali ingia tena.",
+			string(KindTokenExpired):       "Muda wa kipindi chako umekwisha. Tafadhali ingia tena.",
+			string(KindTokenRevoked):       "Kipindi chako kimekamilika. Tafadhali ingia tena.",
+			string(KindTokenReused):        "Kwa usalama wako, umetolewa. Tafadhali ingia tena.",
+			string(KindInvalidCredentials): "Barua pepe au nenosiri si sahihi.",
+			string(KindInsufficientScope):  "Huna ruhusa ya kufanya hivyo.",
+			string(KindForbidden):          "Huna ruhusa ya kufanya hivyo.",
+			string(KindBlocked):            "Ufikiaji kutoka mtandao au eneo lako hauruhusiwi.",
+			string(KindNotFound):           "Hatukuweza kupata ulichokuwa ukitafuta.",
+			string(KindRateLimited):        "Majaribio mengi mno. Tafadhali subiri kidogo kisha ujaribu tena.",
+			string(KindInvalidRequest):     "Ombi hilo lina hitilafu. Tafadhali hakiki kisha ujaribu tena.",
+			string(KindUpstream):           "Huduma ya kuingia haipatikani kwa sasa. Tafadhali jaribu tena baadaye kidogo.",
+			string(KindInternal):           "Hitilafu imetokea. Tafadhali jaribu tena.",
+			"invalid_grant":                "Muda wa kipindi chako umekwisha. Tafadhali ingia tena.",
+			"account_locked":               "Akaunti yako imefungwa. Tafadhali wasiliana na huduma kwa wateja.",
+			"email_not_verified":           "Tafadhali thibitisha barua pepe yako kabla ya kuingia.",
+			"mfa_required":                 "Uthibitisho wa ziada unahitajika.",
+			"user_exists":                  "Tayari kuna akaunti yenye barua pepe hii.",
+			"email_conflict":               "Tayari kuna akaunti yenye barua pepe hii katika shirika lingine.",
+			"weak_password":                "Tafadhali chagua nenosiri imara zaidi.",
+			"tenant_not_found":             "Shirika hili halikupatikana.",
+			"session_max_age_exceeded":     "Kwa usalama wako, tafadhali ingia tena.",
+			"session_idle_timeout":         "Umetolewa baada ya muda bila shughuli. Tafadhali ingia tena.",
+			"unknown":                      "Hitilafu imetokea. Tafadhali jaribu tena.",
+		},
+	}
+)
+
+// RegisterErrorMessages adds or overrides user-facing messages for lang (e.g. "de" or
+// "pt-BR"), keyed by auth-service error code or ErrorKind. Call it during setup, e.g. to
+// localise product-specific codes or add a language.
+func RegisterErrorMessages(lang string, messages map[string]string) {
+	lang = strings.ToLower(lang)
+	errorMessagesMu.Lock()
+	defer errorMessagesMu.Unlock()
+	catalog := errorMessages[lang]
+	if catalog == nil {
+		catalog = make(map[string]string, len(messages))
+		errorMessages[lang] = catalog
+	}
+	for code, msg := range messages {
+		catalog[code] = msg
+	}
+}
+
+// ErrorMessage returns a friendly, localised message for err, suitable for showing to end
+// users; it never includes internal details. lang is a BCP 47 tag or an Accept-Language
+// header value ("fr-CA", "sw, en;q=0.8"). Each language in turn (the requested one, its base
+// language, then English) is searched for the auth-service error code, then the error's
+// Kind, so users get a generic message in their language over a specific one in English.
+func ErrorMessage(err error, lang string) string {
+	var codes []string
+	var ae *AuthError
+	if errors.As(err, &ae) {
+		if ae.Code != "" {
+			codes = append(codes, ae.Code)
+		}
+		codes = append(codes, string(ae.Kind))
+	}
+	codes = append(codes, "unknown")
+
+	langs := preferredLanguages(lang)
+	errorMessagesMu.RLock()
+	defer errorMessagesMu.RUnlock()
+	for _, l := range langs {
+		for _, code := range codes {
+			if msg, ok := errorMessages[l][code]; ok {
+				return msg
+			}
+		}
+	}
+	return errorMessages["en"]["unknown"]
+}
+
+// preferredLanguages expands lang into lookup order: each listed tag, then its base
+// language, then English. Quality values are ignored; tags are taken in the order given.
+func preferredLanguages(lang string) []string {
+	var langs []string
+	for _, part := range strings.Split(lang, ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+		langs = append(langs, tag)
+		if base, _, ok := strings.Cut(tag, "-"); ok {
+			langs = append(langs, base)
+		}
+	}
+	return append(langs, "en")
+}
@@ -0,0 +1,33 @@
+package authclient
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorMessage(t *testing.T) {
+	RegisterErrorMessages("en", map[string]string{"seat_limit": "Your plan has no free seats."})
+	locked := &AuthError{Kind: KindForbidden, Code: "account_locked"}
+	seats := &AuthError{Kind: KindForbidden, Code: "seat_limit"}
+	tests := []struct {
+		name string
+		err  error
+		lang string
+		want string
+	}{
+		{"kind", ErrTokenExpired, "en", "Your session has expired. Please sign in again."},
+		{"code over kind", locked, "en", "Your account is locked. Please contact support."},
+		{"french", newAuthError(KindInvalidCredentials, "auth-service: login failed", nil), "fr", "L'adresse e-mail ou le mot de passe est incorrect."},
+		{"regional tag", locked, "fr-CA", "Votre compte est verrouillé. Veuillez contacter le support."},
+		{"accept-language list", ErrRateLimited, "de-DE, sw;q=0.8", "Majaribio mengi mno. Tafadhali subiri kidogo kisha ujaribu tena."},
+		{"unknown language", ErrForbidden, "ja", "You don't have permission to do that."},
+		{"english-only code falls back to kind", seats, "fr", "Vous n'avez pas l'autorisation d'effectuer cette action."},
+		{"english-only code", seats, "en-GB", "Your plan has no free seats."},
+		{"plain error", errors.New("dial tcp: refused"), "sw", "Hitilafu imetokea. Tafadhali jaribu tena."},
+	}
+	for _, tt := range tests {
+		if got := ErrorMessage(tt.err, tt.lang); got != tt.want {
+			t.Errorf("%s: ErrorMessage = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
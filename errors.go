@@ -0,0 +1,216 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrorKind classifies an AuthError independently of which component produced it.
+type ErrorKind string
+
+const (
+	KindTokenMissing       ErrorKind = "token_missing"       // no bearer token or API key presented
+	KindTokenMalformed     ErrorKind = "token_malformed"     // not a parseable JWT
+	KindTokenExpired       ErrorKind = "token_expired"       // exp (or nbf) check failed
+	KindTokenRevoked       ErrorKind = "token_revoked"       // token or its session was revoked
+	KindTokenReused        ErrorKind = "token_reused"        // rotated refresh token replayed; its family is revoked
+	KindSignatureInvalid   ErrorKind = "signature_invalid"   // signature did not verify
+	KindKeyNotFound        ErrorKind = "key_not_found"       // kid missing or not in the JWKS
+	KindClaimsInvalid      ErrorKind = "claims_invalid"      // issuer, audience or token type rejected
+	KindInvalidCredentials ErrorKind = "invalid_credentials" // API key, password or refresh token rejected
+	KindMFARequired        ErrorKind = "mfa_required"        // password accepted, second factor outstanding; see MFAChallengeResponse
+	KindInsufficientScope  ErrorKind = "insufficient_scope"  // authenticated but lacking scope/role/permission
+	KindForbidden          ErrorKind = "forbidden"           // authenticated but denied (tenant, service, ...)
+	KindBlocked            ErrorKind = "blocked"             // refused by risk policy (IP reputation, geo-block); see BlockedError
+	KindNotFound           ErrorKind = "not_found"           // auth-service resource does not exist
+	KindRateLimited        ErrorKind = "rate_limited"        // auth-service returned 429
+	KindInvalidRequest     ErrorKind = "invalid_request"     // auth-service rejected the request as malformed
+	KindUpstream           ErrorKind = "upstream"            // auth-service unreachable or failing
+	KindInternal           ErrorKind = "internal"            // local failure (encoding, configuration)
+)
+
+// Sentinels for errors.Is. An *AuthError matches the sentinel of its Kind, so callers can
+// write errors.Is(err, authclient.ErrTokenExpired) regardless of the producing component.
+var (
+	ErrTokenMissing       = &AuthError{Kind: KindTokenMissing}
+	ErrTokenMalformed     = &AuthError{Kind: KindTokenMalformed}
+	ErrTokenExpired       = &AuthError{Kind: KindTokenExpired}
+	ErrTokenRevoked       = &AuthError{Kind: KindTokenRevoked}
+	ErrTokenReused        = &AuthError{Kind: KindTokenReused}
+	ErrSignatureInvalid   = &AuthError{Kind: KindSignatureInvalid}
+	ErrKeyNotFound        = &AuthError{Kind: KindKeyNotFound}
+	ErrClaimsInvalid      = &AuthError{Kind: KindClaimsInvalid}
+	ErrInvalidCredentials = &AuthError{Kind: KindInvalidCredentials}
+	ErrMFARequired        = &AuthError{Kind: KindMFARequired}
+	ErrInsufficientScope  = &AuthError{Kind: KindInsufficientScope}
+	ErrForbidden          = &AuthError{Kind: KindForbidden}
+	ErrBlocked            = &AuthError{Kind: KindBlocked}
+	ErrNotFound           = &AuthError{Kind: KindNotFound}
+	ErrRateLimited        = &AuthError{Kind: KindRateLimited}
+	ErrUpstream           = &AuthError{Kind: KindUpstream}
+)
+
+// AuthError is the error type returned by Validator, APIKeyValidator, Client and the
+// middleware. Cause keeps the underlying error (a jwt error, a transport error, or the
+// auth-service *Error body) for errors.As.
+type AuthError struct {
+	Kind       ErrorKind
+	StatusCode int    // HTTP status a server should answer with (or auth-service answered with)
+	Code       string // machine-readable code, e.g. auth-service's error_code
+	Message    string // human-readable summary, without secrets
+	Cause      error
+	RetryAfter time.Duration // auth-service's Retry-After on 429 and 503 responses; zero when absent
+}
+
+func (e *AuthError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = string(e.Kind)
+	}
+	if e.Cause != nil {
+		return msg + ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+func (e *AuthError) Unwrap() error { return e.Cause }
+
+// Is matches sentinels by Kind.
+func (e *AuthError) Is(target error) bool {
+	t, ok := target.(*AuthError)
+	return ok && t.Kind == e.Kind && t.StatusCode == 0 && t.Message == "" && t.Cause == nil
+}
+
+// newAuthError builds an AuthError with the default status for kind.
+func newAuthError(kind ErrorKind, message string, cause error) *AuthError {
+	return &AuthError{Kind: kind, StatusCode: kindStatus(kind), Message: message, Cause: cause}
+}
+
+// kindStatus is the HTTP status a server should use for kind.
+func kindStatus(kind ErrorKind) int {
+	switch kind {
+	case KindInsufficientScope, KindForbidden, KindBlocked:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindRateLimited:
+		return http.StatusTooManyRequests
+	case KindInvalidRequest:
+		return http.StatusBadRequest
+	case KindUpstream:
+		return http.StatusServiceUnavailable
+	case KindInternal:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusUnauthorized
+	}
+}
+
+// statusKind classifies an auth-service HTTP status.
+func statusKind(status int) ErrorKind {
+	switch {
+	case status == http.StatusUnauthorized:
+		return KindInvalidCredentials
+	case status == http.StatusForbidden:
+		return KindForbidden
+	case status == http.StatusNotFound:
+		return KindNotFound
+	case status == http.StatusTooManyRequests:
+		return KindRateLimited
+	case status >= 500:
+		return KindUpstream
+	default:
+		return KindInvalidRequest
+	}
+}
+
+// tokenReuseCodes are the auth-service error codes meaning a refresh token that had already
+// been rotated was presented again. auth-service then revokes the whole token family, so the
+// session may be in an attacker's hands and must not be retried.
+var tokenReuseCodes = map[string]bool{
+	"refresh_token_reused": true,
+	"token_reuse_detected": true,
+	"token_family_revoked": true,
+}
+
+// withRetryAfter records the Retry-After header of h on an AuthError from responseError.
+func withRetryAfter(err error, h http.Header) error {
+	var ae *AuthError
+	if errors.As(err, &ae) {
+		ae.RetryAfter = parseRetryAfter(h.Get("Retry-After"))
+	}
+	return err
+}
+
+// parseRetryAfter parses a Retry-After value in delay-seconds or HTTP-date form.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// KindOf returns the Kind of the first AuthError in err's chain, or "" if there is none.
+func KindOf(err error) ErrorKind {
+	var ae *AuthError
+	if errors.As(err, &ae) {
+		return ae.Kind
+	}
+	return ""
+}
+
+// ErrorHook receives unexpected failures (auth-service unreachable or answering 5xx, JWKS
+// fetch errors) with the operation that failed, e.g. for forwarding to Sentry. Expected
+// outcomes such as rejected tokens or keys are not reported. Hooks run synchronously on the
+// failing goroutine and must be fast and safe for concurrent use.
+type ErrorHook func(ctx context.Context, op string, err error)
+
+// report calls hook if it is set.
+func (hook ErrorHook) report(ctx context.Context, op string, err error) {
+	if hook != nil {
+		hook(ctx, op, err)
+	}
+}
+
+// asAuthError returns err's *AuthError, or wraps err as KindInternal with message.
+func asAuthError(err error, message string) *AuthError {
+	var ae *AuthError
+	if errors.As(err, &ae) {
+		return ae
+	}
+	return newAuthError(KindInternal, message, err)
+}
+
+// publicMessage is the text safe to return to API callers: it never includes issuer,
+// audience or key details from Message/Cause.
+func (e *AuthError) publicMessage() string {
+	switch e.Kind {
+	case KindTokenMissing:
+		return "missing bearer token or API key"
+	case KindTokenExpired:
+		return "token expired"
+	case KindTokenRevoked, KindTokenReused:
+		return "token revoked"
+	case KindInvalidCredentials:
+		return "invalid credentials"
+	case KindMFARequired:
+		return "additional verification required"
+	case KindUpstream:
+		return "authentication temporarily unavailable"
+	case KindInsufficientScope, KindForbidden:
+		return "forbidden"
+	case KindBlocked:
+		return "access blocked"
+	default:
+		return "invalid token"
+	}
+}
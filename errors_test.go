@@ -0,0 +1,103 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestAuthErrorKinds(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid_grant","error_description":"refresh token revoked"}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, nil).Refresh(context.Background(), "r1")
+	if !errors.Is(err, ErrInvalidCredentials) || errors.Is(err, ErrUpstream) {
+		t.Fatalf("Refresh() error = %v (kind %q), want invalid_credentials", err, KindOf(err))
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.ErrorField != "invalid_grant" {
+		t.Fatalf("auth-service body not reachable via errors.As: %v", err)
+	}
+
+	if got := parseError(jwt.ErrTokenExpired); !errors.Is(got, ErrTokenExpired) {
+		t.Fatalf("parseError(expired) = %v", got)
+	}
+	if got := parseError(jwt.ErrTokenMalformed); KindOf(got) != KindTokenMalformed {
+		t.Fatalf("parseError(malformed) kind = %q", KindOf(got))
+	}
+}
+
+func TestErrorHooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	type report struct {
+		op  string
+		err error
+	}
+	capture := func(reports *[]report) ErrorHook {
+		return func(ctx context.Context, op string, err error) {
+			*reports = append(*reports, report{op, err})
+		}
+	}
+
+	t.Run("client", func(t *testing.T) {
+		var reports []report
+		_, err := New(srv.URL, WithOnError(capture(&reports))).Login(context.Background(), LoginRequest{Email: "ada@example.com"})
+		if err == nil {
+			t.Fatal("expected login to fail")
+		}
+		if len(reports) != 1 || reports[0].op != "POST /api/v1/auth/login" {
+			t.Fatalf("reports = %v, want one for POST /api/v1/auth/login", reports)
+		}
+		var ae *AuthError
+		if !errors.As(reports[0].err, &ae) || ae.Kind != KindUpstream || ae.StatusCode != http.StatusBadGateway {
+			t.Fatalf("reported error = %v, want upstream 502", reports[0].err)
+		}
+	})
+
+	t.Run("jwks", func(t *testing.T) {
+		var reports []report
+		cfg := DefaultConfig(srv.URL, "issuer", "audience")
+		cfg.ManualRefresh = true
+		cfg.OnError = capture(&reports)
+		_, err := NewValidator(cfg)
+		if err == nil {
+			t.Fatal("expected the initial JWKS fetch to fail")
+		}
+		if len(reports) != 1 || reports[0].op != "jwks.fetch" || !errors.Is(err, reports[0].err) {
+			t.Fatalf("reports = %v, want one for jwks.fetch carrying %v", reports, err)
+		}
+	})
+
+	t.Run("api key", func(t *testing.T) {
+		var reports []report
+		v := NewAPIKeyValidator(srv.URL, nil, WithAPIKeyOnError(capture(&reports)))
+		_, err := v.ValidateAPIKeyFull(context.Background(), "some-key")
+		if err == nil {
+			t.Fatal("expected validation to fail")
+		}
+		if len(reports) != 1 || reports[0].op != "apikey.validate" || reports[0].err != err {
+			t.Fatalf("reports = %v, want one for apikey.validate carrying %v", reports, err)
+		}
+	})
+
+	t.Run("nil hook", func(t *testing.T) {
+		ErrorHook(nil).report(context.Background(), "op", errors.New("boom"))
+		if _, err := New(srv.URL).Login(context.Background(), LoginRequest{}); KindOf(err) != KindUpstream {
+			t.Fatalf("Login() error = %v, want upstream without a hook", err)
+		}
+		if _, err := NewAPIKeyValidator(srv.URL, nil).ValidateAPIKeyFull(context.Background(), "some-key"); KindOf(err) != KindUpstream {
+			t.Fatalf("ValidateAPIKeyFull() error = %v, want upstream without a hook", err)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// Package kafkaevents delivers auth-service events from a Kafka topic to an
+// events.Dispatcher, the same registry the webhook receiver uses.
+package kafkaevents
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"github.com/Bengo-Hub/shared-auth-client/events"
+	"github.com/segmentio/kafka-go"
+)
+
+// DefaultTopic is the topic auth-service publishes events to.
+const DefaultTopic = "auth.events"
+
+// Option configures Run.
+type Option func(*options)
+
+type options struct {
+	logger     authclient.Logger
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// WithLogger logs malformed messages and handler failures to logger.
+func WithLogger(logger authclient.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithBackoff sets the delays between retries of a failing handler (default 1s, doubling
+// up to 30s).
+func WithBackoff(min, max time.Duration) Option {
+	return func(o *options) {
+		if min > 0 && max >= min {
+			o.minBackoff, o.maxBackoff = min, max
+		}
+	}
+}
+
+// Run reads events with reader and dispatches them until ctx is cancelled or reading fails.
+// Use a reader with a GroupID so offsets are committed per consumer group. An offset is
+// committed only after its event was handled; a failing handler is retried with backoff,
+// which holds back the partition, so handlers must eventually succeed or return nil for
+// events they choose to drop. Malformed messages are logged and skipped.
+func Run(ctx context.Context, reader *kafka.Reader, d events.Dispatcher, opts ...Option) error {
+	o := options{logger: authclient.NopLogger(), minBackoff: time.Second, maxBackoff: 30 * time.Second}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		var event events.Event
+		if err := json.Unmarshal(msg.Value, &event); err != nil || event.Type == "" {
+			o.logger.Warn("malformed auth event", "topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset, "error", err)
+		} else if err := o.dispatch(ctx, d, event); err != nil {
+			return err
+		}
+
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			return err
+		}
+	}
+}
+
+// dispatch retries d.Dispatch until it succeeds or ctx is cancelled.
+func (o options) dispatch(ctx context.Context, d events.Dispatcher, event events.Event) error {
+	backoff := o.minBackoff
+	for {
+		err := d.Dispatch(ctx, event)
+		if err == nil {
+			return nil
+		}
+		o.logger.Warn("auth event handler failed, retrying", "id", event.ID, "type", event.Type, "error", err, "retry_in", backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, o.maxBackoff)
+	}
+}
@@ -0,0 +1,38 @@
+package kafkaevents
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"github.com/Bengo-Hub/shared-auth-client/events"
+)
+
+type flakyDispatcher struct{ failures int }
+
+func (d *flakyDispatcher) Dispatch(context.Context, events.Event) error {
+	if d.failures > 0 {
+		d.failures--
+		return errors.New("temporarily unavailable")
+	}
+	return nil
+}
+
+func TestDispatchRetriesUntilHandled(t *testing.T) {
+	o := options{logger: authclient.NopLogger(), minBackoff: time.Millisecond, maxBackoff: 2 * time.Millisecond}
+	d := &flakyDispatcher{failures: 3}
+	if err := o.dispatch(context.Background(), d, events.Event{Type: events.TypeUserCreated}); err != nil {
+		t.Fatalf("dispatch = %v", err)
+	}
+	if d.failures != 0 {
+		t.Fatalf("remaining failures = %d", d.failures)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := o.dispatch(ctx, &flakyDispatcher{failures: 1}, events.Event{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("dispatch after cancel = %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+// Package natsevents delivers auth-service events published on NATS to an events.Dispatcher,
+// the same registry the webhook receiver uses.
+package natsevents
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"github.com/Bengo-Hub/shared-auth-client/events"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// DefaultSubject matches every auth-service event; auth-service publishes each event on
+// "auth.events.<type>", e.g. auth.events.user.created.
+const DefaultSubject = "auth.events.>"
+
+// Option configures a consumer.
+type Option func(*options)
+
+type options struct {
+	logger     authclient.Logger
+	retryDelay time.Duration
+}
+
+// WithLogger logs malformed messages and handler failures to logger.
+func WithLogger(logger authclient.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithRetryDelay sets how long JetStream waits before redelivering an event whose handler
+// failed (default 5 seconds).
+func WithRetryDelay(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.retryDelay = d
+		}
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{logger: authclient.NopLogger(), retryDelay: 5 * time.Second}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Subscribe queue-subscribes to subject on core NATS so each event is handled by one replica
+// of the service. Core NATS does not redeliver, so handler failures are only logged; use
+// ConsumeJetStream when events must not be lost.
+func Subscribe(nc *nats.Conn, subject, queue string, d events.Dispatcher, opts ...Option) (*nats.Subscription, error) {
+	o := newOptions(opts)
+	return nc.QueueSubscribe(subject, queue, func(msg *nats.Msg) {
+		event, ok := o.decode(msg.Subject, msg.Data)
+		if !ok {
+			return
+		}
+		if err := d.Dispatch(context.Background(), event); err != nil {
+			o.logger.Error("auth event handler failed", "subject", msg.Subject, "id", event.ID, "type", event.Type, "error", err)
+		}
+	})
+}
+
+// ConsumeJetStream dispatches messages from a JetStream consumer until ctx is cancelled.
+// Messages are acked once handled, redelivered after the retry delay when the handler
+// fails, and terminated when they cannot be decoded.
+func ConsumeJetStream(ctx context.Context, consumer jetstream.Consumer, d events.Dispatcher, opts ...Option) error {
+	o := newOptions(opts)
+	cc, err := consumer.Consume(func(msg jetstream.Msg) {
+		event, ok := o.decode(msg.Subject(), msg.Data())
+		if !ok {
+			_ = msg.Term()
+			return
+		}
+		if err := d.Dispatch(ctx, event); err != nil {
+			o.logger.Warn("auth event handler failed, redelivering", "subject", msg.Subject(), "id", event.ID, "type", event.Type, "error", err)
+			_ = msg.NakWithDelay(o.retryDelay)
+			return
+		}
+		_ = msg.Ack()
+	})
+	if err != nil {
+		return err
+	}
+	<-ctx.Done()
+	cc.Stop()
+	return ctx.Err()
+}
+
+func (o options) decode(subject string, data []byte) (events.Event, bool) {
+	var event events.Event
+	if err := json.Unmarshal(data, &event); err != nil || event.Type == "" {
+		o.logger.Warn("malformed auth event", "subject", subject, "error", err)
+		return event, false
+	}
+	return event, true
+}
@@ -0,0 +1,98 @@
+// Package events defines the auth-service domain events delivered to services by webhook,
+// server-sent events or a message broker, with typed payloads for each event type.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event types emitted by auth-service.
+const (
+	TypeUserCreated    = "user.created"
+	TypeUserUpdated    = "user.updated"
+	TypeUserDeleted    = "user.deleted"
+	TypeSessionRevoked = "session.revoked"
+	TypeTenantUpdated  = "tenant.updated"
+	TypeAPIKeyRevoked  = "apikey.revoked"
+
+	TypePermissionsChanged = "permissions.changed"
+)
+
+// Event is the envelope shared by every transport. Data holds the type-specific payload;
+// decode it with Decode.
+type Event struct {
+	ID         string          `json:"id"` // unique per event; redeliveries reuse it
+	Type       string          `json:"type"`
+	TenantID   string          `json:"tenant_id,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Dispatcher routes a decoded event to the service's handlers. Transports (webhooks, the
+// revocation stream, broker consumers) deliver through a Dispatcher so handlers are written
+// once. A non-nil error asks the transport to redeliver the event.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, event Event) error
+}
+
+// Decode unmarshals the event payload into v.
+func (e Event) Decode(v any) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("decode %s event %s: %w", e.Type, e.ID, err)
+	}
+	return nil
+}
+
+// UserCreated is the payload of TypeUserCreated.
+type UserCreated struct {
+	UserID     string `json:"user_id"`
+	Email      string `json:"email"`
+	TenantID   string `json:"tenant_id"`
+	TenantSlug string `json:"tenant_slug,omitempty"`
+}
+
+// UserUpdated is the payload of TypeUserUpdated. Fields names the changed attributes when
+// auth-service reports them (e.g. "email", "profile"); empty means unknown.
+type UserUpdated struct {
+	UserID   string   `json:"user_id"`
+	TenantID string   `json:"tenant_id"`
+	Fields   []string `json:"fields,omitempty"`
+}
+
+// UserDeleted is the payload of TypeUserDeleted.
+type UserDeleted struct {
+	UserID   string `json:"user_id"`
+	TenantID string `json:"tenant_id"`
+}
+
+// SessionRevoked is the payload of TypeSessionRevoked. AllSessions is set for
+// "sign out everywhere", in which case SessionID is empty.
+type SessionRevoked struct {
+	SessionID   string `json:"session_id,omitempty"`
+	UserID      string `json:"user_id"`
+	AllSessions bool   `json:"all_sessions,omitempty"`
+}
+
+// TenantUpdated is the payload of TypeTenantUpdated.
+type TenantUpdated struct {
+	TenantID string `json:"tenant_id"`
+	Slug     string `json:"slug"`
+	Name     string `json:"name,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+// APIKeyRevoked is the payload of TypeAPIKeyRevoked. Keys are identified by fingerprint
+// (authclient.Fingerprint), never by value.
+type APIKeyRevoked struct {
+	Fingerprints []string `json:"fingerprints"`
+}
+
+// PermissionsChanged is the payload of TypePermissionsChanged, emitted when roles, grants or
+// policies change. An empty UserID means every user of TenantID is affected.
+type PermissionsChanged struct {
+	TenantID string `json:"tenant_id"`
+	UserID   string `json:"user_id,omitempty"`
+}
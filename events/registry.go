@@ -0,0 +1,152 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// HandlerFunc handles one event. A non-nil error asks the transport to redeliver it.
+type HandlerFunc func(ctx context.Context, event Event) error
+
+// Registry holds a service's event handlers and dispatches events to them. Register handlers
+// once and pass the Registry to every transport (webhooks.Handler, RevocationStream, the
+// eventbus consumers) so delivery can change without touching handler code.
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[string][]HandlerFunc
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string][]HandlerFunc)}
+}
+
+// Handle registers fn for eventType. Several handlers may be registered for one type; they
+// run in registration order.
+func (r *Registry) Handle(eventType string, fn HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.handlers == nil {
+		r.handlers = make(map[string][]HandlerFunc)
+	}
+	r.handlers[eventType] = append(r.handlers[eventType], fn)
+}
+
+// Dispatch runs every handler registered for event.Type and joins their errors. Events of
+// unregistered types are ignored. It implements Dispatcher.
+func (r *Registry) Dispatch(ctx context.Context, event Event) error {
+	r.mu.RLock()
+	handlers := r.handlers[event.Type]
+	r.mu.RUnlock()
+	var errs []error
+	for _, fn := range handlers {
+		if err := fn(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UserCreatedEvent is a TypeUserCreated event with its decoded payload.
+type UserCreatedEvent struct {
+	Event Event
+	Data  UserCreated
+}
+
+// UserUpdatedEvent is a TypeUserUpdated event with its decoded payload.
+type UserUpdatedEvent struct {
+	Event Event
+	Data  UserUpdated
+}
+
+// UserDeletedEvent is a TypeUserDeleted event with its decoded payload.
+type UserDeletedEvent struct {
+	Event Event
+	Data  UserDeleted
+}
+
+// SessionRevokedEvent is a TypeSessionRevoked event with its decoded payload.
+type SessionRevokedEvent struct {
+	Event Event
+	Data  SessionRevoked
+}
+
+// TenantUpdatedEvent is a TypeTenantUpdated event with its decoded payload.
+type TenantUpdatedEvent struct {
+	Event Event
+	Data  TenantUpdated
+}
+
+// APIKeyRevokedEvent is a TypeAPIKeyRevoked event with its decoded payload.
+type APIKeyRevokedEvent struct {
+	Event Event
+	Data  APIKeyRevoked
+}
+
+// PermissionsChangedEvent is a TypePermissionsChanged event with its decoded payload.
+type PermissionsChangedEvent struct {
+	Event Event
+	Data  PermissionsChanged
+}
+
+// OnUserCreated registers fn for TypeUserCreated.
+func (r *Registry) OnUserCreated(fn func(ctx context.Context, e UserCreatedEvent) error) {
+	handleTyped(r, TypeUserCreated, func(ctx context.Context, e Event, p UserCreated) error {
+		return fn(ctx, UserCreatedEvent{Event: e, Data: p})
+	})
+}
+
+// OnUserUpdated registers fn for TypeUserUpdated.
+func (r *Registry) OnUserUpdated(fn func(ctx context.Context, e UserUpdatedEvent) error) {
+	handleTyped(r, TypeUserUpdated, func(ctx context.Context, e Event, p UserUpdated) error {
+		return fn(ctx, UserUpdatedEvent{Event: e, Data: p})
+	})
+}
+
+// OnUserDeleted registers fn for TypeUserDeleted.
+func (r *Registry) OnUserDeleted(fn func(ctx context.Context, e UserDeletedEvent) error) {
+	handleTyped(r, TypeUserDeleted, func(ctx context.Context, e Event, p UserDeleted) error {
+		return fn(ctx, UserDeletedEvent{Event: e, Data: p})
+	})
+}
+
+// OnSessionRevoked registers fn for TypeSessionRevoked.
+func (r *Registry) OnSessionRevoked(fn func(ctx context.Context, e SessionRevokedEvent) error) {
+	handleTyped(r, TypeSessionRevoked, func(ctx context.Context, e Event, p SessionRevoked) error {
+		return fn(ctx, SessionRevokedEvent{Event: e, Data: p})
+	})
+}
+
+// OnTenantUpdated registers fn for TypeTenantUpdated.
+func (r *Registry) OnTenantUpdated(fn func(ctx context.Context, e TenantUpdatedEvent) error) {
+	handleTyped(r, TypeTenantUpdated, func(ctx context.Context, e Event, p TenantUpdated) error {
+		return fn(ctx, TenantUpdatedEvent{Event: e, Data: p})
+	})
+}
+
+// OnAPIKeyRevoked registers fn for TypeAPIKeyRevoked.
+func (r *Registry) OnAPIKeyRevoked(fn func(ctx context.Context, e APIKeyRevokedEvent) error) {
+	handleTyped(r, TypeAPIKeyRevoked, func(ctx context.Context, e Event, p APIKeyRevoked) error {
+		return fn(ctx, APIKeyRevokedEvent{Event: e, Data: p})
+	})
+}
+
+// OnPermissionsChanged registers fn for TypePermissionsChanged.
+func (r *Registry) OnPermissionsChanged(fn func(ctx context.Context, e PermissionsChangedEvent) error) {
+	handleTyped(r, TypePermissionsChanged, func(ctx context.Context, e Event, p PermissionsChanged) error {
+		return fn(ctx, PermissionsChangedEvent{Event: e, Data: p})
+	})
+}
+
+// handleTyped registers fn with the payload decoded as T. A payload that does not decode
+// is returned as an error.
+func handleTyped[T any](r *Registry, eventType string, fn func(ctx context.Context, e Event, payload T) error) {
+	r.Handle(eventType, func(ctx context.Context, e Event) error {
+		var payload T
+		if err := e.Decode(&payload); err != nil {
+			return err
+		}
+		return fn(ctx, e, payload)
+	})
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestRegistryDispatch(t *testing.T) {
+	r := NewRegistry()
+	var deleted []string
+	r.OnUserDeleted(func(ctx context.Context, e UserDeletedEvent) error {
+		deleted = append(deleted, e.Data.UserID)
+		return nil
+	})
+	r.OnUserDeleted(func(ctx context.Context, e UserDeletedEvent) error {
+		return errors.New("downstream unavailable")
+	})
+
+	tests := []struct {
+		name    string
+		event   Event
+		wantErr bool
+	}{
+		{"typed handlers", Event{Type: TypeUserDeleted, Data: json.RawMessage(`{"user_id":"u1"}`)}, true},
+		{"undecodable payload", Event{Type: TypeUserDeleted, Data: json.RawMessage(`[]`)}, true},
+		{"unregistered type", Event{Type: TypeTenantUpdated, Data: json.RawMessage(`{}`)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.Dispatch(context.Background(), tt.event); (err != nil) != tt.wantErr {
+				t.Fatalf("Dispatch = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if len(deleted) != 1 || deleted[0] != "u1" {
+		t.Fatalf("deleted = %v", deleted)
+	}
+}
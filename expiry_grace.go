@@ -0,0 +1,51 @@
+package authclient
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// WithExpiryGrace accepts bearer tokens that expired at most grace ago. A token can expire
+// while a request travels from the gateway through downstream services, and clocks between
+// services drift. Without this, such requests fail part-way through the chain. Keep grace
+// small (a few seconds). It applies only in this middleware, not to Validator.ValidateToken.
+// Grace-accepted tokens are never cached. observe, if non-nil, is called with how long
+// each accepted token had been expired, e.g. to feed a histogram. ExpiryGraceCount reports
+// the running total.
+func WithExpiryGrace(grace time.Duration, observe func(r *http.Request, expiredFor time.Duration)) AuthMiddlewareOption {
+	return func(a *AuthMiddleware) {
+		a.expiryGrace = grace
+		a.onExpiryGrace = observe
+	}
+}
+
+// ExpiryGraceCount returns how many requests were let through by WithExpiryGrace.
+func (a *AuthMiddleware) ExpiryGraceCount() uint64 {
+	return a.expiryGraceCount.Load()
+}
+
+// validateWithGrace re-validates a token rejected as expired, accepting it when it expired
+// no more than the configured grace ago.
+func (a *AuthMiddleware) validateWithGrace(r *http.Request, tokenStr string, err error) (*Claims, bool) {
+	if a.graceParser == nil || !errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, false
+	}
+	v := a.validatorFor(tokenStr)
+	if v == nil {
+		return nil, false
+	}
+	claims, err := v.validate(tokenStr, a.graceParser)
+	if err != nil || claims.RegisteredClaims.ExpiresAt == nil {
+		return nil, false
+	}
+	expiredFor := time.Since(claims.RegisteredClaims.ExpiresAt.Time)
+	a.expiryGraceCount.Add(1)
+	if a.onExpiryGrace != nil {
+		a.onExpiryGrace(r, expiredFor)
+	}
+	a.logger.Debug("accepted recently expired token", "expired_for", expiredFor, "method", r.Method, "path", r.URL.Path)
+	return claims, true
+}
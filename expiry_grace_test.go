@@ -0,0 +1,76 @@
+package authclient
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestExpiryGrace(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewSigner(key, "k1", SignerConfig{Issuer: "auth", Audience: []string{"orders"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := httptest.NewServer(signer.JWKSHandler())
+	defer jwks.Close()
+	v, err := NewValidator(DefaultConfig(jwks.URL, "auth", "orders"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Stop()
+
+	var observed []time.Duration
+	mw := NewAuthMiddleware(v, WithExpiryGrace(5*time.Second, func(r *http.Request, d time.Duration) {
+		observed = append(observed, d)
+	}))
+	strict := NewAuthMiddleware(v)
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tokenExpiredAgo := func(ago time.Duration) string {
+		now := time.Now()
+		token, err := signer.Sign(Claims{RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "u1",
+			IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+			NotBefore: jwt.NewNumericDate(now.Add(-time.Minute)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(-ago)),
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	tests := []struct {
+		name       string
+		mw         *AuthMiddleware
+		token      string
+		wantStatus int
+	}{
+		{"within grace", mw, tokenExpiredAgo(2 * time.Second), http.StatusOK},
+		{"beyond grace", mw, tokenExpiredAgo(30 * time.Second), http.StatusUnauthorized},
+		{"grace not configured", strict, tokenExpiredAgo(2 * time.Second), http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.token)
+		rec := httptest.NewRecorder()
+		tt.mw.RequireAuth(ok).ServeHTTP(rec, req)
+		if rec.Code != tt.wantStatus {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, tt.wantStatus)
+		}
+	}
+	if mw.ExpiryGraceCount() != 1 || len(observed) != 1 || observed[0] < time.Second {
+		t.Errorf("grace count = %d, observed %v; want one acceptance of a ~2s-expired token", mw.ExpiryGraceCount(), observed)
+	}
+	if _, err := v.ValidateToken(tokenExpiredAgo(2 * time.Second)); err == nil {
+		t.Error("ValidateToken must not apply the middleware grace")
+	}
+}
@@ -0,0 +1,131 @@
+// Package grpcauth carries bearer tokens and propagated claims through gRPC metadata using the
+// same conventions as the HTTP middleware in authclient (Authorization: Bearer, X-Auth-Claims).
+package grpcauth
+
+import (
+	"context"
+	"strings"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationKey = "authorization"
+	// ClaimsKey is the metadata key for propagated claims (lower-cased DefaultClaimsHeader).
+	ClaimsKey = "x-auth-claims"
+)
+
+// TokenFromIncomingContext returns the bearer token from incoming metadata.
+func TokenFromIncomingContext(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	for _, v := range md.Get(authorizationKey) {
+		if len(v) > 7 && strings.EqualFold(v[:7], "bearer ") {
+			if token := strings.TrimSpace(v[7:]); token != "" {
+				return token, true
+			}
+		}
+	}
+	return "", false
+}
+
+// AppendTokenToOutgoingContext attaches token as "authorization: Bearer <token>" to outgoing calls.
+func AppendTokenToOutgoingContext(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+token)
+}
+
+// ClaimsFromIncomingContext decodes claims propagated by an upstream service. The value is not
+// signature-checked; use VerifiedClaimsFromIncomingContext when a secret is shared.
+func ClaimsFromIncomingContext(ctx context.Context) (*authclient.Claims, bool) {
+	value, ok := firstIncoming(ctx, ClaimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, err := authclient.DecodeClaimsHeader(value)
+	return claims, err == nil
+}
+
+// VerifiedClaimsFromIncomingContext decodes propagated claims signed with secret.
+func VerifiedClaimsFromIncomingContext(ctx context.Context, secret []byte) (*authclient.Claims, error) {
+	value, ok := firstIncoming(ctx, ClaimsKey)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing propagated claims")
+	}
+	return authclient.VerifyClaimsHeader(value, secret)
+}
+
+// AppendClaimsToOutgoingContext propagates claims to downstream calls, signed when secret is set.
+func AppendClaimsToOutgoingContext(ctx context.Context, claims *authclient.Claims, secret []byte) context.Context {
+	value := authclient.EncodeClaimsHeader(claims)
+	if len(secret) > 0 {
+		value = authclient.SignClaimsHeader(claims, secret)
+	}
+	return metadata.AppendToOutgoingContext(ctx, ClaimsKey, value)
+}
+
+// UnaryServerInterceptor validates the incoming bearer token and stores the claims with
+// authclient.ContextWithClaims, so handlers use authclient.ClaimsFromContext exactly as HTTP
+// handlers do.
+func UnaryServerInterceptor(validator *authclient.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, validator)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming RPCs: the stream's Context
+// carries the validated claims.
+func StreamServerInterceptor(validator *authclient.Validator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), validator)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate validates the bearer token in ctx's metadata and returns ctx with its claims.
+func authenticate(ctx context.Context, validator *authclient.Validator) (context.Context, error) {
+	token, ok := TokenFromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	claims, err := validator.ValidateToken(token)
+	if err != nil {
+		if authclient.KindOf(err) == authclient.KindUpstream {
+			// JWKS could not be refreshed; the caller may retry.
+			return nil, status.Error(codes.Unavailable, "token validation unavailable")
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return authclient.ContextWithClaims(ctx, claims), nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context { return s.ctx }
+
+func firstIncoming(ctx context.Context, key string) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	vals := md.Get(key)
+	if len(vals) == 0 || vals[0] == "" {
+		return "", false
+	}
+	return vals[0], true
+}
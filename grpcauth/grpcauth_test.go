@@ -0,0 +1,130 @@
+package grpcauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http/httptest"
+	"testing"
+
+	authclient "github.com/Bengo-Hub/shared-auth-client"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestValidator(t *testing.T) (*authclient.Validator, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := authclient.NewSigner(key, "k1", authclient.SignerConfig{Issuer: "https://auth.example.com", Audience: []string{"orders"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := httptest.NewServer(signer.JWKSHandler())
+	t.Cleanup(jwks.Close)
+	v, err := authclient.NewValidator(authclient.DefaultConfig(jwks.URL, "https://auth.example.com", "orders"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(v.Stop)
+	token, err := signer.Sign(authclient.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v, token
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context { return s.ctx }
+
+func TestServerInterceptors(t *testing.T) {
+	v, token := newTestValidator(t)
+	unary := UnaryServerInterceptor(v)
+	stream := StreamServerInterceptor(v)
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want codes.Code
+	}{
+		{"no metadata", nil, codes.Unauthenticated},
+		{"no authorization", metadata.Pairs("x-request-id", "r1"), codes.Unauthenticated},
+		{"not bearer", metadata.Pairs("authorization", "Basic dXNlcjpwYXNz"), codes.Unauthenticated},
+		{"invalid token", metadata.Pairs("authorization", "Bearer not-a-jwt"), codes.Unauthenticated},
+		{"valid token", metadata.Pairs("authorization", "bearer "+token), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			subject := func(ctx context.Context) string {
+				claims, ok := authclient.ClaimsFromContext(ctx)
+				if !ok {
+					return ""
+				}
+				return claims.Subject
+			}
+
+			var unarySubject string
+			_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/orders.v1.Orders/Get"}, func(ctx context.Context, req any) (any, error) {
+				unarySubject = subject(ctx)
+				return nil, nil
+			})
+			if status.Code(err) != tt.want {
+				t.Fatalf("unary: code = %s, want %s", status.Code(err), tt.want)
+			}
+
+			var streamSubject string
+			err = stream(nil, &testStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/orders.v1.Orders/Watch"}, func(srv any, ss grpc.ServerStream) error {
+				streamSubject = subject(ss.Context())
+				return nil
+			})
+			if status.Code(err) != tt.want {
+				t.Fatalf("stream: code = %s, want %s", status.Code(err), tt.want)
+			}
+
+			if tt.want == codes.OK && (unarySubject != "user-1" || streamSubject != "user-1") {
+				t.Fatalf("handler subjects = %q, %q; want user-1", unarySubject, streamSubject)
+			}
+		})
+	}
+}
+
+func TestPropagatedClaims(t *testing.T) {
+	secret := []byte("s3cret")
+	claims := &authclient.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"}}
+	incoming := func(ctx context.Context) context.Context {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		return metadata.NewIncomingContext(context.Background(), md)
+	}
+
+	signed := incoming(AppendClaimsToOutgoingContext(context.Background(), claims, secret))
+	if got, err := VerifiedClaimsFromIncomingContext(signed, secret); err != nil || got.Subject != "user-1" {
+		t.Fatalf("VerifiedClaimsFromIncomingContext = %+v, %v", got, err)
+	}
+	if _, err := VerifiedClaimsFromIncomingContext(signed, []byte("other")); err == nil {
+		t.Fatal("claims verified with the wrong secret")
+	}
+	if _, err := VerifiedClaimsFromIncomingContext(context.Background(), secret); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("missing claims: err = %v", err)
+	}
+
+	unsigned := incoming(AppendClaimsToOutgoingContext(context.Background(), claims, nil))
+	if got, ok := ClaimsFromIncomingContext(unsigned); !ok || got.Subject != "user-1" {
+		t.Fatalf("ClaimsFromIncomingContext = %+v, %v", got, ok)
+	}
+	if _, err := VerifiedClaimsFromIncomingContext(unsigned, secret); err == nil {
+		t.Fatal("unsigned claims accepted by VerifiedClaimsFromIncomingContext")
+	}
+}
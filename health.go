@@ -0,0 +1,125 @@
+package authclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// AuthServiceHealth records whether the Client last reached auth-service. Any HTTP response,
+// including 4xx, counts as reachable; transport errors and 5xx do not.
+type AuthServiceHealth struct {
+	Reachable   bool      `json:"reachable"`
+	LastSuccess time.Time `json:"last_success,omitempty"`
+	LastFailure time.Time `json:"last_failure,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	// FailingSince is the first failure since the last success; zero while reachable.
+	FailingSince time.Time `json:"failing_since,omitempty"`
+}
+
+// reachability is updated by reachabilityTransport on every Client round trip.
+type reachability struct {
+	mu     sync.Mutex
+	health AuthServiceHealth
+}
+
+func (r *reachability) record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err == nil {
+		r.health.Reachable = true
+		r.health.LastSuccess = time.Now()
+		r.health.FailingSince = time.Time{}
+		return
+	}
+	r.health.Reachable = false
+	r.health.LastFailure = time.Now()
+	if r.health.FailingSince.IsZero() {
+		r.health.FailingSince = r.health.LastFailure
+	}
+	r.health.LastError = err.Error()
+}
+
+// reachabilityTransport records auth-service reachability and reports outages to onError.
+type reachabilityTransport struct {
+	base    http.RoundTripper
+	state   *reachability
+	onError ErrorHook
+}
+
+func (t *reachabilityTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	var failure error
+	switch {
+	case err != nil:
+		failure = newAuthError(KindUpstream, "auth-service: request failed", err)
+	case resp.StatusCode >= 500:
+		failure = &AuthError{Kind: KindUpstream, StatusCode: resp.StatusCode, Message: "auth-service: " + http.StatusText(resp.StatusCode)}
+	}
+	t.state.record(failure)
+	if failure != nil {
+		t.onError.report(req.Context(), req.Method+" "+req.URL.Path, failure)
+	}
+	return resp, err
+}
+
+// Health reports the client's last contact with auth-service.
+func (c *Client) Health() AuthServiceHealth {
+	c.reach.mu.Lock()
+	defer c.reach.mu.Unlock()
+	return c.reach.health
+}
+
+// HealthConfig selects the components HealthHandler reports on. Nil components are omitted.
+type HealthConfig struct {
+	Validator       *Validator
+	APIKeyValidator *APIKeyValidator
+	Client          *Client
+}
+
+// HealthReport is the JSON body served by HealthHandler. It has no circuit-breaker section:
+// the client has no breaker, and AuthService reachability is what it would report.
+type HealthReport struct {
+	Status      string             `json:"status"` // "ok" or "degraded"
+	JWKS        *JWKSHealth        `json:"jwks,omitempty"`
+	APIKeyCache *APIKeyCacheStats  `json:"api_key_cache,omitempty"`
+	AuthService *AuthServiceHealth `json:"auth_service,omitempty"`
+}
+
+// HealthHandler serves the auth subsystem's status as JSON, for mounting on an internal route
+// such as /internal/authz-health. It responds 503 only when no JWKS keys are loaded, since
+// tokens cannot be validated at all then; stale keys or an unreachable auth-service report
+// "degraded" with 200 so a blip does not pull every replica out of rotation.
+func HealthHandler(cfg HealthConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := HealthReport{Status: "ok"}
+		code := http.StatusOK
+		if cfg.Validator != nil {
+			h := cfg.Validator.Health()
+			report.JWKS = &h
+			if !h.Fresh {
+				report.Status = "degraded"
+			}
+			if h.KeyCount == 0 {
+				code = http.StatusServiceUnavailable
+			}
+		}
+		if cfg.APIKeyValidator != nil {
+			stats := cfg.APIKeyValidator.CacheStats()
+			report.APIKeyCache = &stats
+		}
+		if cfg.Client != nil {
+			h := cfg.Client.Health()
+			report.AuthService = &h
+			if !h.Reachable && !h.LastFailure.IsZero() {
+				report.Status = "degraded"
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(report)
+	})
+}
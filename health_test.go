@@ -0,0 +1,65 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthHandler(t *testing.T) {
+	withKeys, _ := newBenchValidator(t)
+	emptyJWKS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"keys":[]}`))
+	}))
+	defer emptyJWKS.Close()
+	noKeys, err := NewValidator(DefaultConfig(emptyJWKS.URL, "https://auth.example.com", "orders"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer noKeys.Stop()
+
+	authService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"tenant not found"}`, http.StatusNotFound)
+	}))
+	defer authService.Close()
+	reachable := NewClient(authService.URL, nil)
+	_, _ = reachable.CheckTenantExists(context.Background(), "acme") // a 4xx still counts as reachable
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	unreachable := NewClient(down.URL, nil)
+	_, _ = unreachable.CheckTenantExists(context.Background(), "acme")
+	if !reachable.Health().Reachable || unreachable.Health().Reachable || unreachable.Health().LastError == "" {
+		t.Fatalf("reachability: %+v, %+v", reachable.Health(), unreachable.Health())
+	}
+
+	tests := []struct {
+		name       string
+		cfg        HealthConfig
+		wantCode   int
+		wantStatus string
+	}{
+		{"nothing configured", HealthConfig{}, http.StatusOK, "ok"},
+		{"keys loaded, auth-service reachable", HealthConfig{Validator: withKeys, Client: reachable}, http.StatusOK, "ok"},
+		{"auth-service never contacted", HealthConfig{Client: NewClient(authService.URL, nil)}, http.StatusOK, "ok"},
+		{"auth-service unreachable", HealthConfig{Validator: withKeys, Client: unreachable}, http.StatusOK, "degraded"},
+		{"no JWKS keys", HealthConfig{Validator: noKeys, Client: reachable}, http.StatusServiceUnavailable, "ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			HealthHandler(tt.cfg).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/internal/authz-health", nil))
+			var report HealthReport
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatal(err)
+			}
+			if rec.Code != tt.wantCode || report.Status != tt.wantStatus {
+				t.Fatalf("got %d %q, want %d %q: %s", rec.Code, report.Status, tt.wantCode, tt.wantStatus, rec.Body)
+			}
+			if (report.AuthService != nil) != (tt.cfg.Client != nil) || (report.JWKS != nil) != (tt.cfg.Validator != nil) {
+				t.Fatalf("report sections do not match config: %s", rec.Body)
+			}
+		})
+	}
+}
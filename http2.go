@@ -0,0 +1,139 @@
+package authclient
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HTTP2Options configures HTTP/2 for calls to auth-service. Multiplexing many small requests
+// (token validation, permission checks) over a few long-lived connections avoids per-request
+// connection churn through the service mesh.
+type HTTP2Options struct {
+	// Cleartext speaks HTTP/2 without TLS (h2c with prior knowledge) to http:// URLs, for
+	// in-cluster traffic where the mesh sidecar terminates TLS. https:// URLs still use
+	// HTTP/2 over TLS. HTTP/1.1 is never used.
+	Cleartext bool
+
+	// MaxConcurrentStreams caps requests in flight on each connection, below whatever
+	// SETTINGS_MAX_CONCURRENT_STREAMS auth-service advertises; further requests wait for a
+	// free stream (or their context). The client always honours the server's limit too,
+	// queueing rather than dialing extra connections. Zero leaves only the server's limit.
+	MaxConcurrentStreams int
+	// MaxConnsPerHost bounds connections to each auth-service host. With
+	// MaxConcurrentStreams set it defaults to 1, so at most MaxConnsPerHost ×
+	// MaxConcurrentStreams requests are in flight per host.
+	MaxConnsPerHost int
+
+	// HealthCheckInterval pings a connection that has received nothing for this long and
+	// closes it if no answer arrives within PingTimeout (default 15s), so requests are not
+	// stranded on a connection the mesh silently dropped. Zero disables health checks.
+	HealthCheckInterval time.Duration
+	PingTimeout         time.Duration
+}
+
+// WithHTTP2 forces HTTP/2 for every call to auth-service. It composes with WithTLSConfig in
+// either order.
+func WithHTTP2(opts HTTP2Options) ClientOption {
+	return func(c *Client) {
+		c.http2 = &opts
+	}
+}
+
+// NewHTTP2Transport returns a transport configured by opts, based on http.DefaultTransport,
+// for http.Clients passed to NewAPIKeyValidator or NewValidator.
+func NewHTTP2Transport(opts HTTP2Options) http.RoundTripper {
+	return opts.transport(nil)
+}
+
+// transport applies opts to base, cloning it when it is an *http.Transport (or nil, meaning
+// http.DefaultTransport).
+func (o HTTP2Options) transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if o.MaxConcurrentStreams > 0 && o.MaxConnsPerHost <= 0 {
+		o.MaxConnsPerHost = 1
+	}
+	if t, ok := base.(*http.Transport); ok {
+		t = t.Clone()
+		var protocols http.Protocols
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(o.Cleartext)
+		t.Protocols = &protocols
+		t.ForceAttemptHTTP2 = true
+		t.MaxConnsPerHost = o.MaxConnsPerHost
+		t.HTTP2 = &http.HTTP2Config{
+			StrictMaxConcurrentRequests: true,
+			SendPingTimeout:             o.HealthCheckInterval,
+			PingTimeout:                 o.PingTimeout,
+		}
+		base = t
+	}
+	if o.MaxConcurrentStreams > 0 {
+		base = &streamLimitTransport{
+			base:  base,
+			limit: o.MaxConnsPerHost * o.MaxConcurrentStreams,
+			hosts: make(map[string]chan struct{}),
+		}
+	}
+	return base
+}
+
+// streamLimitTransport bounds the requests in flight to each host.
+type streamLimitTransport struct {
+	base  http.RoundTripper
+	limit int
+
+	mu    sync.Mutex
+	hosts map[string]chan struct{}
+}
+
+func (t *streamLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	slots := t.slots(req.URL.Host)
+	select {
+	case slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		<-slots
+		return nil, err
+	}
+	// The stream stays open until the body is consumed or closed.
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: func() { <-slots }}
+	return resp, nil
+}
+
+// CloseIdleConnections closes idle connections of the underlying transport.
+func (t *streamLimitTransport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+func (t *streamLimitTransport) slots(host string) chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	slots, ok := t.hosts[host]
+	if !ok {
+		slots = make(chan struct{}, t.limit)
+		t.hosts[host] = slots
+	}
+	return slots
+}
+
+// releaseOnClose calls release once, when the body is first closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
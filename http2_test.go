@@ -0,0 +1,88 @@
+package authclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithHTTP2(t *testing.T) {
+	var proto atomic.Value
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proto.Store(r.Proto)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("h2c", func(t *testing.T) {
+		srv := httptest.NewUnstartedServer(handler)
+		srv.Config.Protocols = new(http.Protocols)
+		srv.Config.Protocols.SetHTTP1(true)
+		srv.Config.Protocols.SetUnencryptedHTTP2(true)
+		srv.Start()
+		defer srv.Close()
+
+		c := NewClient(srv.URL, nil, WithHTTP2(HTTP2Options{Cleartext: true}))
+		if _, err := c.CheckTenantExists(context.Background(), "acme"); err != nil {
+			t.Fatal(err)
+		}
+		if got := proto.Load(); got != "HTTP/2.0" {
+			t.Fatalf("proto = %v, want HTTP/2.0", got)
+		}
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		srv := httptest.NewUnstartedServer(handler)
+		srv.EnableHTTP2 = true
+		srv.StartTLS()
+		defer srv.Close()
+
+		tlsConfig := &tls.Config{RootCAs: srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs}
+		c := NewClient(srv.URL, nil, WithHTTP2(HTTP2Options{}), WithTLSConfig(tlsConfig))
+		if _, err := c.CheckTenantExists(context.Background(), "acme"); err != nil {
+			t.Fatal(err)
+		}
+		if got := proto.Load(); got != "HTTP/2.0" {
+			t.Fatalf("proto = %v, want HTTP/2.0", got)
+		}
+	})
+}
+
+func TestHTTP2MaxConcurrentStreams(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+	}))
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil, WithHTTP2(HTTP2Options{Cleartext: true, MaxConcurrentStreams: 2}))
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Go(func() {
+			_, _ = c.CheckTenantExists(context.Background(), "acme")
+		})
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Fatalf("peak in-flight requests = %d, want 2", got)
+	}
+}
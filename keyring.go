@@ -0,0 +1,104 @@
+package authclient
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSealedValue is returned by Keyring.Open for values that were tampered with, bound
+// to a different name, or sealed with a key no longer in the keyring.
+var ErrInvalidSealedValue = errors.New("keyring: invalid sealed value")
+
+// KeyringKey is one AES-256 key of a Keyring. ID is stored alongside each sealed value so the
+// right key is chosen on Open; it must be non-empty and must not contain ".".
+type KeyringKey struct {
+	ID     string
+	Secret []byte // 32 bytes
+}
+
+// Keyring seals small values (refresh tokens, OAuth state) with AES-256-GCM for storage in
+// browser cookies. The first key encrypts; every key decrypts, so keys can be rotated by
+// prepending a new one and dropping the oldest once its values have expired.
+type Keyring struct {
+	primary string
+	aeads   map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring whose first key is the primary (encrypting) key.
+func NewKeyring(keys ...KeyringKey) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("keyring: at least one key required")
+	}
+	k := &Keyring{primary: keys[0].ID, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for _, key := range keys {
+		if key.ID == "" || strings.Contains(key.ID, ".") {
+			return nil, fmt.Errorf("keyring: invalid key ID %q", key.ID)
+		}
+		if _, dup := k.aeads[key.ID]; dup {
+			return nil, fmt.Errorf("keyring: duplicate key ID %q", key.ID)
+		}
+		if len(key.Secret) != 32 {
+			return nil, fmt.Errorf("keyring: key %q must be 32 bytes, got %d", key.ID, len(key.Secret))
+		}
+		block, err := aes.NewCipher(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("keyring: key %q: %w", key.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("keyring: key %q: %w", key.ID, err)
+		}
+		k.aeads[key.ID] = aead
+	}
+	return k, nil
+}
+
+// Seal encrypts plaintext with the primary key as "<key id>.<base64url(nonce|ciphertext)>".
+// name (e.g. the cookie name) is authenticated, so a value cannot be replayed under another name.
+func (k *Keyring) Seal(name string, plaintext []byte) (string, error) {
+	aead := k.aeads[k.primary]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("keyring: nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, sealedValueAAD(name, k.primary))
+	return k.primary + "." + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a value produced by Seal under the same name, using whichever key sealed it.
+func (k *Keyring) Open(name, value string) ([]byte, error) {
+	id, payload, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, ErrInvalidSealedValue
+	}
+	aead, ok := k.aeads[id]
+	if !ok {
+		return nil, ErrInvalidSealedValue
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrInvalidSealedValue
+	}
+	n := aead.NonceSize()
+	plaintext, err := aead.Open(nil, sealed[:n], sealed[n:], sealedValueAAD(name, id))
+	if err != nil {
+		return nil, ErrInvalidSealedValue
+	}
+	return plaintext, nil
+}
+
+// NeedsRotation reports whether value was sealed with a non-primary key and should be
+// re-sealed (e.g. by writing the cookie again).
+func (k *Keyring) NeedsRotation(value string) bool {
+	id, _, _ := strings.Cut(value, ".")
+	return id != k.primary
+}
+
+func sealedValueAAD(name, keyID string) []byte {
+	return []byte(name + "\x00" + keyID)
+}
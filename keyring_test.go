@@ -0,0 +1,41 @@
+package authclient
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	oldKey := KeyringKey{ID: "2025", Secret: bytes.Repeat([]byte{1}, 32)}
+	newKey := KeyringKey{ID: "2026", Secret: bytes.Repeat([]byte{2}, 32)}
+
+	before, err := NewKeyring(oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := before.Seal("refresh_token", []byte("r1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := NewKeyring(newKey, oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := after.Open("refresh_token", sealed)
+	if err != nil || string(got) != "r1" {
+		t.Fatalf("Open() with rotated keyring = %q, %v", got, err)
+	}
+	if !after.NeedsRotation(sealed) {
+		t.Fatal("value sealed with the old key should need rotation")
+	}
+	if _, err := after.Open("access_token", sealed); !errors.Is(err, ErrInvalidSealedValue) {
+		t.Fatalf("Open() under another name = %v, want ErrInvalidSealedValue", err)
+	}
+
+	retired, _ := NewKeyring(newKey)
+	if _, err := retired.Open("refresh_token", sealed); !errors.Is(err, ErrInvalidSealedValue) {
+		t.Fatalf("Open() after dropping the old key = %v, want ErrInvalidSealedValue", err)
+	}
+}
@@ -0,0 +1,107 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Runnable is a component with background work: Validator, ValidatorRegistry,
+// TokenManager and RevocationStream. Run does the work until ctx is cancelled or Close is
+// called, then releases the component; it returns ctx.Err(), nil after Close, or the error
+// that stopped it. Close stops a running Run and is safe to call more than once.
+type Runnable interface {
+	Run(ctx context.Context) error
+	Close() error
+}
+
+// Runtime runs a set of components together, so a service can start every background
+// refresher with one call and tie it to its own errgroup or signal handling:
+//
+//	rt := authclient.NewRuntime(validator, tokens, stream)
+//	g.Go(func() error { return rt.Run(ctx) })
+//
+// When one component fails, the others are stopped and Run returns that error.
+type Runtime struct {
+	mu         sync.Mutex
+	components []Runnable
+}
+
+// NewRuntime creates a runtime managing components.
+func NewRuntime(components ...Runnable) *Runtime {
+	return &Runtime{components: components}
+}
+
+// Add registers another component. Components added while Run is in progress are not
+// started by it.
+func (r *Runtime) Add(c Runnable) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.components = append(r.components, c)
+}
+
+// Run runs every component until ctx is cancelled or one of them fails, then closes them
+// all. It returns the first component error, or ctx.Err() after a cancellation.
+func (r *Runtime) Run(ctx context.Context) error {
+	r.mu.Lock()
+	components := append([]Runnable(nil), r.components...)
+	r.mu.Unlock()
+
+	g, gctx := errgroup.WithContext(ctx)
+	for _, c := range components {
+		g.Go(func() error {
+			err := c.Run(gctx)
+			if err != nil && gctx.Err() != nil && errors.Is(err, gctx.Err()) {
+				return nil // stopped with the group, not a failure of its own
+			}
+			return err
+		})
+	}
+	err := g.Wait()
+	if closeErr := r.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+// Close closes every component, returning their errors joined.
+func (r *Runtime) Close() error {
+	r.mu.Lock()
+	components := append([]Runnable(nil), r.components...)
+	r.mu.Unlock()
+
+	var errs []error
+	for _, c := range components {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// untilClosed returns a context that is cancelled with ctx or once closed is closed.
+func untilClosed(ctx context.Context, closed <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// runErr is the result of a Run that ended with ctx: nil if it was stopped by Close,
+// otherwise ctx's error.
+func runErr(ctx context.Context, closed <-chan struct{}) error {
+	select {
+	case <-closed:
+		return nil
+	default:
+		return ctx.Err()
+	}
+}
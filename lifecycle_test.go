@@ -0,0 +1,101 @@
+package authclient
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failingRunnable fails its Run after a short delay.
+type failingRunnable struct{ closed atomic.Bool }
+
+func (f *failingRunnable) Run(ctx context.Context) error {
+	time.Sleep(20 * time.Millisecond)
+	return errors.New("component failed")
+}
+
+func (f *failingRunnable) Close() error {
+	f.closed.Store(true)
+	return nil
+}
+
+func TestRuntime(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewSigner(key, "k1", SignerConfig{Issuer: "https://auth.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fetches atomic.Int32
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		signer.JWKSHandler().ServeHTTP(w, r)
+	}))
+	defer jwks.Close()
+
+	config := DefaultConfig(jwks.URL, "https://auth.example.com", "orders")
+	config.RefreshInterval = 5 * time.Millisecond
+	config.ManualRefresh = true
+	v, err := NewValidator(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if n := fetches.Load(); n != 1 {
+		t.Fatalf("ManualRefresh validator fetched %d times before Run, want 1", n)
+	}
+
+	stream := NewRevocationStream(NewClient("http://auth-service.invalid", nil), RevocationStreamConfig{MinBackoff: time.Hour})
+	rt := NewRuntime(v, stream)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- rt.Run(ctx) }()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run after cancel = %v, want context.Canceled", err)
+	}
+	if n := fetches.Load(); n < 3 {
+		t.Fatalf("JWKS fetched %d times while running, want periodic refresh", n)
+	}
+	time.Sleep(10 * time.Millisecond) // a cancelled fetch may still reach the server
+	settled := fetches.Load()
+	time.Sleep(30 * time.Millisecond)
+	if n := fetches.Load(); n != settled {
+		t.Fatalf("validator kept refreshing after Run returned (%d -> %d)", settled, n)
+	}
+
+	// One failing component stops the others and is reported.
+	tm := NewTokenManager(nil, nil, TokenManagerConfig{RefreshFraction: 0.5, ManualRefresh: true})
+	failing := &failingRunnable{}
+	err = NewRuntime(tm, failing).Run(context.Background())
+	if err == nil || err.Error() != "component failed" {
+		t.Fatalf("Run = %v, want the component's error", err)
+	}
+	if !failing.closed.Load() {
+		t.Fatal("components were not closed")
+	}
+	select {
+	case <-tm.stop:
+	default:
+		t.Fatal("token manager still running")
+	}
+
+	// Close ends a Run that is not cancelled, with a nil error.
+	stream = NewRevocationStream(NewClient("http://auth-service.invalid", nil), RevocationStreamConfig{MinBackoff: time.Hour})
+	go func() { done <- stream.Run(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+	_ = stream.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("Run after Close = %v, want nil", err)
+	}
+}
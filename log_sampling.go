@@ -0,0 +1,109 @@
+package authclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SamplingConfig bounds how often repeated log entries are written. Within each Interval the
+// first First entries per key are logged, then one in every Thereafter; when the next
+// interval starts, a summary with the number of suppressed entries is logged.
+type SamplingConfig struct {
+	Interval   time.Duration // defaults to 1 minute
+	First      int           // defaults to 10
+	Thereafter int           // defaults to 100; 0 drops everything after First
+}
+
+// DefaultSamplingConfig is used by AuthMiddleware for authentication-failure logs.
+var DefaultSamplingConfig = SamplingConfig{Interval: time.Minute, First: 10, Thereafter: 100}
+
+// NewSampledLogger wraps base so that Debug, Info and Warn entries are sampled per key, where
+// the key is the message plus the value of its "reason" field (if any). Error entries are
+// never sampled. Counters are kept per key, so keys should come from a small set (constant
+// messages, ErrorKind reasons). Use it to keep credential-stuffing attacks from flooding the
+// log pipeline.
+func NewSampledLogger(base Logger, cfg SamplingConfig) Logger {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultSamplingConfig.Interval
+	}
+	if cfg.First <= 0 {
+		cfg.First = DefaultSamplingConfig.First
+	}
+	if cfg.Thereafter < 0 {
+		cfg.Thereafter = 0
+	}
+	return &sampledLogger{base: orNop(base), cfg: cfg, counters: make(map[string]*sampleCounter)}
+}
+
+type sampledLogger struct {
+	base Logger
+	cfg  SamplingConfig
+
+	mu       sync.Mutex
+	counters map[string]*sampleCounter
+}
+
+type sampleCounter struct {
+	windowStart time.Time
+	seen        int
+	suppressed  int
+}
+
+func (l *sampledLogger) Debug(msg string, kv ...any) {
+	if l.allow(msg, kv) {
+		l.base.Debug(msg, kv...)
+	}
+}
+
+func (l *sampledLogger) Info(msg string, kv ...any) {
+	if l.allow(msg, kv) {
+		l.base.Info(msg, kv...)
+	}
+}
+
+func (l *sampledLogger) Warn(msg string, kv ...any) {
+	if l.allow(msg, kv) {
+		l.base.Warn(msg, kv...)
+	}
+}
+
+func (l *sampledLogger) Error(msg string, kv ...any) { l.base.Error(msg, kv...) }
+
+// allow reports whether an entry should be written, logging the previous window's summary
+// when a new window starts.
+func (l *sampledLogger) allow(msg string, kv []any) bool {
+	var reason string
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i] == "reason" {
+			reason = fmt.Sprint(kv[i+1])
+			break
+		}
+	}
+	key := msg + "\x00" + reason
+
+	now := time.Now()
+	l.mu.Lock()
+	c, ok := l.counters[key]
+	if !ok {
+		c = &sampleCounter{windowStart: now}
+		l.counters[key] = c
+	}
+	var suppressed int
+	if now.Sub(c.windowStart) >= l.cfg.Interval {
+		suppressed = c.suppressed
+		*c = sampleCounter{windowStart: now}
+	}
+	c.seen++
+	allowed := c.seen <= l.cfg.First ||
+		(l.cfg.Thereafter > 0 && (c.seen-l.cfg.First)%l.cfg.Thereafter == 0)
+	if !allowed {
+		c.suppressed++
+	}
+	l.mu.Unlock()
+
+	if suppressed > 0 {
+		l.base.Warn("log entries suppressed by sampling", "message", msg, "reason", reason, "suppressed", suppressed, "interval", l.cfg.Interval)
+	}
+	return allowed
+}
@@ -0,0 +1,28 @@
+package authclient
+
+import (
+	"testing"
+	"time"
+)
+
+type countingLogger struct {
+	nopLogger
+	warns []string
+}
+
+func (l *countingLogger) Warn(msg string, kv ...any) { l.warns = append(l.warns, msg) }
+
+func TestSampledLogger(t *testing.T) {
+	base := &countingLogger{}
+	l := NewSampledLogger(base, SamplingConfig{Interval: time.Hour, First: 2, Thereafter: 5})
+
+	for range 12 {
+		l.Warn("authentication failed", "reason", KindTokenExpired)
+	}
+	l.Warn("authentication failed", "reason", KindSignatureInvalid)
+
+	// 2 first + the 7th and 12th expired entries + the first signature entry.
+	if len(base.warns) != 5 {
+		t.Fatalf("logged %d entries, want 5", len(base.warns))
+	}
+}
@@ -0,0 +1,59 @@
+package authclient
+
+import (
+	"log/slog"
+
+	"go.uber.org/zap"
+)
+
+// Logger is the structured logger used throughout this package. Fields are alternating
+// key/value pairs, e.g. logger.Warn("refresh failed", "error", err, "status", 502).
+// *slog.Logger satisfies it directly; wrap a *zap.Logger with ZapLogger.
+type Logger interface {
+	Debug(msg string, keysAndValues ...any)
+	Info(msg string, keysAndValues ...any)
+	Warn(msg string, keysAndValues ...any)
+	Error(msg string, keysAndValues ...any)
+}
+
+// NopLogger returns a Logger that discards everything.
+func NopLogger() Logger { return nopLogger{} }
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+// ZapLogger adapts a *zap.Logger to Logger. A nil logger yields NopLogger.
+func ZapLogger(logger *zap.Logger) Logger {
+	if logger == nil {
+		return NopLogger()
+	}
+	return zapLogger{logger.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+}
+
+type zapLogger struct{ s *zap.SugaredLogger }
+
+func (l zapLogger) Debug(msg string, kv ...any) { l.s.Debugw(msg, kv...) }
+func (l zapLogger) Info(msg string, kv ...any)  { l.s.Infow(msg, kv...) }
+func (l zapLogger) Warn(msg string, kv ...any)  { l.s.Warnw(msg, kv...) }
+func (l zapLogger) Error(msg string, kv ...any) { l.s.Errorw(msg, kv...) }
+
+// slogLogger names a *slog.Logger the way zap's Named does, so both backends emit the same
+// "logger" attribute (e.g. "auth-service-client"). A nil logger uses slog.Default().
+func slogLogger(logger *slog.Logger, name string) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return logger.With(slog.String("logger", name))
+}
+
+// orNop returns logger, or NopLogger when it is nil.
+func orNop(logger Logger) Logger {
+	if logger == nil {
+		return NopLogger()
+	}
+	return logger
+}
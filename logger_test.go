@@ -0,0 +1,130 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestClientSlogAttributes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid_credentials"}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	client := NewClient(srv.URL, nil, WithSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	if _, err := client.Login(context.Background(), LoginRequest{Email: "ada@example.com"}); err == nil {
+		t.Fatal("expected login to fail")
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log output %q: %v", buf.String(), err)
+	}
+	if entry["logger"] != "auth-service-client" || entry["status"] != float64(401) || entry["email"] != (redactor{}).email("ada@example.com") {
+		t.Fatalf("log entry = %v", entry)
+	}
+}
+
+func TestZapLogger(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	logger := ZapLogger(zap.New(core))
+	logger.Debug("debug", "n", 1)
+	logger.Info("info", "n", 2)
+	logger.Warn("warn", "n", 3)
+	logger.Error("error", "n", 4)
+
+	entries := logs.AllUntimed()
+	wantLevels := []zapcore.Level{zap.DebugLevel, zap.InfoLevel, zap.WarnLevel, zap.ErrorLevel}
+	if len(entries) != len(wantLevels) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(wantLevels))
+	}
+	for i, e := range entries {
+		if e.Level != wantLevels[i] || e.ContextMap()["n"] != int64(i+1) {
+			t.Fatalf("entry %d = %s %q %v, want level %s and n=%d", i, e.Level, e.Message, e.ContextMap(), wantLevels[i], i+1)
+		}
+	}
+
+	if _, ok := ZapLogger(nil).(nopLogger); !ok {
+		t.Fatal("ZapLogger(nil) should be NopLogger")
+	}
+}
+
+func TestNopLogger(t *testing.T) {
+	logger := NopLogger()
+	logger.Debug("debug", "k", "v")
+	logger.Info("info", "k", "v")
+	logger.Warn("warn", "k", "v")
+	logger.Error("error", "k", "v")
+
+	if _, ok := orNop(nil).(nopLogger); !ok {
+		t.Fatal("orNop(nil) should be NopLogger")
+	}
+	custom := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if got := orNop(custom); got != Logger(custom) {
+		t.Fatalf("orNop(logger) = %v, want the logger itself", got)
+	}
+}
+
+func TestNewClientZapShim(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid_credentials"}`))
+	}))
+	defer srv.Close()
+
+	core, logs := observer.New(zap.DebugLevel)
+	client := NewClient(srv.URL, zap.New(core))
+	if _, err := client.Login(context.Background(), LoginRequest{Email: "ada@example.com"}); err == nil {
+		t.Fatal("expected login to fail")
+	}
+	if logs.Len() == 0 || logs.All()[0].LoggerName != "auth-service-client" {
+		t.Fatalf("entries = %v, want logs named auth-service-client", logs.All())
+	}
+
+	// New logs nothing without a logger option.
+	if _, ok := New(srv.URL).logger.(nopLogger); !ok {
+		t.Fatal("New without a logger option should not log")
+	}
+}
+
+func TestAPIKeyLoggerOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	logWith := func(opt func(*zap.Logger) APIKeyValidatorOption) []observer.LoggedEntry {
+		core, logs := observer.New(zap.DebugLevel)
+		v := NewAPIKeyValidator(srv.URL, nil, opt(zap.New(core)))
+		if _, err := v.ValidateAPIKeyFull(context.Background(), "some-key"); err == nil {
+			t.Fatal("expected validation to fail")
+		}
+		return logs.AllUntimed()
+	}
+	deprecated := logWith(WithLogger)
+	canonical := logWith(func(l *zap.Logger) APIKeyValidatorOption {
+		return WithAPIKeyLogger(ZapLogger(l.Named("api-key-validator")))
+	})
+
+	if len(deprecated) == 0 || len(deprecated) != len(canonical) {
+		t.Fatalf("WithLogger logged %d entries, WithAPIKeyLogger %d; want the same, non-zero", len(deprecated), len(canonical))
+	}
+	for i := range deprecated {
+		d, c := deprecated[i], canonical[i]
+		if d.Message != c.Message || d.Level != c.Level || d.LoggerName != c.LoggerName || d.ContextMap()["key_fingerprint"] != c.ContextMap()["key_fingerprint"] {
+			t.Fatalf("entry %d: WithLogger %s %q %v, WithAPIKeyLogger %s %q %v", i, d.LoggerName, d.Message, d.ContextMap(), c.LoggerName, c.Message, c.ContextMap())
+		}
+	}
+}
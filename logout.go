@@ -0,0 +1,85 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// LogoutResult reports what a Logout or LogoutAll call revoked. Counts are zero when
+// auth-service does not report them (a 204 response).
+type LogoutResult struct {
+	UserID          string `json:"user_id,omitempty"`
+	SessionID       string `json:"session_id,omitempty"` // set by Logout
+	SessionsRevoked int    `json:"sessions_revoked"`
+	TokensRevoked   int    `json:"tokens_revoked"` // refresh tokens invalidated
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modelled above; see DecodeMode
+}
+
+// Logout ends one session: its refresh tokens stop working at once, and access tokens
+// carrying its sid are revoked for services consuming revocation events. An empty
+// sessionID ends the session accessToken belongs to; ending another of the user's sessions
+// (e.g. from a "your devices" page) is allowed for the user's own sessions only, unless the
+// token carries admin permissions.
+func (c *Client) Logout(ctx context.Context, sessionID, accessToken string) (*LogoutResult, error) {
+	return c.logout(ctx, "/api/v1/auth/logout", "logout", map[string]string{"session_id": sessionID}, accessToken)
+}
+
+// LogoutAll revokes every session and refresh token of userID across all devices ("sign
+// out everywhere"). An empty userID signs out the user owning accessToken; signing out
+// another user requires an admin token. Pair it with TokenManager.Destroy to purge local
+// copies.
+func (c *Client) LogoutAll(ctx context.Context, userID, accessToken string) (*LogoutResult, error) {
+	return c.logout(ctx, "/api/v1/auth/logout-all", "logout all", map[string]string{"user_id": userID}, accessToken)
+}
+
+func (c *Client) logout(ctx context.Context, path, op string, req map[string]string, accessToken string) (*LogoutResult, error) {
+	if accessToken == "" {
+		return nil, newAuthError(KindTokenMissing, "auth-service: access token required for "+op, nil)
+	}
+	for k, v := range req {
+		if v == "" {
+			delete(req, k)
+		}
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+accessToken)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return nil, c.responseError(op, resp.StatusCode, respBody)
+	}
+
+	result := &LogoutResult{UserID: req["user_id"], SessionID: req["session_id"]}
+	if len(bytes.TrimSpace(respBody)) > 0 {
+		if err := c.decode(respBody, result); err != nil {
+			return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLogout(t *testing.T) {
+	var (
+		gotPath string
+		gotAuth string
+		gotBody map[string]string
+		status  int
+		body    string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth, gotBody = r.URL.Path, r.Header.Get("Authorization"), nil
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	c := New(srv.URL)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		call     func() (*LogoutResult, error)
+		status   int
+		body     string
+		wantPath string
+		wantBody map[string]string
+		want     LogoutResult
+		wantKind ErrorKind
+	}{
+		{
+			name:     "current session, 204",
+			call:     func() (*LogoutResult, error) { return c.Logout(ctx, "", "at-1") },
+			status:   http.StatusNoContent,
+			wantPath: "/api/v1/auth/logout",
+			wantBody: map[string]string{},
+		},
+		{
+			name:     "named session, JSON result",
+			call:     func() (*LogoutResult, error) { return c.Logout(ctx, "sess-2", "at-1") },
+			status:   http.StatusOK,
+			body:     `{"user_id":"u1","session_id":"sess-2","sessions_revoked":1,"tokens_revoked":2}`,
+			wantPath: "/api/v1/auth/logout",
+			wantBody: map[string]string{"session_id": "sess-2"},
+			want:     LogoutResult{UserID: "u1", SessionID: "sess-2", SessionsRevoked: 1, TokensRevoked: 2},
+		},
+		{
+			name:     "own user everywhere, 204",
+			call:     func() (*LogoutResult, error) { return c.LogoutAll(ctx, "", "at-1") },
+			status:   http.StatusNoContent,
+			wantPath: "/api/v1/auth/logout-all",
+			wantBody: map[string]string{},
+		},
+		{
+			name:     "other user everywhere, JSON result",
+			call:     func() (*LogoutResult, error) { return c.LogoutAll(ctx, "u2", "at-1") },
+			status:   http.StatusOK,
+			body:     `{"sessions_revoked":3,"tokens_revoked":4}`,
+			wantPath: "/api/v1/auth/logout-all",
+			wantBody: map[string]string{"user_id": "u2"},
+			want:     LogoutResult{UserID: "u2", SessionsRevoked: 3, TokensRevoked: 4},
+		},
+		{
+			name:     "token rejected",
+			call:     func() (*LogoutResult, error) { return c.Logout(ctx, "", "at-1") },
+			status:   http.StatusUnauthorized,
+			body:     `{"error":"invalid_token"}`,
+			wantPath: "/api/v1/auth/logout",
+			wantBody: map[string]string{},
+			wantKind: KindInvalidCredentials,
+		},
+		{
+			name:     "auth-service error",
+			call:     func() (*LogoutResult, error) { return c.LogoutAll(ctx, "u2", "at-1") },
+			status:   http.StatusServiceUnavailable,
+			body:     `{"error":"unavailable"}`,
+			wantPath: "/api/v1/auth/logout-all",
+			wantBody: map[string]string{"user_id": "u2"},
+			wantKind: KindUpstream,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body = tt.status, tt.body
+			res, err := tt.call()
+			if gotPath != tt.wantPath || gotAuth != "Bearer at-1" {
+				t.Fatalf("request = %s with Authorization %q, want %s with Bearer at-1", gotPath, gotAuth, tt.wantPath)
+			}
+			if len(gotBody) != len(tt.wantBody) {
+				t.Fatalf("request body = %v, want %v", gotBody, tt.wantBody)
+			}
+			for k, v := range tt.wantBody {
+				if gotBody[k] != v {
+					t.Fatalf("request body = %v, want %v", gotBody, tt.wantBody)
+				}
+			}
+			if tt.wantKind != "" {
+				if KindOf(err) != tt.wantKind {
+					t.Fatalf("err = %v, want kind %s", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.UserID != tt.want.UserID || res.SessionID != tt.want.SessionID ||
+				res.SessionsRevoked != tt.want.SessionsRevoked || res.TokensRevoked != tt.want.TokensRevoked {
+				t.Fatalf("result = %+v, want %+v", res, tt.want)
+			}
+		})
+	}
+
+	if _, err := c.Logout(ctx, "sess-2", ""); KindOf(err) != KindTokenMissing {
+		t.Fatalf("Logout without a token: err = %v, want %s", err, KindTokenMissing)
+	}
+}
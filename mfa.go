@@ -0,0 +1,160 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// MFA methods auth-service offers in MFAChallengeResponse.Methods and MFAStatus.Methods.
+const (
+	MFAMethodTOTP         = "totp"
+	MFAMethodRecoveryCode = "recovery_code" // one of the codes handed out by ConfirmTOTP
+)
+
+// MFAChallengeResponse is what auth-service answers a correct password with when the account
+// has MFA enabled: no tokens yet, but a challenge to complete with VerifyMFAChallenge. Login
+// returns it as the Cause of a KindMFARequired AuthError; get it with AsMFAChallenge.
+type MFAChallengeResponse struct {
+	ChallengeID string   `json:"challenge_id"`
+	Methods     []string `json:"methods"`    // factors the user may answer with, e.g. MFAMethodTOTP
+	ExpiresIn   int      `json:"expires_in"` // seconds until the challenge lapses and the password must be entered again
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modelled above; see DecodeMode
+
+	apiErr *Error // set when auth-service answered with an error document
+}
+
+func (r *MFAChallengeResponse) Error() string {
+	return "mfa required: " + strings.Join(r.Methods, ", ")
+}
+
+func (r *MFAChallengeResponse) Unwrap() error {
+	if r.apiErr == nil {
+		return nil
+	}
+	return r.apiErr
+}
+
+// AsMFAChallenge returns the MFA challenge carried by err, if any.
+func AsMFAChallenge(err error) (*MFAChallengeResponse, bool) {
+	var challenge *MFAChallengeResponse
+	ok := errors.As(err, &challenge)
+	return challenge, ok
+}
+
+// parseMFAChallenge reads a challenge from an auth-service login body, or returns nil when
+// the body carries tokens or no challenge. auth-service marks challenges with mfa_required
+// or, in older releases, only with challenge_id.
+func (c *Client) parseMFAChallenge(body []byte) *MFAChallengeResponse {
+	var probe struct {
+		MFARequired bool   `json:"mfa_required"`
+		ChallengeID string `json:"challenge_id"`
+		AccessToken string `json:"access_token"`
+	}
+	if json.Unmarshal(body, &probe) != nil || probe.AccessToken != "" || (!probe.MFARequired && probe.ChallengeID == "") {
+		return nil
+	}
+	challenge := &MFAChallengeResponse{}
+	if err := c.decode(body, challenge); err != nil {
+		// Strict decoding must not turn a challenge into an unreadable response.
+		_ = json.Unmarshal(body, challenge)
+	}
+	return challenge
+}
+
+// mfaRequired builds the error Login returns for challenge.
+func mfaRequired(status int, challenge *MFAChallengeResponse) *AuthError {
+	return &AuthError{
+		Kind:       KindMFARequired,
+		StatusCode: status,
+		Code:       "mfa_required",
+		Message:    "auth-service: login requires a second factor",
+		Cause:      challenge,
+	}
+}
+
+// TOTPEnrollment is a pending authenticator-app enrollment. Show URI as a QR code (or Secret
+// for manual entry), then pass the first code the app displays to ConfirmTOTP.
+type TOTPEnrollment struct {
+	Secret    string `json:"secret"`      // base32 shared secret
+	URI       string `json:"otpauth_uri"` // otpauth://totp/... provisioning URI
+	ExpiresIn int    `json:"expires_in"`  // seconds left to confirm the enrollment
+}
+
+// MFAStatus reports the second factors enabled on an account.
+type MFAStatus struct {
+	Enabled bool     `json:"enabled"`
+	Methods []string `json:"methods"`
+	// RecoveryCodes are single-use codes for a lost device. auth-service returns them once,
+	// from ConfirmTOTP; show them to the user and do not store them.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// EnrollTOTP starts enrolling an authenticator app for the user owning accessToken. MFA is
+// not enforced until ConfirmTOTP succeeds; enrolling again replaces a pending enrollment.
+func (c *Client) EnrollTOTP(ctx context.Context, accessToken string) (*TOTPEnrollment, error) {
+	if accessToken == "" {
+		return nil, newAuthError(KindTokenMissing, "auth-service: access token required to enroll totp", nil)
+	}
+	var enrollment TOTPEnrollment
+	if err := c.postWithToken(ctx, "/api/v1/auth/mfa/totp/enroll", "enroll totp", nil, accessToken, &enrollment); err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+// ConfirmTOTP completes EnrollTOTP with a code from the authenticator app, enabling MFA on
+// the account. A wrong code fails with KindInvalidCredentials and leaves the enrollment
+// pending.
+func (c *Client) ConfirmTOTP(ctx context.Context, code, accessToken string) (*MFAStatus, error) {
+	if code == "" {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: code required to confirm totp", nil)
+	}
+	if accessToken == "" {
+		return nil, newAuthError(KindTokenMissing, "auth-service: access token required to confirm totp", nil)
+	}
+	var status MFAStatus
+	if err := c.postWithToken(ctx, "/api/v1/auth/mfa/totp/confirm", "confirm totp", map[string]string{"code": code}, accessToken, &status); err != nil {
+		return nil, err
+	}
+	c.logger.Info("auth-service: mfa enabled", "methods", status.Methods)
+	return &status, nil
+}
+
+// VerifyMFAChallenge answers the challenge returned by Login with a TOTP or recovery code,
+// issuing the session Login withheld. A wrong code fails with KindInvalidCredentials and
+// may be retried until auth-service's attempt limit; an expired challenge fails with
+// KindInvalidRequest and the user must sign in again.
+func (c *Client) VerifyMFAChallenge(ctx context.Context, challengeID, code string) (*AuthResponse, error) {
+	if challengeID == "" || code == "" {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: challenge ID and code required to verify mfa challenge", nil)
+	}
+	req := map[string]string{"challenge_id": challengeID, "code": code}
+	var authResp AuthResponse
+	if err := c.postJSON(ctx, "/api/v1/auth/mfa/verify", "verify mfa challenge", req, &authResp); err != nil {
+		// A 404 here means an unknown or lapsed challenge, not a missing resource.
+		if ae, ok := err.(*AuthError); ok && ae.Kind == KindNotFound {
+			ae.Kind = KindInvalidRequest
+		}
+		return nil, err
+	}
+	return &authResp, nil
+}
+
+// DisableMFA turns MFA off for the user owning accessToken. auth-service requires a current
+// TOTP or recovery code, so a stolen access token alone cannot remove the second factor.
+func (c *Client) DisableMFA(ctx context.Context, code, accessToken string) error {
+	if code == "" {
+		return newAuthError(KindInvalidRequest, "auth-service: code required to disable mfa", nil)
+	}
+	if accessToken == "" {
+		return newAuthError(KindTokenMissing, "auth-service: access token required to disable mfa", nil)
+	}
+	if err := c.postWithToken(ctx, "/api/v1/auth/mfa/disable", "disable mfa", map[string]string{"code": code}, accessToken, nil); err != nil {
+		return err
+	}
+	c.logger.Info("auth-service: mfa disabled")
+	return nil
+}
@@ -0,0 +1,71 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLoginWithMFA(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/api/v1/auth/login":
+			_, _ = w.Write([]byte(`{"mfa_required":true,"challenge_id":"ch-1","methods":["totp","recovery_code"],"expires_in":300}`))
+		case "/api/v1/auth/mfa/verify":
+			switch {
+			case body["challenge_id"] != "ch-1":
+				http.Error(w, `{"error":"challenge not found"}`, http.StatusNotFound)
+			case body["code"] != "123456":
+				http.Error(w, `{"error":"invalid code","error_code":"invalid_mfa_code"}`, http.StatusUnauthorized)
+			default:
+				_, _ = w.Write([]byte(`{"access_token":"at","refresh_token":"rt","expires_in":900}`))
+			}
+		case "/api/v1/auth/mfa/totp/enroll":
+			_, _ = w.Write([]byte(`{"secret":"JBSWY3DPEHPK3PXP","otpauth_uri":"otpauth://totp/acme:ada?secret=JBSWY3DPEHPK3PXP"}`))
+		case "/api/v1/auth/mfa/totp/confirm":
+			_, _ = w.Write([]byte(`{"enabled":true,"methods":["totp"],"recovery_codes":["a1b2","c3d4"]}`))
+		case "/api/v1/auth/mfa/disable":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil, WithDecodeMode(DecodeStrict))
+	ctx := context.Background()
+	_, err := c.Login(ctx, LoginRequest{Email: "ada@acme.test", Password: "pw"})
+	challenge, ok := AsMFAChallenge(err)
+	if !errors.Is(err, ErrMFARequired) || !ok || challenge.ChallengeID != "ch-1" || len(challenge.Methods) != 2 || challenge.ExpiresIn != 300 {
+		t.Fatalf("Login: err = %v, challenge = %+v", err, challenge)
+	}
+
+	if _, err := c.VerifyMFAChallenge(ctx, "ch-1", "000000"); KindOf(err) != KindInvalidCredentials {
+		t.Fatalf("wrong code: kind %s", KindOf(err))
+	}
+	if _, err := c.VerifyMFAChallenge(ctx, "ch-0", "123456"); KindOf(err) != KindInvalidRequest {
+		t.Fatalf("lapsed challenge: kind %s", KindOf(err))
+	}
+	resp, err := c.VerifyMFAChallenge(ctx, "ch-1", "123456")
+	if err != nil || resp.AccessToken != "at" {
+		t.Fatalf("VerifyMFAChallenge = %+v, %v", resp, err)
+	}
+
+	enrollment, err := c.EnrollTOTP(ctx, "at")
+	if err != nil || enrollment.Secret != "JBSWY3DPEHPK3PXP" {
+		t.Fatalf("EnrollTOTP = %+v, %v", enrollment, err)
+	}
+	status, err := c.ConfirmTOTP(ctx, "123456", "at")
+	if err != nil || !status.Enabled || len(status.RecoveryCodes) != 2 {
+		t.Fatalf("ConfirmTOTP = %+v, %v", status, err)
+	}
+	if err := c.DisableMFA(ctx, "123456", "at"); err != nil {
+		t.Fatalf("DisableMFA: %v", err)
+	}
+	if err := c.DisableMFA(ctx, "123456", ""); KindOf(err) != KindTokenMissing {
+		t.Fatalf("DisableMFA without token: %v", err)
+	}
+}
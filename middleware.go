@@ -6,6 +6,10 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
 )
 
 type contextKey string
@@ -16,43 +20,162 @@ const claimsContextKey contextKey = "auth_claims"
 type AuthMiddleware struct {
 	validator       *Validator
 	apiKeyValidator *APIKeyValidator
+	allowedServices map[string]struct{}
+	audit           AuditSink
+	logger          Logger // sampled; see WithMiddlewareLogger
+	sampling        SamplingConfig
+
+	expiryGrace      time.Duration // see WithExpiryGrace
+	graceParser      *jwt.Parser   // validator parser with expiryGrace leeway
+	onExpiryGrace    func(*http.Request, time.Duration)
+	expiryGraceCount atomic.Uint64
+
+	breakGlass *breakGlass        // see WithBreakGlass
+	registry   *ValidatorRegistry // see WithValidatorRegistry
+
+	tenantStatus TenantStatusChecker   // see WithTenantStatus
+	blocked      *BlockedRequestConfig // see WithBlockedRequests
+}
+
+// AuthMiddlewareOption configures an AuthMiddleware.
+type AuthMiddlewareOption func(*AuthMiddleware)
+
+// WithAllowedServices restricts service-bound API keys to the given service names (normally
+// just the host service, e.g. "ordering-service"), so a key minted for billing cannot call the
+// orders API. Keys without a service binding (tenant/partner keys) are unaffected.
+func WithAllowedServices(services ...string) AuthMiddlewareOption {
+	return func(a *AuthMiddleware) {
+		if a.allowedServices == nil {
+			a.allowedServices = make(map[string]struct{}, len(services))
+		}
+		for _, s := range services {
+			a.allowedServices[s] = struct{}{}
+		}
+	}
+}
+
+// WithMiddlewareAuditSink emits AuditImpersonationUsed whenever a token carrying an RFC 8693
+// "act" claim is accepted.
+func WithMiddlewareAuditSink(sink AuditSink) AuthMiddlewareOption {
+	return func(a *AuthMiddleware) {
+		a.audit = sink
+	}
+}
+
+// WithMiddlewareLogger logs rejected requests (reason, method, path) to logger. Repeated
+// failures are sampled with DefaultSamplingConfig unless WithFailureLogSampling is given.
+func WithMiddlewareLogger(logger Logger) AuthMiddlewareOption {
+	return func(a *AuthMiddleware) {
+		a.logger = logger
+	}
+}
+
+// WithFailureLogSampling tunes sampling of authentication-failure logs.
+func WithFailureLogSampling(cfg SamplingConfig) AuthMiddlewareOption {
+	return func(a *AuthMiddleware) {
+		a.sampling = cfg
+	}
 }
 
 // NewAuthMiddleware creates a new instance with JWT validator only.
-func NewAuthMiddleware(validator *Validator) *AuthMiddleware {
-	return &AuthMiddleware{validator: validator}
+func NewAuthMiddleware(validator *Validator, opts ...AuthMiddlewareOption) *AuthMiddleware {
+	a := &AuthMiddleware{validator: validator}
+	a.apply(opts)
+	return a
 }
 
 // NewAuthMiddlewareWithAPIKey creates a new instance with both JWT validator and API key validator.
-func NewAuthMiddlewareWithAPIKey(validator *Validator, apiKeyValidator *APIKeyValidator) *AuthMiddleware {
-	return &AuthMiddleware{
+func NewAuthMiddlewareWithAPIKey(validator *Validator, apiKeyValidator *APIKeyValidator, opts ...AuthMiddlewareOption) *AuthMiddleware {
+	a := &AuthMiddleware{
 		validator:       validator,
 		apiKeyValidator: apiKeyValidator,
 	}
+	a.apply(opts)
+	return a
+}
+
+func (a *AuthMiddleware) apply(opts []AuthMiddlewareOption) {
+	a.sampling = DefaultSamplingConfig
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.expiryGrace > 0 {
+		a.graceParser = jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}), jwt.WithLeeway(a.expiryGrace))
+	}
+	if a.logger == nil {
+		a.logger = NopLogger()
+		return
+	}
+	a.logger = NewSampledLogger(a.logger, a.sampling)
+}
+
+// serviceAllowed reports whether an API key bound to service may call this host.
+func (a *AuthMiddleware) serviceAllowed(service string) bool {
+	if len(a.allowedServices) == 0 || service == "" {
+		return true
+	}
+	_, ok := a.allowedServices[service]
+	return ok
 }
 
 // RequireAuth ensures incoming requests possess a valid bearer token or API key.
 func (a *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Claims already established in-process (e.g. by NewClaimsHeaderMiddleware from a
+		// trusted gateway) need no signature check, but this host's own policy still applies.
+		if claims, ok := ClaimsFromContext(r.Context()); ok {
+			if a.propagatedAdmitted(w, r, claims) {
+				next.ServeHTTP(w, r)
+			}
+			return
+		}
+
 		authHeader := r.Header.Get("Authorization")
+		failure := newAuthError(KindTokenMissing, "missing bearer token or API key", nil)
 
 		// Try JWT Bearer token first
 		if authHeader != "" && strings.HasPrefix(strings.ToLower(authHeader), "bearer ") {
 			tokenStr := strings.TrimSpace(authHeader[7:])
-			claims, err := a.validator.ValidateToken(tokenStr)
+			claims, err := a.validateToken(tokenStr)
+			if graced, ok := a.validateWithGrace(r, tokenStr, err); ok {
+				claims, err = graced, nil
+			}
 			if err == nil {
+				if !a.tenantAdmitted(w, r, claims.TenantID) {
+					return
+				}
+				if actor, ok := claims.Impersonator(); ok {
+					emitAudit(r.Context(), a.audit, AuditEvent{
+						Type:       AuditImpersonationUsed,
+						Outcome:    AuditOutcomeSuccess,
+						Actor:      actor,
+						Subject:    claims.Subject,
+						TenantID:   claims.TenantID,
+						TenantSlug: claims.TenantSlug,
+						Attributes: map[string]any{"method": r.Method, "path": r.URL.Path},
+					})
+				}
 				ctx := context.WithValue(r.Context(), claimsContextKey, claims)
 				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
+			failure = asAuthError(err, "invalid token")
 		}
 
 		// Fallback to API key if JWT validation failed or no Bearer token
 		if a.apiKeyValidator != nil {
-			apiKey := r.Header.Get("X-API-Key")
+			apiKey := r.Header.Get(a.apiKeyValidator.HeaderName())
 			if apiKey != "" {
 				result, err := a.apiKeyValidator.ValidateAPIKeyFull(r.Context(), apiKey)
 				if err == nil {
+					if !a.serviceAllowed(result.Service) {
+						a.logger.Warn("authentication rejected", "reason", KindForbidden, "method", r.Method, "path", r.URL.Path, "service", result.Service)
+						writeAuthError(w, http.StatusForbidden, "API key not permitted for this service")
+						return
+					}
+					if !a.tenantAdmitted(w, r, result.TenantID) {
+						return
+					}
 					// Convert API key result to Claims for consistent handling
 					claims := result.ToClaims()
 					// Store client_id in Subject for API keys
@@ -61,13 +184,44 @@ func (a *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
+				failure = asAuthError(err, "invalid API key")
+				if failure.Kind == KindBlocked {
+					a.writeBlocked(w, r, failure)
+					return
+				}
 			}
 		}
 
-		writeAuthError(w, http.StatusUnauthorized, "missing bearer token or API key")
+		if claims, ok := a.admitBreakGlass(r); ok {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), claimsContextKey, claims)))
+			return
+		}
+
+		a.logger.Warn("authentication failed", "reason", failure.Kind, "method", r.Method, "path", r.URL.Path)
+		// An outage (KindUpstream) answers 503 so clients retry instead of re-authenticating.
+		writeAuthError(w, kindStatus(failure.Kind), failure.publicMessage())
 	})
 }
 
+// propagatedAdmitted applies the service allow-list, revocation and tenant checks to claims
+// asserted by an upstream hop, writing the rejection if they fail.
+func (a *AuthMiddleware) propagatedAdmitted(w http.ResponseWriter, r *http.Request, claims *Claims) bool {
+	if claims.IsService && !a.serviceAllowed(claims.ServiceName) {
+		a.logger.Warn("authentication rejected", "reason", KindForbidden, "method", r.Method, "path", r.URL.Path, "service", claims.ServiceName)
+		writeAuthError(w, http.StatusForbidden, "API key not permitted for this service")
+		return false
+	}
+	if a.validator != nil {
+		if err := a.validator.checkRevoked(claims); err != nil {
+			failure := asAuthError(err, "token revoked")
+			a.logger.Warn("authentication failed", "reason", failure.Kind, "method", r.Method, "path", r.URL.Path)
+			writeAuthError(w, kindStatus(failure.Kind), failure.publicMessage())
+			return false
+		}
+	}
+	return a.tenantAdmitted(w, r, claims.TenantID)
+}
+
 // Middleware creates HTTP middleware that validates JWT tokens.
 // Deprecated: Use AuthMiddleware.RequireAuth instead.
 func Middleware(validator *Validator) func(http.Handler) http.Handler {
@@ -277,6 +431,42 @@ func RequireAllPermissions(permissions ...string) func(http.Handler) http.Handle
 	}
 }
 
+// ============================================================================
+// Tenant Isolation Middleware
+// ============================================================================
+
+// TenantFromHeader returns a RequireTenant resolver reading the tenant identifier from a
+// request header (e.g. "X-Tenant-ID").
+func TenantFromHeader(name string) func(*http.Request) string {
+	return func(r *http.Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+// RequireTenant creates middleware that requires the tenant addressed by the request (as
+// returned by resolve, e.g. a path parameter or header) to be the token's tenant. The
+// identifier may be either the tenant UUID or its slug. Requests that address no tenant pass;
+// only the platform owner may act across tenants.
+func RequireTenant(resolve func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				writeAuthError(w, http.StatusUnauthorized, "missing claims")
+				return
+			}
+
+			requested := resolve(r)
+			if requested == "" || claims.IsPlatformOwner || claims.TenantRef().Matches(requested) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			writeAuthError(w, http.StatusForbidden, "tenant mismatch")
+		})
+	}
+}
+
 // RequirePlatformOwner creates middleware that requires the user to be a platform owner
 // (a user of the platform's own operating tenant). A tenant superuser is NOT a platform
 // owner and must never reach platform-level pages/configs through this gate.
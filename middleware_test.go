@@ -0,0 +1,119 @@
+package authclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithAllowedServices(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get(DefaultAPIKeyHeader) {
+		case "orders-key":
+			_, _ = w.Write([]byte(`{"client_id":"orders-worker","service":"ordering-service"}`))
+		case "billing-key":
+			_, _ = w.Write([]byte(`{"client_id":"billing-worker","service":"billing-service"}`))
+		case "partner-key":
+			_, _ = w.Write([]byte(`{"client_id":"partner-1"}`))
+		default:
+			http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	v, _ := newBenchValidator(t)
+	m := NewAuthMiddlewareWithAPIKey(v, NewAPIKeyValidator(srv.URL, nil), WithAllowedServices("ordering-service"))
+	h := m.RequireAuth(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"orders-key", http.StatusOK},         // bound to this service
+		{"billing-key", http.StatusForbidden}, // bound to another service
+		{"partner-key", http.StatusOK},        // not service-bound
+		{"unknown-key", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set(DefaultAPIKeyHeader, tt.key)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.key, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestRequireTenant(t *testing.T) {
+	tenant := &Claims{TenantID: "5b8e2d7c-0f4a-4c1e-9a3b-6d2f8e1c0a7b", TenantSlug: "acme"}
+	owner := &Claims{TenantID: "0a1b2c3d-0000-4000-8000-000000000000", TenantSlug: "platform", IsPlatformOwner: true}
+	h := RequireTenant(TenantFromHeader("X-Tenant-ID"))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	tests := []struct {
+		name   string
+		claims *Claims
+		header string
+		want   int
+	}{
+		{"tenant ID", tenant, "5b8e2d7c-0f4a-4c1e-9a3b-6d2f8e1c0a7b", http.StatusOK},
+		{"slug", tenant, "acme", http.StatusOK},
+		{"slug case-insensitive", tenant, "ACME", http.StatusOK},
+		{"no tenant addressed", tenant, "", http.StatusOK},
+		{"other tenant", tenant, "globex", http.StatusForbidden},
+		{"platform owner", owner, "globex", http.StatusOK},
+		{"no claims", nil, "acme", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		if tt.header != "" {
+			req.Header.Set("X-Tenant-ID", tt.header)
+		}
+		if tt.claims != nil {
+			req = req.WithContext(ContextWithClaims(req.Context(), tt.claims))
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestRequireAuthFailureStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get(DefaultAPIKeyHeader) {
+		case "outage-key":
+			http.Error(w, `{"error":"database unavailable"}`, http.StatusInternalServerError)
+		default:
+			http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	v, _ := newBenchValidator(t)
+	h := NewAuthMiddlewareWithAPIKey(v, NewAPIKeyValidator(srv.URL, nil)).RequireAuth(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"no credentials", "", "", http.StatusUnauthorized},
+		{"malformed bearer token", "Authorization", "Bearer not-a-jwt", http.StatusUnauthorized},
+		{"unknown API key", DefaultAPIKeyHeader, "unknown-key", http.StatusUnauthorized},
+		{"auth-service outage", DefaultAPIKeyHeader, "outage-key", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		if tt.header != "" {
+			req.Header.Set(tt.header, tt.value)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+}
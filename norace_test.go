@@ -0,0 +1,5 @@
+//go:build !race
+
+package authclient
+
+const raceEnabled = false
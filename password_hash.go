@@ -0,0 +1,65 @@
+package authclient
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// Password hash algorithms auth-service can import via SyncUserRequest.PasswordHash. Users
+// keep their existing passwords and are re-hashed with auth-service's own algorithm on their
+// next login.
+const (
+	HashAlgorithmBcrypt       = "bcrypt"        // $2a$, $2b$ or $2y$ modular crypt format
+	HashAlgorithmArgon2id     = "argon2id"      // PHC string, $argon2id$v=19$m=...,t=...,p=...$salt$hash
+	HashAlgorithmArgon2i      = "argon2i"       // PHC string, as argon2id
+	HashAlgorithmScrypt       = "scrypt"        // PHC string, $scrypt$ln=...,r=...,p=...$salt$hash
+	HashAlgorithmPBKDF2SHA256 = "pbkdf2-sha256" // PHC string, or a bare hash with salt and iterations in HashParams
+	HashAlgorithmPBKDF2SHA512 = "pbkdf2-sha512" // as pbkdf2-sha256
+)
+
+var (
+	bcryptHash = regexp.MustCompile(`^\$2[aby]\$(0[4-9]|[12][0-9]|3[01])\$[./A-Za-z0-9]{53}$`)
+	argon2Hash = regexp.MustCompile(`^\$(argon2id|argon2i)\$v=\d+\$m=\d+,t=\d+,p=\d+\$[A-Za-z0-9+/]+={0,2}\$[A-Za-z0-9+/]+={0,2}$`)
+	scryptHash = regexp.MustCompile(`^\$scrypt\$ln=\d+,r=\d+,p=\d+\$[A-Za-z0-9+/.]+={0,2}\$[A-Za-z0-9+/.]+={0,2}$`)
+	pbkdf2Hash = regexp.MustCompile(`^\$(pbkdf2-sha256|pbkdf2-sha512)\$i=\d+\$[A-Za-z0-9+/.]+={0,2}\$[A-Za-z0-9+/.]+={0,2}$`)
+)
+
+// validateCredentials checks the credential fields of a sync request before it is sent, so
+// hashes auth-service cannot import are rejected without a round trip.
+func (r SyncUserRequest) validateCredentials() error {
+	if r.PasswordHash == "" {
+		if r.HashAlgorithm != "" || len(r.HashParams) > 0 {
+			return newAuthError(KindInvalidRequest, "user sync: hash_algorithm set without password_hash", nil)
+		}
+		return nil
+	}
+	if r.Password != "" {
+		return newAuthError(KindInvalidRequest, "user sync: password and password_hash are mutually exclusive", nil)
+	}
+
+	var valid bool
+	switch r.HashAlgorithm {
+	case "":
+		return newAuthError(KindInvalidRequest, "user sync: hash_algorithm required with password_hash", nil)
+	case HashAlgorithmBcrypt:
+		valid = bcryptHash.MatchString(r.PasswordHash)
+	case HashAlgorithmArgon2id, HashAlgorithmArgon2i:
+		m := argon2Hash.FindStringSubmatch(r.PasswordHash)
+		valid = m != nil && m[1] == r.HashAlgorithm
+	case HashAlgorithmScrypt:
+		valid = scryptHash.MatchString(r.PasswordHash)
+	case HashAlgorithmPBKDF2SHA256, HashAlgorithmPBKDF2SHA512:
+		if m := pbkdf2Hash.FindStringSubmatch(r.PasswordHash); m != nil {
+			valid = m[1] == r.HashAlgorithm
+		} else {
+			iterations, err := strconv.Atoi(r.HashParams["iterations"])
+			valid = r.HashParams["salt"] != "" && err == nil && iterations > 0
+		}
+	default:
+		return newAuthError(KindInvalidRequest, "user sync: unsupported hash_algorithm "+strconv.Quote(r.HashAlgorithm), nil)
+	}
+	if !valid {
+		return newAuthError(KindInvalidRequest, "user sync: password_hash is not a valid "+r.HashAlgorithm+" hash", nil)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSyncUserPasswordHash(t *testing.T) {
+	const bcrypt = "$2b$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW"
+	tests := []struct {
+		name  string
+		req   SyncUserRequest
+		valid bool
+	}{
+		{"plain password", SyncUserRequest{Password: "hunter2"}, true},
+		{"bcrypt", SyncUserRequest{PasswordHash: bcrypt, HashAlgorithm: HashAlgorithmBcrypt}, true},
+		{"bcrypt truncated", SyncUserRequest{PasswordHash: bcrypt[:40], HashAlgorithm: HashAlgorithmBcrypt}, false},
+		{"argon2id", SyncUserRequest{PasswordHash: "$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", HashAlgorithm: HashAlgorithmArgon2id}, true},
+		{"argon2i hash labelled argon2id", SyncUserRequest{PasswordHash: "$argon2i$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", HashAlgorithm: HashAlgorithmArgon2id}, false},
+		{"scrypt", SyncUserRequest{PasswordHash: "$scrypt$ln=16,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$nFNh2CVHVjNldFVKDHDlm4CbdRSCdEBsjjJxD+iCs5E", HashAlgorithm: HashAlgorithmScrypt}, true},
+		{"pbkdf2 PHC", SyncUserRequest{PasswordHash: "$pbkdf2-sha256$i=600000$c2FsdA$aGFzaA", HashAlgorithm: HashAlgorithmPBKDF2SHA256}, true},
+		{"pbkdf2 bare with params", SyncUserRequest{PasswordHash: "aGFzaA==", HashAlgorithm: HashAlgorithmPBKDF2SHA512, HashParams: map[string]string{"salt": "c2FsdA", "iterations": "210000"}}, true},
+		{"pbkdf2 bare without salt", SyncUserRequest{PasswordHash: "aGFzaA==", HashAlgorithm: HashAlgorithmPBKDF2SHA512}, false},
+		{"unsupported algorithm", SyncUserRequest{PasswordHash: "5f4dcc3b5aa765d61d8327deb882cf99", HashAlgorithm: "md5"}, false},
+		{"missing algorithm", SyncUserRequest{PasswordHash: bcrypt}, false},
+		{"both password and hash", SyncUserRequest{Password: "x", PasswordHash: bcrypt, HashAlgorithm: HashAlgorithmBcrypt}, false},
+		{"algorithm without hash", SyncUserRequest{Password: "x", HashAlgorithm: HashAlgorithmBcrypt}, false},
+	}
+	for _, tt := range tests {
+		err := tt.req.validateCredentials()
+		if (err == nil) != tt.valid {
+			t.Errorf("%s: validateCredentials() = %v, want valid = %v", tt.name, err, tt.valid)
+		}
+		if err != nil && !errors.Is(err, &AuthError{Kind: KindInvalidRequest}) {
+			t.Errorf("%s: err = %v, want invalid_request", tt.name, err)
+		}
+	}
+
+	// Invalid hashes never reach auth-service.
+	client := NewClient("http://127.0.0.1:0", nil)
+	if _, err := client.SyncUser(context.Background(), SyncUserRequest{PasswordHash: "x", HashAlgorithm: "md5"}, "key"); KindOf(err) != KindInvalidRequest {
+		t.Errorf("SyncUser with md5 hash: err = %v, want invalid_request", err)
+	}
+}
@@ -0,0 +1,182 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// PermissionCheckRequest asks auth-service whether the token's subject may perform Action on
+// Resource (a resource type, e.g. "orders"), optionally for one Object (e.g. an order ID).
+type PermissionCheckRequest struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	Object   string `json:"object,omitempty"`
+}
+
+// Obligation is a condition attached to an allow decision that the service must enforce,
+// e.g. {"type": "mask_fields", "params": {"fields": ["salary"]}}.
+type Obligation struct {
+	Type   string         `json:"type"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// PermissionDecision is auth-service's answer to a PermissionCheckRequest.
+type PermissionDecision struct {
+	Allowed     bool         `json:"allowed"`
+	Reason      string       `json:"reason,omitempty"`
+	Obligations []Obligation `json:"obligations,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modelled above; see DecodeMode
+}
+
+// CheckPermission asks auth-service's authorization endpoint whether the holder of
+// accessToken may perform action on resource (and object, when not empty). A deny is a
+// decision, not an error.
+func (c *Client) CheckPermission(ctx context.Context, accessToken, resource, action, object string) (*PermissionDecision, error) {
+	url := fmt.Sprintf("%s/api/v1/authz/check", c.baseURL)
+
+	body, err := json.Marshal(PermissionCheckRequest{Resource: resource, Action: action, Object: object})
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Warn("auth-service: permission check failed",
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody),
+			"resource", resource,
+			"action", action)
+		return nil, c.responseError("permission check", resp.StatusCode, respBody)
+	}
+
+	var decision PermissionDecision
+	if err := c.decode(respBody, &decision); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+
+	return &decision, nil
+}
+
+// PermissionChecker enforces auth-service permission checks in middleware, reusing
+// decisions from a DecisionCache so repeated requests do not each call auth-service.
+type PermissionChecker struct {
+	client *Client
+	cache  *DecisionCache
+}
+
+// NewPermissionChecker creates a checker calling client. cache may be nil to call
+// auth-service on every request.
+func NewPermissionChecker(client *Client, cache *DecisionCache) *PermissionChecker {
+	return &PermissionChecker{client: client, cache: cache}
+}
+
+// Check returns the cached decision for the claims' subject and tenant or asks auth-service
+// with accessToken. Without a subject (no claims) the decision is not cached, since it
+// could not be invalidated.
+func (p *PermissionChecker) Check(ctx context.Context, claims *Claims, accessToken, resource, action, object string) (*PermissionDecision, error) {
+	cacheable := p.cache != nil && claims != nil && claims.Subject != ""
+	var key DecisionKey
+	if cacheable {
+		key = DecisionKey{Subject: claims.Subject, Tenant: claims.TenantID, Resource: resource, Action: action, Object: object}
+		if decision, ok := p.cache.Get(key); ok {
+			return decision, nil
+		}
+	}
+
+	decision, err := p.client.CheckPermission(ctx, accessToken, resource, action, object)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		p.cache.Put(key, decision)
+	}
+	return decision, nil
+}
+
+// RequirePermission creates middleware that asks auth-service whether the request's bearer
+// token may perform action on resource. On allow, the decision (with any obligations) is
+// available to handlers through PermissionDecisionFromContext. Mount it after RequireAuth.
+func (p *PermissionChecker) RequirePermission(resource, action string) func(http.Handler) http.Handler {
+	return p.RequireObjectPermission(resource, action, nil)
+}
+
+// RequireObjectPermission is RequirePermission for a single object, whose ID object derives
+// from the request (e.g. a route parameter).
+func (p *PermissionChecker) RequireObjectPermission(resource, action string, object func(*http.Request) string) func(http.Handler) http.Handler {
+	required := resource + ":" + action
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				writeAuthError(w, http.StatusUnauthorized, "missing bearer token")
+				return
+			}
+			var objectID string
+			if object != nil {
+				objectID = object(r)
+			}
+
+			claims, _ := ClaimsFromContext(r.Context())
+			decision, err := p.Check(r.Context(), claims, token, resource, action, objectID)
+			if err != nil {
+				if KindOf(err) == KindInvalidCredentials {
+					writeAuthError(w, http.StatusUnauthorized, "invalid token")
+					return
+				}
+				writeAuthError(w, http.StatusServiceUnavailable, "authorization temporarily unavailable")
+				return
+			}
+			if !decision.Allowed {
+				writePermissionError(w, http.StatusForbidden, required)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), permissionDecisionContextKey, decision)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+const permissionDecisionContextKey contextKey = "permission_decision"
+
+// PermissionDecisionFromContext returns the decision stored by PermissionChecker middleware.
+func PermissionDecisionFromContext(ctx context.Context) (*PermissionDecision, bool) {
+	decision, ok := ctx.Value(permissionDecisionContextKey).(*PermissionDecision)
+	return decision, ok
+}
+
+// bearerToken returns the token from the request's Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "bearer ") {
+		return "", false
+	}
+	token := strings.TrimSpace(header[7:])
+	return token, token != ""
+}
@@ -0,0 +1,58 @@
+package authclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPermissionCheckerMiddleware(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var req PermissionCheckRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		allowed := r.Header.Get("Authorization") == "Bearer manager" && req.Action == "refund"
+		json.NewEncoder(w).Encode(PermissionDecision{
+			Allowed:     allowed,
+			Obligations: []Obligation{{Type: "log_access"}},
+		})
+	}))
+	defer srv.Close()
+
+	checker := NewPermissionChecker(NewClient(srv.URL, nil), NewDecisionCache(DecisionCacheConfig{}))
+	handler := checker.RequirePermission("orders", "refund")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if d, ok := PermissionDecisionFromContext(r.Context()); !ok || len(d.Obligations) != 1 {
+			t.Errorf("decision not in context: %+v", d)
+		}
+	}))
+
+	tests := []struct {
+		token string
+		want  int
+	}{
+		{"manager", http.StatusOK},
+		{"manager", http.StatusOK}, // cached
+		{"cashier", http.StatusForbidden},
+		{"", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/orders/1/refund", nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		claims := &Claims{TenantID: "t1"}
+		claims.Subject = tt.token
+		req = req.WithContext(ContextWithClaims(req.Context(), claims))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Fatalf("token %q: status = %d, want %d", tt.token, rec.Code, tt.want)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("auth-service calls = %d, want 2", got)
+	}
+}
@@ -0,0 +1,164 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PolicyInput is the document a policy engine evaluates, sent to OPA as "input".
+type PolicyInput struct {
+	Claims   *Claims        `json:"claims"`
+	Request  PolicyRequest  `json:"request"`
+	Resource map[string]any `json:"resource,omitempty"`
+}
+
+// PolicyRequest describes the HTTP request being authorized. Headers are not included so
+// credentials never reach the policy engine.
+type PolicyRequest struct {
+	Method string              `json:"method"`
+	Path   string              `json:"path"`
+	Parts  []string            `json:"parts"` // Path split on "/", e.g. ["api", "v1", "orders"]
+	Host   string              `json:"host,omitempty"`
+	Query  map[string][]string `json:"query,omitempty"`
+}
+
+// PolicyDecision is a policy engine's answer. Reason is optional and returned to the caller
+// on deny, so policies must not put sensitive detail in it.
+type PolicyDecision struct {
+	Allow  bool   `json:"allow"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// PolicyEvaluator decides whether an authenticated request is allowed. OPAClient evaluates
+// against a remote OPA server; an embedded engine (e.g. the OPA Go SDK's rego package) can
+// implement the interface directly.
+type PolicyEvaluator interface {
+	Evaluate(ctx context.Context, input PolicyInput) (PolicyDecision, error)
+}
+
+// OPAClient evaluates a policy through OPA's Data API (POST /v1/data/<path>).
+type OPAClient struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewOPAClient creates an evaluator for the decision at policyPath (e.g. "authz/allow" or
+// "authz" for a {allow, reason} object) on the OPA server at baseURL. httpClient may be nil.
+func NewOPAClient(baseURL, policyPath string, httpClient *http.Client) *OPAClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 2 * time.Second}
+	}
+	return &OPAClient{
+		url:        strings.TrimSuffix(baseURL, "/") + "/v1/data/" + strings.Trim(policyPath, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// Evaluate implements PolicyEvaluator. The decision document may be a boolean or an object
+// with "allow" and optional "reason"; an undefined decision denies.
+func (c *OPAClient) Evaluate(ctx context.Context, input PolicyInput) (PolicyDecision, error) {
+	body, err := json.Marshal(map[string]any{"input": input})
+	if err != nil {
+		return PolicyDecision{}, newAuthError(KindInternal, "opa: marshal input", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return PolicyDecision{}, newAuthError(KindInternal, "opa: create request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return PolicyDecision{}, newAuthError(KindUpstream, "opa: request failed", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return PolicyDecision{}, newAuthError(KindUpstream, "opa: read response", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return PolicyDecision{}, newAuthError(KindUpstream, fmt.Sprintf("opa: status %d", resp.StatusCode), nil)
+	}
+
+	var out struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(respBody, &out); err != nil {
+		return PolicyDecision{}, newAuthError(KindUpstream, "opa: decode response", err)
+	}
+	var decision PolicyDecision
+	switch {
+	case len(out.Result) == 0:
+		// Undefined decision: the policy did not produce a result for this input.
+	case out.Result[0] == '{':
+		err = json.Unmarshal(out.Result, &decision)
+	default:
+		err = json.Unmarshal(out.Result, &decision.Allow)
+	}
+	if err != nil {
+		return PolicyDecision{}, newAuthError(KindUpstream, "opa: unexpected decision document", err)
+	}
+	return decision, nil
+}
+
+// RequirePolicy creates middleware that asks evaluator whether the authenticated request is
+// allowed, answering 403 on deny. resource, if non-nil, adds request-specific attributes
+// (e.g. the owner of the addressed record) to PolicyInput.Resource. Evaluation errors fail
+// closed with 503. Mount it after RequireAuth.
+func RequirePolicy(evaluator PolicyEvaluator, resource func(*http.Request) map[string]any) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				writeAuthError(w, http.StatusUnauthorized, "missing claims")
+				return
+			}
+
+			input := PolicyInput{Claims: claims, Request: policyRequest(r)}
+			if resource != nil {
+				input.Resource = resource(r)
+			}
+			decision, err := evaluator.Evaluate(r.Context(), input)
+			if err != nil {
+				writeAuthError(w, http.StatusServiceUnavailable, "authorization temporarily unavailable")
+				return
+			}
+			if !decision.Allow {
+				writePolicyError(w, decision.Reason)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func policyRequest(r *http.Request) PolicyRequest {
+	path := r.URL.Path
+	return PolicyRequest{
+		Method: r.Method,
+		Path:   path,
+		Parts:  strings.FieldsFunc(path, func(c rune) bool { return c == '/' }),
+		Host:   r.Host,
+		Query:  r.URL.Query(),
+	}
+}
+
+func writePolicyError(w http.ResponseWriter, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	body := map[string]any{
+		"error": "forbidden by policy",
+		"code":  "policy_denied",
+	}
+	if reason != "" {
+		body["reason"] = reason
+	}
+	_ = json.NewEncoder(w).Encode(body)
+}
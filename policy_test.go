@@ -0,0 +1,56 @@
+package authclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequirePolicyWithOPA(t *testing.T) {
+	opa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/data/authz/decision" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var body struct {
+			Input PolicyInput `json:"input"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch body.Input.Request.Parts[0] {
+		case "allowed":
+			w.Write([]byte(`{"result":{"allow":true}}`))
+		case "denied":
+			w.Write([]byte(`{"result":{"allow":false,"reason":"outside business hours"}}`))
+		case "undefined":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer opa.Close()
+
+	handler := RequirePolicy(NewOPAClient(opa.URL, "authz/decision", nil), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/allowed", http.StatusOK},
+		{"/denied", http.StatusForbidden},
+		{"/undefined", http.StatusForbidden},
+		{"/broken", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req = req.WithContext(ContextWithClaims(req.Context(), &Claims{TenantSlug: "acme"}))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
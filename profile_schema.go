@@ -0,0 +1,429 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultProfileSchemaTTL is how long WithProfileValidation caches a tenant's schema.
+const DefaultProfileSchemaTTL = 10 * time.Minute
+
+// ProfileFieldError is one profile field that does not match the tenant's schema.
+type ProfileFieldError struct {
+	Field   string `json:"field"` // dotted path, e.g. "address.postcode" or "phones[1]"
+	Message string `json:"message"`
+}
+
+// ProfileValidationError lists every profile field that does not match the tenant's schema.
+// It is the Cause of the KindInvalidRequest error returned by Register and SyncUser; use
+// errors.As to get at the fields, e.g. to mark them in a form.
+type ProfileValidationError struct {
+	Fields []ProfileFieldError `json:"fields"`
+}
+
+func (e *ProfileValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = f.Field + ": " + f.Message
+	}
+	return strings.Join(parts, "; ")
+}
+
+// ProfileSchema validates user profiles against a JSON Schema. It supports the keywords
+// profile forms use: type, properties, required, additionalProperties, enum, minLength,
+// maxLength, pattern, format (email, date, date-time, uri, uuid), minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, items, minItems and maxItems. Other keywords are
+// ignored; auth-service still validates the full schema.
+type ProfileSchema struct {
+	root *schemaNode
+}
+
+type schemaNode struct {
+	types        []string
+	properties   map[string]*schemaNode
+	required     []string
+	additional   *schemaNode // schema for properties not listed in properties
+	noAdditional bool        // additionalProperties: false
+	enum         []any
+	minLength    *int
+	maxLength    *int
+	pattern      *regexp.Regexp
+	format       string
+	minimum      *float64
+	maximum      *float64
+	exclMinimum  *float64
+	exclMaximum  *float64
+	items        *schemaNode
+	minItems     *int
+	maxItems     *int
+}
+
+type rawSchema struct {
+	Type                 json.RawMessage            `json:"type"`
+	Properties           map[string]json.RawMessage `json:"properties"`
+	Required             []string                   `json:"required"`
+	AdditionalProperties json.RawMessage            `json:"additionalProperties"`
+	Enum                 []any                      `json:"enum"`
+	MinLength            *int                       `json:"minLength"`
+	MaxLength            *int                       `json:"maxLength"`
+	Pattern              string                     `json:"pattern"`
+	Format               string                     `json:"format"`
+	Minimum              *float64                   `json:"minimum"`
+	Maximum              *float64                   `json:"maximum"`
+	ExclusiveMinimum     *float64                   `json:"exclusiveMinimum"`
+	ExclusiveMaximum     *float64                   `json:"exclusiveMaximum"`
+	Items                json.RawMessage            `json:"items"`
+	MinItems             *int                       `json:"minItems"`
+	MaxItems             *int                       `json:"maxItems"`
+}
+
+// ParseProfileSchema parses a JSON Schema document.
+func ParseProfileSchema(data []byte) (*ProfileSchema, error) {
+	root, err := parseSchemaNode(data, "")
+	if err != nil {
+		return nil, fmt.Errorf("profile schema: %w", err)
+	}
+	return &ProfileSchema{root: root}, nil
+}
+
+func parseSchemaNode(data []byte, path string) (*schemaNode, error) {
+	var raw rawSchema
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", schemaPath(path), err)
+	}
+	n := &schemaNode{
+		required:    raw.Required,
+		enum:        raw.Enum,
+		minLength:   raw.MinLength,
+		maxLength:   raw.MaxLength,
+		format:      raw.Format,
+		minimum:     raw.Minimum,
+		maximum:     raw.Maximum,
+		exclMinimum: raw.ExclusiveMinimum,
+		exclMaximum: raw.ExclusiveMaximum,
+		minItems:    raw.MinItems,
+		maxItems:    raw.MaxItems,
+	}
+	if len(raw.Type) > 0 {
+		var one string
+		if err := json.Unmarshal(raw.Type, &one); err == nil {
+			n.types = []string{one}
+		} else if err := json.Unmarshal(raw.Type, &n.types); err != nil {
+			return nil, fmt.Errorf("%s: type must be a string or an array of strings", schemaPath(path))
+		}
+	}
+	if raw.Pattern != "" {
+		re, err := regexp.Compile(raw.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: pattern: %w", schemaPath(path), err)
+		}
+		n.pattern = re
+	}
+	if len(raw.Properties) > 0 {
+		n.properties = make(map[string]*schemaNode, len(raw.Properties))
+		for name, sub := range raw.Properties {
+			child, err := parseSchemaNode(sub, fieldPath(path, name))
+			if err != nil {
+				return nil, err
+			}
+			n.properties[name] = child
+		}
+	}
+	switch ap := strings.TrimSpace(string(raw.AdditionalProperties)); ap {
+	case "", "true":
+	case "false":
+		n.noAdditional = true
+	default:
+		child, err := parseSchemaNode(raw.AdditionalProperties, fieldPath(path, "*"))
+		if err != nil {
+			return nil, err
+		}
+		n.additional = child
+	}
+	if len(raw.Items) > 0 {
+		child, err := parseSchemaNode(raw.Items, path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		n.items = child
+	}
+	return n, nil
+}
+
+// Validate checks profile against the schema, returning a *ProfileValidationError listing
+// every mismatch, or nil.
+func (s *ProfileSchema) Validate(profile map[string]interface{}) error {
+	// Round-trip through JSON so Go values (ints, structs, typed slices) are checked exactly
+	// as auth-service will see them.
+	var doc any = map[string]any{}
+	if profile != nil {
+		data, err := json.Marshal(profile)
+		if err != nil {
+			return &ProfileValidationError{Fields: []ProfileFieldError{{Message: "not JSON-encodable: " + err.Error()}}}
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return &ProfileValidationError{Fields: []ProfileFieldError{{Message: err.Error()}}}
+		}
+	}
+	var errs []ProfileFieldError
+	s.root.validate("", doc, &errs)
+	if len(errs) > 0 {
+		return &ProfileValidationError{Fields: errs}
+	}
+	return nil
+}
+
+func (n *schemaNode) validate(path string, v any, errs *[]ProfileFieldError) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, ProfileFieldError{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if len(n.types) > 0 && !slices.ContainsFunc(n.types, func(t string) bool { return jsonTypeIs(v, t) }) {
+		fail("must be %s", strings.Join(n.types, " or "))
+		return
+	}
+	if len(n.enum) > 0 && !slices.ContainsFunc(n.enum, func(e any) bool { return reflect.DeepEqual(e, v) }) {
+		fail("must be one of %s", enumList(n.enum))
+	}
+
+	switch v := v.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if n.minLength != nil && length < *n.minLength {
+			fail("must be at least %d characters", *n.minLength)
+		}
+		if n.maxLength != nil && length > *n.maxLength {
+			fail("must be at most %d characters", *n.maxLength)
+		}
+		if n.pattern != nil && !n.pattern.MatchString(v) {
+			fail("must match %s", n.pattern)
+		}
+		if n.format != "" && !formatValid(n.format, v) {
+			fail("must be a valid %s", n.format)
+		}
+	case float64:
+		if n.minimum != nil && v < *n.minimum {
+			fail("must be at least %v", *n.minimum)
+		}
+		if n.maximum != nil && v > *n.maximum {
+			fail("must be at most %v", *n.maximum)
+		}
+		if n.exclMinimum != nil && v <= *n.exclMinimum {
+			fail("must be greater than %v", *n.exclMinimum)
+		}
+		if n.exclMaximum != nil && v >= *n.exclMaximum {
+			fail("must be less than %v", *n.exclMaximum)
+		}
+	case []any:
+		if n.minItems != nil && len(v) < *n.minItems {
+			fail("must have at least %d items", *n.minItems)
+		}
+		if n.maxItems != nil && len(v) > *n.maxItems {
+			fail("must have at most %d items", *n.maxItems)
+		}
+		if n.items != nil {
+			for i, item := range v {
+				n.items.validate(path+"["+strconv.Itoa(i)+"]", item, errs)
+			}
+		}
+	case map[string]any:
+		for _, name := range n.required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, ProfileFieldError{Field: fieldPath(path, name), Message: "is required"})
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			child, ok := n.properties[name]
+			switch {
+			case ok:
+				child.validate(fieldPath(path, name), v[name], errs)
+			case n.noAdditional:
+				*errs = append(*errs, ProfileFieldError{Field: fieldPath(path, name), Message: "is not allowed"})
+			case n.additional != nil:
+				n.additional.validate(fieldPath(path, name), v[name], errs)
+			}
+		}
+	}
+}
+
+// jsonTypeIs reports whether a decoded JSON value has JSON Schema type t.
+func jsonTypeIs(v any, t string) bool {
+	switch v := v.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case string:
+		return t == "string"
+	case float64:
+		return t == "number" || (t == "integer" && v == math.Trunc(v))
+	case []any:
+		return t == "array"
+	case map[string]any:
+		return t == "object"
+	}
+	return false
+}
+
+func formatValid(format, v string) bool {
+	switch format {
+	case "email":
+		addr, err := mail.ParseAddress(v)
+		return err == nil && addr.Address == v
+	case "date":
+		_, err := time.Parse(time.DateOnly, v)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, v)
+		return err == nil
+	case "uri":
+		u, err := url.Parse(v)
+		return err == nil && u.Scheme != ""
+	case "uuid":
+		return uuid.Validate(v) == nil
+	}
+	return true // formats we do not know are left to auth-service
+}
+
+func enumList(values []any) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		b, _ := json.Marshal(v)
+		parts[i] = string(b)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func fieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func schemaPath(path string) string {
+	if path == "" {
+		return "root"
+	}
+	return path
+}
+
+// WithProfileValidation makes Register, SyncUser and SyncUsers check Profile against the
+// tenant's profile JSON Schema before sending, so malformed profiles fail locally with a
+// *ProfileValidationError naming the offending fields instead of a 400 from auth-service.
+// Schemas are fetched per tenant and cached for ttl (DefaultProfileSchemaTTL when zero).
+// Tenants without a schema, and schemas that cannot be fetched, skip the check.
+func WithProfileValidation(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		if ttl <= 0 {
+			ttl = DefaultProfileSchemaTTL
+		}
+		c.profileSchemas = &profileSchemaCache{ttl: ttl, entries: make(map[string]profileSchemaEntry)}
+	}
+}
+
+type profileSchemaCache struct {
+	ttl   time.Duration
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]profileSchemaEntry // by tenant slug
+}
+
+type profileSchemaEntry struct {
+	schema  *ProfileSchema // nil when the tenant has none
+	expires time.Time
+}
+
+// ProfileSchema fetches the profile schema configured for tenantSlug, e.g. to validate a
+// form before submitting it. It returns nil, nil when the tenant has none.
+func (c *Client) ProfileSchema(ctx context.Context, tenantSlug string) (*ProfileSchema, error) {
+	endpoint := c.baseURL + "/api/v1/tenants/by-slug/" + url.PathEscape(tenantSlug) + "/profile-schema"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+	httpReq.Header.Set("Accept", "application/schema+json, application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusNoContent:
+		return nil, nil
+	default:
+		return nil, c.responseError("profile schema", resp.StatusCode, respBody)
+	}
+	schema, err := ParseProfileSchema(respBody)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: invalid profile schema", err)
+	}
+	return schema, nil
+}
+
+// validateProfile checks profile against tenantSlug's cached schema when
+// WithProfileValidation is set.
+func (c *Client) validateProfile(ctx context.Context, tenantSlug string, profile map[string]interface{}) error {
+	if c.profileSchemas == nil || tenantSlug == "" {
+		return nil
+	}
+	schema := c.profileSchemas.get(ctx, c, tenantSlug)
+	if schema == nil {
+		return nil
+	}
+	if err := schema.Validate(profile); err != nil {
+		return newAuthError(KindInvalidRequest, "profile does not match tenant schema", err)
+	}
+	return nil
+}
+
+// get returns tenantSlug's schema, refetching it once the cached copy expires. When a fetch
+// fails the previous schema, if any, stays in use.
+func (p *profileSchemaCache) get(ctx context.Context, c *Client, tenantSlug string) *ProfileSchema {
+	p.mu.Lock()
+	entry, ok := p.entries[tenantSlug]
+	p.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.schema
+	}
+
+	v, _, _ := p.group.Do(tenantSlug, func() (any, error) {
+		schema, err := c.ProfileSchema(context.WithoutCancel(ctx), tenantSlug)
+		if err != nil {
+			c.logger.Warn("auth-service: profile schema unavailable, skipping local validation", "error", err, "tenant_slug", tenantSlug)
+			return entry.schema, nil
+		}
+		p.mu.Lock()
+		p.entries[tenantSlug] = profileSchemaEntry{schema: schema, expires: time.Now().Add(p.ttl)}
+		p.mu.Unlock()
+		return schema, nil
+	})
+	return v.(*ProfileSchema)
+}
@@ -0,0 +1,91 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+const testProfileSchema = `{
+	"type": "object",
+	"required": ["first_name", "country"],
+	"additionalProperties": false,
+	"properties": {
+		"first_name": {"type": "string", "minLength": 1, "maxLength": 50},
+		"country": {"enum": ["KE", "UG", "TZ"]},
+		"age": {"type": "integer", "minimum": 18},
+		"website": {"type": "string", "format": "uri"},
+		"phones": {"type": "array", "maxItems": 2, "items": {"type": "string", "pattern": "^\\+[0-9]{8,15}$"}},
+		"address": {"type": "object", "properties": {"postcode": {"type": ["string", "null"], "pattern": "^[0-9]{5}$"}}}
+	}
+}`
+
+func TestProfileSchemaValidate(t *testing.T) {
+	schema, err := ParseProfileSchema([]byte(testProfileSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		profile map[string]interface{}
+		fields  []string // offending fields, in report order
+	}{
+		{"valid", map[string]interface{}{"first_name": "Ada", "country": "KE", "age": 36, "phones": []string{"+254700000000"}}, nil},
+		{"nil profile", nil, []string{"first_name", "country"}},
+		{"wrong types", map[string]interface{}{"first_name": "Ada", "country": "KE", "age": 18.5, "website": "not a uri"}, []string{"age", "website"}},
+		{"nested", map[string]interface{}{"first_name": "Ada", "country": "US", "phones": []string{"+1", "+254700000000", "+254711111111"}, "address": map[string]any{"postcode": "ABC"}}, []string{"address.postcode", "country", "phones", "phones[0]"}},
+		{"unknown field", map[string]interface{}{"first_name": "", "country": "UG", "nickname": "a"}, []string{"first_name", "nickname"}},
+	}
+	for _, tt := range tests {
+		err := schema.Validate(tt.profile)
+		var got []string
+		var verr *ProfileValidationError
+		if errors.As(err, &verr) {
+			for _, f := range verr.Fields {
+				got = append(got, f.Field)
+			}
+		}
+		if !slices.Equal(got, tt.fields) {
+			t.Errorf("%s: fields %q, want %q (err %v)", tt.name, got, tt.fields, err)
+		}
+	}
+}
+
+func TestWithProfileValidation(t *testing.T) {
+	var schemaFetches, registers atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/tenants/by-slug/acme/profile-schema":
+			schemaFetches.Add(1)
+			_, _ = w.Write([]byte(testProfileSchema))
+		case "/api/v1/auth/register":
+			registers.Add(1)
+			_, _ = w.Write([]byte(`{"access_token":"a"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil, WithProfileValidation(0))
+	ctx := context.Background()
+	_, err := c.Register(ctx, RegisterRequest{Email: "ada@acme.test", TenantSlug: "acme", Profile: map[string]interface{}{"first_name": "Ada"}})
+	var verr *ProfileValidationError
+	if KindOf(err) != KindInvalidRequest || !errors.As(err, &verr) || verr.Fields[0].Field != "country" {
+		t.Fatalf("invalid profile: err = %v", err)
+	}
+	if _, err := c.Register(ctx, RegisterRequest{Email: "ada@acme.test", TenantSlug: "acme", Profile: map[string]interface{}{"first_name": "Ada", "country": "KE"}}); err != nil {
+		t.Fatalf("valid profile: %v", err)
+	}
+	// Tenants without a schema are not checked.
+	if _, err := c.Register(ctx, RegisterRequest{Email: "bob@globex.test", TenantSlug: "globex"}); err != nil {
+		t.Fatalf("tenant without schema: %v", err)
+	}
+	if schemaFetches.Load() != 1 || registers.Load() != 2 {
+		t.Fatalf("%d schema fetches, %d registrations; want 1 and 2", schemaFetches.Load(), registers.Load())
+	}
+}
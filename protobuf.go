@@ -0,0 +1,316 @@
+package authclient
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ContentTypeProtobuf is the media type of protobuf request and response bodies. The
+// messages are defined in api/bulk.proto and encoded here without generated code, so the
+// package does not depend on the protobuf runtime's reflection.
+const ContentTypeProtobuf = "application/x-protobuf"
+
+// protobufAccept asks for protobuf while still accepting JSON from older auth-service builds.
+const protobufAccept = ContentTypeProtobuf + ", application/json;q=0.5"
+
+// isProtobuf reports whether resp carries a protobuf body.
+func isProtobuf(resp *http.Response) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType == ContentTypeProtobuf
+}
+
+// decodeBody decodes a JSON (per mode) or protobuf response body, choosing by Content-Type.
+func decodeBody(resp *http.Response, out any, mode DecodeMode, unmarshalProto func([]byte) error) error {
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return err
+	}
+	defer putBuffer(buf)
+	data := buf.Bytes()
+	if isProtobuf(resp) {
+		return unmarshalProto(data)
+	}
+	return decodeJSON(data, out, mode)
+}
+
+// apiKeyBatchEntry is one result of the API key batch endpoint.
+type apiKeyBatchEntry struct {
+	Valid bool `json:"valid"`
+	APIKeyValidationResult
+}
+
+func marshalAPIKeyBatchRequest(keys []string) []byte {
+	var b []byte
+	for _, key := range keys {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, key)
+	}
+	return b
+}
+
+func unmarshalAPIKeyBatchResponse(b []byte) ([]apiKeyBatchEntry, error) {
+	var entries []apiKeyBatchEntry
+	err := protoFields(b, func(num protowire.Number, typ protowire.Type, b []byte) int {
+		if num != 1 {
+			return 0
+		}
+		return protoMessage(typ, b, func(msg []byte) error {
+			entry, err := unmarshalAPIKeyBatchEntry(msg)
+			entries = append(entries, entry)
+			return err
+		})
+	})
+	return entries, err
+}
+
+func unmarshalAPIKeyBatchEntry(b []byte) (apiKeyBatchEntry, error) {
+	var e apiKeyBatchEntry
+	err := protoFields(b, func(num protowire.Number, typ protowire.Type, b []byte) int {
+		switch num {
+		case 1:
+			return protoBool(typ, b, &e.Valid)
+		case 2:
+			return protoString(typ, b, &e.ClientID)
+		case 3:
+			return protoString(typ, b, &e.TenantID)
+		case 4:
+			return protoString(typ, b, &e.TenantSlug)
+		case 5:
+			return protoRepeatedString(typ, b, &e.Scopes)
+		case 6:
+			return protoRepeatedString(typ, b, &e.Roles)
+		case 7:
+			return protoString(typ, b, &e.Service)
+		case 8:
+			return protoString(typ, b, &e.SubscriptionPlan)
+		case 9:
+			return protoRepeatedString(typ, b, &e.SubscriptionFeatures)
+		case 10:
+			return protoMessage(typ, b, func(msg []byte) error {
+				var key string
+				var value int64
+				err := protoFields(msg, func(num protowire.Number, typ protowire.Type, b []byte) int {
+					switch num {
+					case 1:
+						return protoString(typ, b, &key)
+					case 2:
+						return protoInt64(typ, b, &value)
+					}
+					return 0
+				})
+				if e.SubscriptionLimits == nil {
+					e.SubscriptionLimits = make(map[string]int)
+				}
+				e.SubscriptionLimits[key] = int(value)
+				return err
+			})
+		case 11:
+			return protoString(typ, b, &e.SubscriptionStatus)
+		case 12:
+			var secs int64
+			n := protoInt64(typ, b, &secs)
+			if secs != 0 {
+				expires := time.Unix(secs, 0).UTC()
+				e.ExpiresAt = &expires
+			}
+			return n
+		case 13:
+			return protoMessage(typ, b, func(msg []byte) error {
+				var rpm, burst int64
+				err := protoFields(msg, func(num protowire.Number, typ protowire.Type, b []byte) int {
+					switch num {
+					case 1:
+						return protoInt64(typ, b, &rpm)
+					case 2:
+						return protoInt64(typ, b, &burst)
+					}
+					return 0
+				})
+				e.RateLimit = &APIKeyRateLimit{RequestsPerMinute: int(rpm), Burst: int(burst)}
+				return err
+			})
+		}
+		return 0
+	})
+	return e, err
+}
+
+func marshalSyncUsersRequest(users []SyncUserRequest) ([]byte, error) {
+	var b []byte
+	for _, u := range users {
+		var msg []byte
+		msg = appendProtoString(msg, 1, u.Email)
+		msg = appendProtoString(msg, 2, u.Password)
+		msg = appendProtoString(msg, 3, u.TenantSlug)
+		if len(u.Profile) > 0 {
+			profile, err := json.Marshal(u.Profile)
+			if err != nil {
+				return nil, err
+			}
+			msg = protowire.AppendTag(msg, 4, protowire.BytesType)
+			msg = protowire.AppendBytes(msg, profile)
+		}
+		msg = appendProtoString(msg, 5, u.Service)
+		msg = appendProtoString(msg, 6, u.PasswordHash)
+		msg = appendProtoString(msg, 7, u.HashAlgorithm)
+		for k, v := range u.HashParams {
+			entry := appendProtoString(appendProtoString(nil, 1, k), 2, v)
+			msg = protowire.AppendTag(msg, 8, protowire.BytesType)
+			msg = protowire.AppendBytes(msg, entry)
+		}
+		msg = appendProtoString(msg, 9, string(u.OnConflict))
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, msg)
+	}
+	return b, nil
+}
+
+func unmarshalSyncUsersResponse(b []byte) ([]syncUserBatchEntry, error) {
+	var entries []syncUserBatchEntry
+	err := protoFields(b, func(num protowire.Number, typ protowire.Type, b []byte) int {
+		if num != 1 {
+			return 0
+		}
+		return protoMessage(typ, b, func(msg []byte) error {
+			var e syncUserBatchEntry
+			err := protoFields(msg, func(num protowire.Number, typ protowire.Type, b []byte) int {
+				switch num {
+				case 1:
+					return protoString(typ, b, &e.UserID)
+				case 2:
+					return protoString(typ, b, &e.Email)
+				case 3:
+					return protoString(typ, b, &e.TenantID)
+				case 4:
+					return protoBool(typ, b, &e.Created)
+				case 5:
+					return protoString(typ, b, &e.Message)
+				case 6:
+					return protoString(typ, b, &e.ErrorCode)
+				case 7:
+					return protoMessage(typ, b, func(msg []byte) (err error) {
+						e.Conflict, err = unmarshalSyncConflict(msg)
+						return err
+					})
+				}
+				return 0
+			})
+			entries = append(entries, e)
+			return err
+		})
+	})
+	return entries, err
+}
+
+func appendProtoString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+// protoFields walks the fields of one message. field returns the bytes it consumed, 0 to
+// skip the field (unknown fields are ignored, as protobuf requires) or a negative protowire
+// error code.
+func protoFields(b []byte, field func(num protowire.Number, typ protowire.Type, b []byte) int) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		n = field(num, typ, b)
+		if n == 0 {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func unmarshalSyncConflict(b []byte) (*SyncConflictError, error) {
+	conflict := &SyncConflictError{}
+	var resolutions []string
+	err := protoFields(b, func(num protowire.Number, typ protowire.Type, b []byte) int {
+		switch num {
+		case 1:
+			return protoString(typ, b, &conflict.ExistingUserID)
+		case 2:
+			return protoString(typ, b, &conflict.TenantID)
+		case 3:
+			return protoString(typ, b, &conflict.TenantSlug)
+		case 4:
+			return protoRepeatedString(typ, b, &resolutions)
+		case 5:
+			return protoString(typ, b, &conflict.Email)
+		}
+		return 0
+	})
+	for _, r := range resolutions {
+		conflict.Resolutions = append(conflict.Resolutions, SyncResolution(r))
+	}
+	return conflict, err
+}
+
+// protoMessage consumes an embedded message and hands it to decode. A decode error is
+// reported as a protowire parse error, since the surrounding walk only deals in lengths.
+func protoMessage(typ protowire.Type, b []byte, decode func([]byte) error) int {
+	if typ != protowire.BytesType {
+		return 0
+	}
+	msg, n := protowire.ConsumeBytes(b)
+	if n >= 0 && decode(msg) != nil {
+		return -1
+	}
+	return n
+}
+
+func protoString(typ protowire.Type, b []byte, dst *string) int {
+	if typ != protowire.BytesType {
+		return 0
+	}
+	v, n := protowire.ConsumeString(b)
+	if n >= 0 {
+		*dst = v
+	}
+	return n
+}
+
+func protoRepeatedString(typ protowire.Type, b []byte, dst *[]string) int {
+	var v string
+	n := protoString(typ, b, &v)
+	if n > 0 {
+		*dst = append(*dst, v)
+	}
+	return n
+}
+
+func protoInt64(typ protowire.Type, b []byte, dst *int64) int {
+	if typ != protowire.VarintType {
+		return 0
+	}
+	v, n := protowire.ConsumeVarint(b)
+	if n >= 0 {
+		*dst = int64(v)
+	}
+	return n
+}
+
+func protoBool(typ protowire.Type, b []byte, dst *bool) int {
+	if typ != protowire.VarintType {
+		return 0
+	}
+	v, n := protowire.ConsumeVarint(b)
+	if n >= 0 {
+		*dst = protowire.DecodeBool(v)
+	}
+	return n
+}
@@ -0,0 +1,161 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// syncBatchServer answers the user sync batch endpoint in the request's encoding, rejecting
+// users without an email. With jsonOnly it answers protobuf requests with 415.
+func syncBatchServer(t *testing.T, jsonOnly bool, seen *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ct := r.Header.Get("Content-Type")
+		*seen = append(*seen, ct)
+		body, _ := io.ReadAll(r.Body)
+		var emails []string
+		if ct == ContentTypeProtobuf {
+			if jsonOnly {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+			err := protoFields(body, func(num protowire.Number, typ protowire.Type, b []byte) int {
+				return protoMessage(typ, b, func(msg []byte) error {
+					var email string
+					err := protoFields(msg, func(num protowire.Number, typ protowire.Type, b []byte) int {
+						if num == 1 {
+							return protoString(typ, b, &email)
+						}
+						return 0
+					})
+					emails = append(emails, email)
+					return err
+				})
+			})
+			if err != nil {
+				t.Errorf("server: decode protobuf: %v", err)
+			}
+			var out []byte
+			for _, email := range emails {
+				var msg []byte
+				if email == "" {
+					msg = appendProtoString(msg, 6, "email_required")
+				} else {
+					msg = appendProtoString(msg, 1, "u-"+email)
+					msg = appendProtoString(msg, 2, email)
+					msg = protowire.AppendTag(msg, 4, protowire.VarintType)
+					msg = protowire.AppendVarint(msg, protowire.EncodeBool(true))
+				}
+				out = protowire.AppendTag(out, 1, protowire.BytesType)
+				out = protowire.AppendBytes(out, msg)
+			}
+			w.Header().Set("Content-Type", ContentTypeProtobuf)
+			w.Write(out)
+			return
+		}
+		var in struct{ Users []SyncUserRequest }
+		json.Unmarshal(body, &in)
+		var results []map[string]any
+		for _, u := range in.Users {
+			if u.Email == "" {
+				results = append(results, map[string]any{"error_code": "email_required"})
+				continue
+			}
+			results = append(results, map[string]any{"user_id": "u-" + u.Email, "email": u.Email, "created": true})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"results": results})
+	}))
+}
+
+func TestSyncUsersEncodings(t *testing.T) {
+	reqs := []SyncUserRequest{
+		{Email: "ada@acme.test", TenantSlug: "acme", Profile: map[string]any{"name": "Ada"}},
+		{TenantSlug: "acme"},
+	}
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		jsonOnly bool
+		want     []string // Content-Types sent, in order
+	}{
+		{"json default", nil, false, []string{"application/json"}},
+		{"protobuf", []ClientOption{WithProtobuf()}, false, []string{ContentTypeProtobuf}},
+		{"protobuf rejected", []ClientOption{WithProtobuf()}, true, []string{ContentTypeProtobuf, "application/json", "application/json"}},
+	}
+	for _, tt := range tests {
+		var seen []string
+		srv := syncBatchServer(t, tt.jsonOnly, &seen)
+		client := NewClient(srv.URL, nil, tt.opts...)
+		calls := 1
+		if tt.jsonOnly {
+			calls = 2 // the second call must go straight to JSON
+		}
+		for range calls {
+			results, err := client.SyncUsers(context.Background(), reqs, "key")
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if results[0].Err != nil || results[0].Response.UserID != "u-ada@acme.test" || !results[0].Response.Created {
+				t.Errorf("%s: result[0] = %+v", tt.name, results[0])
+			}
+			var ae *AuthError
+			if !errors.As(results[1].Err, &ae) || ae.Code != "email_required" {
+				t.Errorf("%s: result[1].Err = %v, want email_required", tt.name, results[1].Err)
+			}
+		}
+		srv.Close()
+		if len(seen) != len(tt.want) {
+			t.Errorf("%s: content types = %v, want %v", tt.name, seen, tt.want)
+			continue
+		}
+		for i := range seen {
+			if seen[i] != tt.want[i] {
+				t.Errorf("%s: content types = %v, want %v", tt.name, seen, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestUnmarshalAPIKeyBatchResponse(t *testing.T) {
+	var entry []byte
+	entry = protowire.AppendTag(entry, 1, protowire.VarintType)
+	entry = protowire.AppendVarint(entry, 1)
+	entry = appendProtoString(entry, 2, "client-1")
+	entry = appendProtoString(entry, 5, "read")
+	entry = appendProtoString(entry, 5, "write")
+	entry = appendProtoString(entry, 99, "unknown field") // must be skipped
+	limit := appendProtoString(nil, 1, "seats")
+	limit = protowire.AppendTag(limit, 2, protowire.VarintType)
+	limit = protowire.AppendVarint(limit, 25)
+	entry = protowire.AppendTag(entry, 10, protowire.BytesType)
+	entry = protowire.AppendBytes(entry, limit)
+	entry = protowire.AppendTag(entry, 12, protowire.VarintType)
+	entry = protowire.AppendVarint(entry, 1767225600)
+	var resp []byte
+	resp = protowire.AppendTag(resp, 1, protowire.BytesType)
+	resp = protowire.AppendBytes(resp, entry)
+
+	entries, err := unmarshalAPIKeyBatchResponse(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(entries))
+	}
+	e := entries[0]
+	if !e.Valid || e.ClientID != "client-1" || len(e.Scopes) != 2 || e.SubscriptionLimits["seats"] != 25 ||
+		e.ExpiresAt == nil || e.ExpiresAt.Unix() != 1767225600 {
+		t.Errorf("entry = %+v", e)
+	}
+
+	if _, err := unmarshalAPIKeyBatchResponse(resp[:len(resp)-3]); err == nil {
+		t.Error("truncated message decoded without error")
+	}
+}
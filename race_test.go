@@ -0,0 +1,6 @@
+//go:build race
+
+package authclient
+
+// raceEnabled is set when tests run under the race detector, which adds allocations.
+const raceEnabled = true
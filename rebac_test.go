@@ -0,0 +1,51 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireRelation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer svc-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req RelationCheckRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		allowed := req.Subject == "user:alice" && req.Relation == "editor" && req.Object == "document:q3"
+		json.NewEncoder(w).Encode(map[string]bool{"allowed": allowed})
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, nil)
+	client.SetTokenSource(func(context.Context) (string, error) { return "svc-token", nil })
+	checker := NewPermissionChecker(client, NewDecisionCache(DecisionCacheConfig{}))
+
+	mux := http.NewServeMux()
+	mux.Handle("PUT /documents/{id}", checker.RequireRelation("editor", ObjectFromPathValue("document", "id"))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+	tests := []struct {
+		user, path string
+		want       int
+	}{
+		{"alice", "/documents/q3", http.StatusOK},
+		{"bob", "/documents/q3", http.StatusForbidden},
+		{"alice", "/documents/q4", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPut, tt.path, nil)
+		claims := &Claims{}
+		claims.Subject = tt.user
+		req = req.WithContext(ContextWithClaims(req.Context(), claims))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Fatalf("%s %s: status = %d, want %d", tt.user, tt.path, rec.Code, tt.want)
+		}
+	}
+}
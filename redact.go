@@ -0,0 +1,116 @@
+package authclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// maxLoggedBody caps how much of an auth-service response body reaches logs and errors.
+const maxLoggedBody = 512
+
+// sensitiveFields are JSON keys whose values are never logged or echoed in errors.
+var sensitiveFields = map[string]struct{}{
+	"access_token":  {},
+	"refresh_token": {},
+	"id_token":      {},
+	"token":         {},
+	"password":      {},
+	"password_hash": {},
+	"secret":        {},
+	"client_secret": {},
+	"api_key":       {},
+	"apikey":        {},
+	"code_verifier": {},
+	"otp":           {},
+}
+
+// redactor sanitises personal data and credentials before they reach logs or error strings.
+// In strict mode (for regulated deployments) emails and response bodies are omitted entirely.
+type redactor struct {
+	strict bool
+}
+
+// email returns a stable pseudonym for an address so failures for one user can still be
+// correlated, e.g. "sha256:1f2e3d4c5b6a".
+func (r redactor) email(email string) string {
+	if email == "" {
+		return ""
+	}
+	if r.strict {
+		return "[redacted]"
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+// body masks sensitive fields in a JSON or form-encoded body, pseudonymises emails in it (see
+// email) and truncates it to maxLoggedBody bytes. Other bodies are only truncated; in strict
+// mode nothing is returned.
+func (r redactor) body(body []byte) string {
+	if r.strict {
+		return "[redacted]"
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		if masked, err := json.Marshal(r.mask(v)); err == nil {
+			body = masked
+		}
+	} else {
+		body = []byte(r.maskForm(string(body)))
+	}
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+func (r redactor) mask(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if _, ok := sensitiveFields[strings.ToLower(k)]; ok {
+				v[k] = "[redacted]"
+				continue
+			}
+			if email, ok := val.(string); ok && strings.EqualFold(k, "email") {
+				v[k] = r.email(email)
+				continue
+			}
+			v[k] = r.mask(val)
+		}
+	case []any:
+		for i := range v {
+			v[i] = r.mask(v[i])
+		}
+	}
+	return v
+}
+
+// maskForm masks an application/x-www-form-urlencoded body the way mask does JSON, leaving
+// the other pairs (and text that is not form-encoded) as they are.
+func (r redactor) maskForm(body string) string {
+	pairs := strings.Split(body, "&")
+	for i, pair := range pairs {
+		k, val, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			continue
+		}
+		if _, sensitive := sensitiveFields[strings.ToLower(key)]; sensitive {
+			pairs[i] = k + "=" + url.QueryEscape("[redacted]")
+		} else if strings.EqualFold(key, "email") {
+			email, err := url.QueryUnescape(val)
+			if err != nil {
+				email = val
+			}
+			pairs[i] = k + "=" + url.QueryEscape(r.email(email))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
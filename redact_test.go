@@ -0,0 +1,57 @@
+package authclient
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	body := []byte(`{"error":"invalid_grant","refresh_token":"r1","user":{"password":"hunter2","name":"Ada"}}`)
+
+	got := (redactor{}).body(body)
+	for _, secret := range []string{"r1", "hunter2"} {
+		if strings.Contains(got, secret) {
+			t.Fatalf("body() = %s, leaks %q", got, secret)
+		}
+	}
+	if !strings.Contains(got, "invalid_grant") || !strings.Contains(got, "Ada") {
+		t.Fatalf("body() = %s, dropped non-sensitive fields", got)
+	}
+	tests := []struct {
+		name  string
+		body  string
+		leaks []string
+		keeps []string
+	}{
+		{"json email", `{"error":"exists","email":"ada@example.com"}`, []string{"ada@example.com"}, []string{"exists", (redactor{}).email("ada@example.com")}},
+		{"form", "grant_type=password&username=ada&password=hunter2&refresh_token=r1&Client_Secret=s3", []string{"hunter2", "r1", "s3"}, []string{"grant_type=password", "username=ada"}},
+		{"form email", "email=ada%40example.com&otp=123456", []string{"ada", "123456"}, []string{url.QueryEscape((redactor{}).email("ada@example.com"))}},
+		{"plain text", "upstream error: password expired", nil, []string{"upstream error: password expired"}},
+	}
+	for _, tt := range tests {
+		got := (redactor{}).body([]byte(tt.body))
+		for _, secret := range tt.leaks {
+			if strings.Contains(got, secret) {
+				t.Errorf("%s: body() = %s, leaks %q", tt.name, got, secret)
+			}
+		}
+		for _, keep := range tt.keeps {
+			if !strings.Contains(got, keep) {
+				t.Errorf("%s: body() = %s, want %q", tt.name, got, keep)
+			}
+		}
+	}
+
+	if long := (redactor{}).body([]byte(strings.Repeat("x", 2*maxLoggedBody))); len(long) > maxLoggedBody+20 {
+		t.Fatalf("body() did not truncate: %d bytes", len(long))
+	}
+
+	if e := (redactor{}).email("Ada@Example.com"); e != (redactor{}).email("ada@example.com") || strings.Contains(e, "ada") {
+		t.Fatalf("email() = %q, want a case-insensitive pseudonym", e)
+	}
+	strict := redactor{strict: true}
+	if strict.body(body) != "[redacted]" || strict.email("ada@example.com") != "[redacted]" {
+		t.Fatal("strict mode must omit bodies and emails")
+	}
+}
@@ -0,0 +1,56 @@
+package authclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRequestSigning(t *testing.T) {
+	secret := []byte("admin-signing-secret")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !VerifyRequestSignature(r, body, time.Minute, []byte("old-secret"), secret) {
+			http.Error(w, `{"error":"invalid_signature"}`, http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get(RequestKeyIDHeader) != "k1" {
+			http.Error(w, `{"error":"unknown_key"}`, http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id":"t1","user_id":"u1"}`))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		opts   []ClientOption
+		signed bool
+	}{
+		{"signed", []ClientOption{WithRequestSigning("k1", secret)}, true},
+		{"wrong secret", []ClientOption{WithRequestSigning("k1", []byte("guess"))}, false},
+		{"unsigned", nil, false},
+	}
+	for _, tt := range tests {
+		client := NewClient(srv.URL, nil, tt.opts...)
+		_, syncErr := client.SyncUser(ctx, SyncUserRequest{Email: "a@b.test", TenantSlug: "acme"}, "key")
+		_, tenantErr := client.CreateTenant(ctx, TenantRequest{Slug: "acme"})
+		if (syncErr == nil) != tt.signed || (tenantErr == nil) != tt.signed {
+			t.Errorf("%s: SyncUser err = %v, CreateTenant err = %v; want accepted = %v", tt.name, syncErr, tenantErr, tt.signed)
+		}
+	}
+
+	// A signature is bound to the endpoint it was made for.
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tenants", nil)
+	now := time.Now()
+	req.Header.Set(RequestTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(RequestSignatureHeader, SignRequest(secret, now, http.MethodPost, "/api/v1/admin/users/sync", nil))
+	if VerifyRequestSignature(req, nil, time.Minute, secret) {
+		t.Error("signature for another endpoint and timestamp accepted")
+	}
+}
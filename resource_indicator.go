@@ -0,0 +1,19 @@
+package authclient
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// validateResources checks RFC 8707 resource indicators before they are sent: each must be
+// an absolute URI without a fragment.
+func validateResources(resources []string) error {
+	for _, r := range resources {
+		u, err := url.Parse(r)
+		if err != nil || !u.IsAbs() || strings.Contains(r, "#") {
+			return newAuthError(KindInvalidRequest, "auth-service: resource "+strconv.Quote(r)+" must be an absolute URI without a fragment", nil)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestResourceIndicators(t *testing.T) {
+	var got struct {
+		Resource []string `json:"resource"`
+		Audience string   `json:"audience"`
+	}
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		got.Resource, got.Audience = nil, ""
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"access_token":"at"}`))
+	}))
+	defer srv.Close()
+	client := NewClient(srv.URL, nil)
+	ctx := context.Background()
+	resources := []string{"https://orders.internal/api", "https://billing.internal/"}
+
+	requests := []struct {
+		name string
+		call func() error
+	}{
+		{"login", func() error {
+			_, err := client.Login(ctx, LoginRequest{Email: "ada@example.com", Resource: resources, Audience: "orders"})
+			return err
+		}},
+		{"refresh", func() error {
+			_, err := client.RefreshWith(ctx, RefreshRequest{RefreshToken: "rt", Resource: resources, Audience: "orders"})
+			return err
+		}},
+		{"client credentials", func() error {
+			_, err := client.ClientCredentials(ctx, ClientCredentialsRequest{ClientID: "svc", Resource: resources, Audience: "orders"})
+			return err
+		}},
+	}
+	for _, tt := range requests {
+		if err := tt.call(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !slices.Equal(got.Resource, resources) || got.Audience != "orders" {
+			t.Errorf("%s: sent resource=%v audience=%q", tt.name, got.Resource, got.Audience)
+		}
+	}
+
+	for _, bad := range []string{"orders", "/api", "https://orders.internal/api#v2"} {
+		_, err := client.Login(ctx, LoginRequest{Email: "ada@example.com", Resource: []string{bad}})
+		var ae *AuthError
+		if !errors.As(err, &ae) || ae.Kind != KindInvalidRequest {
+			t.Errorf("resource %q: err = %v, want %s", bad, err, KindInvalidRequest)
+		}
+	}
+	if calls != len(requests) {
+		t.Errorf("auth-service calls = %d, want %d (invalid resources must not be sent)", calls, len(requests))
+	}
+}
@@ -0,0 +1,121 @@
+package authclient
+
+import (
+	"sync"
+	"time"
+)
+
+// RevocationChecker reports whether already-validated claims belong to a revoked token,
+// session or user. Implementations must be fast and safe for concurrent use; they run on
+// every ValidateToken call.
+type RevocationChecker interface {
+	IsRevoked(claims *Claims) bool
+}
+
+// DefaultRevocationTTL is how long a RevocationList remembers a revocation. It should be at
+// least the longest access-token lifetime: after that the revoked tokens have expired anyway.
+const DefaultRevocationTTL = time.Hour
+
+// RevocationList is an in-memory RevocationChecker. Feed it from RevocationStream, a webhook
+// handler or a broker consumer.
+type RevocationList struct {
+	ttl time.Duration
+
+	mu       sync.RWMutex
+	sessions map[string]time.Time // sid -> forget after
+	tokens   map[string]time.Time // jti -> forget after
+	users    map[string]userRevocation
+}
+
+type userRevocation struct {
+	before time.Time // tokens issued before this are revoked
+	until  time.Time // forget after
+}
+
+// NewRevocationList creates a list remembering revocations for ttl (DefaultRevocationTTL
+// when zero).
+func NewRevocationList(ttl time.Duration) *RevocationList {
+	if ttl <= 0 {
+		ttl = DefaultRevocationTTL
+	}
+	return &RevocationList{
+		ttl:      ttl,
+		sessions: make(map[string]time.Time),
+		tokens:   make(map[string]time.Time),
+		users:    make(map[string]userRevocation),
+	}
+}
+
+// RevokeSession revokes every token carrying session ID sid.
+func (l *RevocationList) RevokeSession(sid string) {
+	if sid == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pruneLocked()
+	l.sessions[sid] = time.Now().Add(l.ttl)
+}
+
+// RevokeToken revokes the single token with ID (jti) jti.
+func (l *RevocationList) RevokeToken(jti string) {
+	if jti == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pruneLocked()
+	l.tokens[jti] = time.Now().Add(l.ttl)
+}
+
+// RevokeUser revokes every token for userID issued before before, as for "sign out
+// everywhere" or a deleted user. Tokens issued afterwards (a fresh login) stay valid.
+func (l *RevocationList) RevokeUser(userID string, before time.Time) {
+	if userID == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pruneLocked()
+	if prev, ok := l.users[userID]; ok && prev.before.After(before) {
+		before = prev.before
+	}
+	l.users[userID] = userRevocation{before: before, until: time.Now().Add(l.ttl)}
+}
+
+// IsRevoked implements RevocationChecker.
+func (l *RevocationList) IsRevoked(claims *Claims) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if _, ok := l.sessions[claims.SessionID]; ok && claims.SessionID != "" {
+		return true
+	}
+	if _, ok := l.tokens[claims.ID]; ok && claims.ID != "" {
+		return true
+	}
+	if u, ok := l.users[claims.Subject]; ok && claims.Subject != "" {
+		return claims.IssuedAt == nil || claims.IssuedAt.Time.Before(u.before)
+	}
+	return false
+}
+
+// pruneLocked drops revocations older than the TTL. Revocations are rare, so a full scan on
+// each write is cheaper than a background sweeper.
+func (l *RevocationList) pruneLocked() {
+	now := time.Now()
+	for k, until := range l.sessions {
+		if now.After(until) {
+			delete(l.sessions, k)
+		}
+	}
+	for k, until := range l.tokens {
+		if now.After(until) {
+			delete(l.tokens, k)
+		}
+	}
+	for k, u := range l.users {
+		if now.After(u.until) {
+			delete(l.users, k)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package authclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Bengo-Hub/shared-auth-client/events"
+)
+
+// DefaultRevocationStreamPath is auth-service's server-sent events endpoint.
+const DefaultRevocationStreamPath = "/api/v1/auth/events/stream"
+
+// RevocationStreamConfig configures a RevocationStream. At least one of Revocations and
+// APIKeys should be set.
+type RevocationStreamConfig struct {
+	Path string // defaults to DefaultRevocationStreamPath
+
+	// Token returns the bearer token for the stream, e.g. ServiceTokenSource.Token.
+	Token func(ctx context.Context) (string, error)
+
+	Revocations *RevocationList  // receives session.revoked and user.deleted
+	APIKeys     *APIKeyValidator // receives apikey.revoked
+
+	// Events, if set, receives every event after the built-in handling, e.g. the
+	// events.Registry shared with the webhook receiver. Handler errors are logged; the
+	// stream cannot redeliver.
+	Events events.Dispatcher
+
+	MinBackoff time.Duration // first reconnect delay, defaults to 1 second
+	MaxBackoff time.Duration // reconnect delay cap, defaults to 30 seconds
+}
+
+// RevocationStream consumes auth-service's event stream (server-sent events) and applies
+// revocations to a RevocationList and an APIKeyValidator's cache, so revoked sessions and
+// keys stop working within seconds instead of when they expire. It reconnects with
+// exponential backoff and resumes from the last event ID.
+type RevocationStream struct {
+	client     *Client
+	cfg        RevocationStreamConfig
+	httpClient *http.Client
+	lastID     string
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// NewRevocationStream creates a stream consumer using client's base URL and transport.
+func NewRevocationStream(client *Client, cfg RevocationStreamConfig) *RevocationStream {
+	if cfg.Path == "" {
+		cfg.Path = DefaultRevocationStreamPath
+	}
+	if cfg.MinBackoff <= 0 {
+		cfg.MinBackoff = time.Second
+	}
+	if cfg.MaxBackoff < cfg.MinBackoff {
+		cfg.MaxBackoff = max(30*time.Second, cfg.MinBackoff)
+	}
+	return &RevocationStream{
+		client: client,
+		cfg:    cfg,
+		// No client timeout: the response body stays open for the life of the stream.
+		httpClient: &http.Client{Transport: client.httpClient.Transport},
+		closed:     make(chan struct{}),
+	}
+}
+
+// Run consumes the stream until ctx is cancelled or Close is called, reconnecting after
+// errors. It returns ctx.Err(), which is nil after Close.
+func (s *RevocationStream) Run(ctx context.Context) error {
+	ctx, cancel := untilClosed(ctx, s.closed)
+	defer cancel()
+	backoff := s.cfg.MinBackoff
+	for {
+		connected, err := s.consume(ctx)
+		if ctx.Err() != nil {
+			return runErr(ctx, s.closed)
+		}
+		if connected {
+			backoff = s.cfg.MinBackoff
+		}
+		s.client.logger.Warn("auth-service: event stream disconnected", "error", err, "retry_in", backoff)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return runErr(ctx, s.closed)
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, s.cfg.MaxBackoff)
+	}
+}
+
+// Close disconnects a running stream and ends Run.
+func (s *RevocationStream) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+// consume reads one connection until it ends. connected reports whether the server accepted
+// the subscription, which resets the backoff.
+func (s *RevocationStream) consume(ctx context.Context) (connected bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.client.baseURL+s.cfg.Path, nil)
+	if err != nil {
+		return false, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if s.lastID != "" {
+		req.Header.Set("Last-Event-ID", s.lastID)
+	}
+	if s.cfg.Token != nil {
+		token, err := s.cfg.Token(ctx)
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return false, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, statusError(resp.StatusCode, "auth-service: event stream")
+	}
+	s.client.logger.Info("auth-service: event stream connected", "last_event_id", s.lastID)
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	var id, name string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if data.Len() > 0 {
+				s.dispatch(ctx, id, name, data.String())
+			}
+			name = ""
+			data.Reset()
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+			s.lastID = value
+		case "event":
+			name = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+				s.cfg.MinBackoff = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return true, newAuthError(KindUpstream, "auth-service: event stream read", err)
+	}
+	return true, newAuthError(KindUpstream, "auth-service: event stream closed", nil)
+}
+
+// dispatch decodes one SSE message into an events.Event. The SSE id and event fields fill
+// in ID and Type when the payload omits them.
+func (s *RevocationStream) dispatch(ctx context.Context, id, name, data string) {
+	var event events.Event
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		s.client.logger.Warn("auth-service: malformed stream event", "id", id, "error", err)
+		return
+	}
+	if event.ID == "" {
+		event.ID = id
+	}
+	if event.Type == "" {
+		event.Type = name
+	}
+	if err := s.apply(event); err != nil {
+		s.client.logger.Warn("auth-service: stream event not applied", "id", event.ID, "type", event.Type, "error", err)
+	}
+	if s.cfg.Events != nil {
+		if err := s.cfg.Events.Dispatch(ctx, event); err != nil {
+			s.client.logger.Error("auth-service: stream event handler failed", "id", event.ID, "type", event.Type, "error", err)
+		}
+	}
+}
+
+func (s *RevocationStream) apply(event events.Event) error {
+	at := event.OccurredAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	switch event.Type {
+	case events.TypeSessionRevoked:
+		var p events.SessionRevoked
+		if err := event.Decode(&p); err != nil || s.cfg.Revocations == nil {
+			return err
+		}
+		if p.AllSessions {
+			s.cfg.Revocations.RevokeUser(p.UserID, at)
+		} else {
+			s.cfg.Revocations.RevokeSession(p.SessionID)
+		}
+	case events.TypeUserDeleted:
+		var p events.UserDeleted
+		if err := event.Decode(&p); err != nil || s.cfg.Revocations == nil {
+			return err
+		}
+		s.cfg.Revocations.RevokeUser(p.UserID, at)
+	case events.TypeAPIKeyRevoked:
+		var p events.APIKeyRevoked
+		if err := event.Decode(&p); err != nil || s.cfg.APIKeys == nil {
+			return err
+		}
+		for _, fp := range p.Fingerprints {
+			s.cfg.APIKeys.InvalidateKey(fp)
+		}
+	}
+	return nil
+}
+
+// statusError classifies a non-2xx response whose body is not worth reading.
+func statusError(status int, message string) *AuthError {
+	return &AuthError{Kind: statusKind(status), StatusCode: status, Message: fmt.Sprintf("%s: %s", message, http.StatusText(status))}
+}
@@ -0,0 +1,79 @@
+package authclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Bengo-Hub/shared-auth-client/events"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestRevocationList(t *testing.T) {
+	l := NewRevocationList(0)
+	now := time.Now()
+	l.RevokeSession("sess-1")
+	l.RevokeUser("user-2", now)
+
+	tests := []struct {
+		name   string
+		claims Claims
+		want   bool
+	}{
+		{"revoked session", Claims{SessionID: "sess-1"}, true},
+		{"other session", Claims{SessionID: "sess-9"}, false},
+		{"user token issued before", Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-2", IssuedAt: jwt.NewNumericDate(now.Add(-time.Minute))}}, true},
+		{"user token issued after", Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-2", IssuedAt: jwt.NewNumericDate(now.Add(time.Minute))}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := l.IsRevoked(&tt.claims); got != tt.want {
+				t.Fatalf("IsRevoked = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevocationStreamAppliesEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Accept = %q", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "id: 1\nevent: session.revoked\ndata: {\"data\":{\"session_id\":\"sess-1\",\"user_id\":\"u1\"}}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	revocations := NewRevocationList(time.Minute)
+	applied := make(chan struct{})
+	registry := events.NewRegistry()
+	registry.OnSessionRevoked(func(context.Context, events.SessionRevokedEvent) error {
+		close(applied)
+		return nil
+	})
+	stream := NewRevocationStream(NewClient(srv.URL, nil), RevocationStreamConfig{
+		Revocations: revocations,
+		Events:      registry,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- stream.Run(ctx) }()
+
+	select {
+	case <-applied:
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not received")
+	}
+	if !revocations.IsRevoked(&Claims{SessionID: "sess-1"}) {
+		t.Fatal("session not revoked")
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Run = %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package authclient
+
+// Risk levels reported in RiskAssessment.Level.
+const (
+	RiskLevelLow    = "low"
+	RiskLevelMedium = "medium"
+	RiskLevelHigh   = "high"
+)
+
+// Actions auth-service may recommend in RiskAssessment.RecommendedAction.
+const (
+	RiskActionAllow  = "allow"
+	RiskActionStepUp = "step_up" // ask for MFA, email confirmation or similar before continuing
+	RiskActionBlock  = "block"
+)
+
+// RiskAssessment is the anomaly block auth-service attaches to login responses. It is
+// advisory: the session is already issued, and product services decide whether to add
+// verification UX (e.g. a "was this you?" prompt or an MFA challenge).
+type RiskAssessment struct {
+	Score             float64      `json:"score"`           // 0 (benign) to 1 (almost certainly hostile)
+	Level             string       `json:"level,omitempty"` // RiskLevelLow, RiskLevelMedium or RiskLevelHigh
+	NewDevice         bool         `json:"new_device,omitempty"`
+	NewLocation       bool         `json:"new_location,omitempty"`
+	ImpossibleTravel  bool         `json:"impossible_travel,omitempty"`
+	Location          *GeoLocation `json:"location,omitempty"`
+	Reasons           []string     `json:"reasons,omitempty"` // machine-readable signals, e.g. "tor_exit_node"
+	RecommendedAction string       `json:"recommended_action,omitempty"`
+}
+
+// GeoLocation is auth-service's GeoIP lookup of the client IP.
+type GeoLocation struct {
+	Country string `json:"country,omitempty"` // ISO 3166-1 alpha-2
+	Region  string `json:"region,omitempty"`
+	City    string `json:"city,omitempty"`
+	ASN     int    `json:"asn,omitempty"`
+}
+
+// Anomalous reports whether any anomaly signal fired. A nil assessment (auth-service did not
+// evaluate the login) is not anomalous.
+func (r *RiskAssessment) Anomalous() bool {
+	if r == nil {
+		return false
+	}
+	return r.NewDevice || r.NewLocation || r.ImpossibleTravel || len(r.Reasons) > 0
+}
+
+// RequiresStepUp reports whether the caller should verify the user further before trusting
+// the session: auth-service recommended it (or a block), or rated the login high risk.
+func (r *RiskAssessment) RequiresStepUp() bool {
+	if r == nil {
+		return false
+	}
+	switch r.RecommendedAction {
+	case RiskActionStepUp, RiskActionBlock:
+		return true
+	case RiskActionAllow:
+		return false
+	}
+	return r.Level == RiskLevelHigh || r.ImpossibleTravel
+}
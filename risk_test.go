@@ -0,0 +1,40 @@
+package authclient
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRiskAssessment(t *testing.T) {
+	var resp AuthResponse
+	body := `{"access_token":"at","risk":{"score":0.82,"level":"high","new_device":true,
+		"location":{"country":"KE","city":"Nairobi","asn":36866},"reasons":["new_device"]}}`
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Risk == nil || resp.Risk.Score != 0.82 || resp.Risk.Location.Country != "KE" {
+		t.Fatalf("Risk = %+v", resp.Risk)
+	}
+
+	tests := []struct {
+		name              string
+		risk              *RiskAssessment
+		anomalous, stepUp bool
+	}{
+		{"not evaluated", nil, false, false},
+		{"benign", &RiskAssessment{Score: 0.05, Level: RiskLevelLow}, false, false},
+		{"new device, medium", &RiskAssessment{Level: RiskLevelMedium, NewDevice: true}, true, false},
+		{"high level", &RiskAssessment{Level: RiskLevelHigh}, false, true},
+		{"impossible travel", &RiskAssessment{ImpossibleTravel: true}, true, true},
+		{"explicit step-up", &RiskAssessment{NewLocation: true, RecommendedAction: RiskActionStepUp}, true, true},
+		{"explicit allow overrides level", &RiskAssessment{Level: RiskLevelHigh, RecommendedAction: RiskActionAllow}, false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.risk.Anomalous(); got != tt.anomalous {
+			t.Errorf("%s: Anomalous() = %v, want %v", tt.name, got, tt.anomalous)
+		}
+		if got := tt.risk.RequiresStepUp(); got != tt.stepUp {
+			t.Errorf("%s: RequiresStepUp() = %v, want %v", tt.name, got, tt.stepUp)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// Scope is an entry of auth-service's scope catalog: every scope tokens and API keys may
+// carry, with the service that defines it.
+type Scope struct {
+	Name        string `json:"name"` // e.g. "orders:read", as checked by RequireScope
+	Description string `json:"description,omitempty"`
+	Service     string `json:"service,omitempty"`    // owning service, e.g. "orders"
+	Deprecated  bool   `json:"deprecated,omitempty"` // still issued, but new code should not check it
+}
+
+// ListScopes returns auth-service's scope catalog, in catalog order. The catalog is public.
+// To check scopes against constants rather than string literals, generate them from the
+// catalog with cmd/scopegen.
+func (c *Client) ListScopes(ctx context.Context) ([]Scope, error) {
+	endpoint := c.baseURL + "/api/v1/scopes"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.logger.Error("auth-service: list scopes request failed", "error", err, "url", endpoint)
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Warn("auth-service: list scopes failed",
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody))
+		return nil, withRetryAfter(c.responseError("list scopes", resp.StatusCode, respBody), resp.Header)
+	}
+
+	var catalog struct {
+		Scopes []Scope `json:"scopes"`
+	}
+	if err := c.decode(respBody, &catalog); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+	return catalog.Scopes, nil
+}
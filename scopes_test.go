@@ -0,0 +1,24 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListScopes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/scopes" || r.Header.Get("Authorization") != "" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"scopes":[{"name":"orders:read","service":"orders"},{"name":"orders:legacy","deprecated":true}]}`))
+	}))
+	defer srv.Close()
+
+	scopes, err := NewClient(srv.URL, nil).ListScopes(context.Background())
+	if err != nil || len(scopes) != 2 || scopes[0].Name != "orders:read" || scopes[0].Service != "orders" || !scopes[1].Deprecated {
+		t.Fatalf("ListScopes = %+v, %v", scopes, err)
+	}
+}
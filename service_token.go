@@ -0,0 +1,234 @@
+package authclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+// ServiceTokenConfig configures a ServiceTokenSource.
+type ServiceTokenConfig struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// Credentials supplies the client secret (CredentialClientSecret) when ClientSecret is
+	// empty. It is asked on every mint, and told to reload after auth-service rejects it.
+	Credentials CredentialProvider
+	// Resources and Audience narrow the token to the APIs this service calls (RFC 8707).
+	Resources []string
+	Audience  string
+
+	// RefreshThreshold is how long before expiry a new token is minted. Defaults to 30s, and
+	// is capped at half the issued token's lifetime so short-lived tokens are still reused.
+	RefreshThreshold time.Duration
+
+	// Shared, when set, shares the token through Redis under
+	// "<KeyPrefix><client_id>:<sorted scopes>" (plus the audience and sorted resources when
+	// set), so all replicas of a service reuse one token
+	// instead of each minting their own.
+	Shared *redis.Client
+	// KeyPrefix defaults to "service-tokens:".
+	KeyPrefix string
+	// LockTTL bounds how long one replica may hold the mint lock; others wait up to this long
+	// for its token before minting their own. Defaults to 10s.
+	LockTTL time.Duration
+}
+
+// ServiceTokenSource hands out client_credentials access tokens, caching them in memory and,
+// optionally, in Redis. Concurrent callers in a process share one mint (singleflight); across
+// replicas a short Redis lock lets one replica mint while the others wait for its result.
+type ServiceTokenSource struct {
+	client *Client
+	config ServiceTokenConfig
+	key    string
+	group  singleflight.Group
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	lifetime  time.Duration
+}
+
+// releaseLockScript deletes the lock only if this replica still owns it.
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// NewServiceTokenSource creates a token source for the given client credentials.
+func NewServiceTokenSource(client *Client, config ServiceTokenConfig) *ServiceTokenSource {
+	if config.RefreshThreshold <= 0 {
+		config.RefreshThreshold = 30 * time.Second
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "service-tokens:"
+	}
+	if config.LockTTL <= 0 {
+		config.LockTTL = 10 * time.Second
+	}
+	scopes := slices.Clone(config.Scopes)
+	slices.Sort(scopes)
+	key := config.KeyPrefix + config.ClientID + ":" + strings.Join(slices.Compact(scopes), ",")
+	if config.Audience != "" || len(config.Resources) > 0 {
+		resources := slices.Clone(config.Resources)
+		slices.Sort(resources)
+		key += ":" + config.Audience + ":" + strings.Join(slices.Compact(resources), ",")
+	}
+	return &ServiceTokenSource{
+		client: client,
+		config: config,
+		key:    key,
+	}
+}
+
+// Token returns a service access token valid for at least RefreshThreshold.
+func (s *ServiceTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	if s.usable(s.expiresAt, s.lifetime) {
+		token := s.token
+		s.mu.Unlock()
+		return token, nil
+	}
+	s.mu.Unlock()
+
+	ch := s.group.DoChan("token", func() (any, error) {
+		fctx, cancel := context.WithTimeout(context.Background(), s.config.LockTTL+10*time.Second)
+		defer cancel()
+		stored, err := s.obtain(fctx)
+		if err != nil {
+			return "", err
+		}
+		s.mu.Lock()
+		s.token, s.expiresAt, s.lifetime = stored.Tokens.AccessToken, stored.ExpiresAt, stored.lifetime()
+		s.mu.Unlock()
+		return stored.Tokens.AccessToken, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return "", res.Err
+		}
+		return res.Val.(string), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// usable reports whether a token issued for lifetime and expiring at expiresAt can still be
+// handed out. The threshold is capped at half the lifetime: a token living no longer than
+// RefreshThreshold would otherwise never be usable, and every call would mint.
+func (s *ServiceTokenSource) usable(expiresAt time.Time, lifetime time.Duration) bool {
+	threshold := min(s.config.RefreshThreshold, lifetime/2)
+	return time.Now().Add(threshold).Before(expiresAt)
+}
+
+// obtain returns a fresh token from Redis, or mints one under the shared lock.
+func (s *ServiceTokenSource) obtain(ctx context.Context) (*StoredTokens, error) {
+	if s.config.Shared == nil {
+		return s.mint(ctx)
+	}
+	if stored, ok := s.loadShared(ctx); ok {
+		return stored, nil
+	}
+
+	owner := make([]byte, 16)
+	if _, err := rand.Read(owner); err != nil {
+		return nil, fmt.Errorf("service token: lock owner: %w", err)
+	}
+	lockKey := s.key + ":lock"
+	acquired, err := s.config.Shared.SetNX(ctx, lockKey, hex.EncodeToString(owner), s.config.LockTTL).Result()
+	if err != nil {
+		// Redis trouble must not take the service down; fall back to a private token.
+		return s.mint(ctx)
+	}
+	if !acquired {
+		return s.waitForShared(ctx)
+	}
+	defer releaseLockScript.Run(context.WithoutCancel(ctx), s.config.Shared, []string{lockKey}, hex.EncodeToString(owner))
+
+	// Another replica may have published between our read and taking the lock.
+	if stored, ok := s.loadShared(ctx); ok {
+		return stored, nil
+	}
+	stored, err := s.mint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(stored); err == nil {
+		s.config.Shared.Set(ctx, s.key, data, time.Until(stored.ExpiresAt))
+	}
+	return stored, nil
+}
+
+// waitForShared polls for the token another replica is minting, minting privately if the
+// lock holder does not publish within LockTTL.
+func (s *ServiceTokenSource) waitForShared(ctx context.Context) (*StoredTokens, error) {
+	deadline := time.Now().Add(s.config.LockTTL)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+		if stored, ok := s.loadShared(ctx); ok {
+			return stored, nil
+		}
+	}
+	return s.mint(ctx)
+}
+
+func (s *ServiceTokenSource) loadShared(ctx context.Context) (*StoredTokens, bool) {
+	data, err := s.config.Shared.Get(ctx, s.key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	var stored StoredTokens
+	if err := json.Unmarshal(data, &stored); err != nil || !s.usable(stored.ExpiresAt, stored.lifetime()) {
+		return nil, false
+	}
+	return &stored, true
+}
+
+func (s *ServiceTokenSource) mint(ctx context.Context) (*StoredTokens, error) {
+	resp, err := s.clientCredentials(ctx)
+	if err != nil && s.config.ClientSecret == "" && s.config.Credentials != nil && KindOf(err) == KindInvalidCredentials {
+		// The secret may have been rotated since it was cached; retry once with a fresh one.
+		invalidateCredential(s.config.Credentials, CredentialClientSecret)
+		resp, err = s.clientCredentials(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("service token: %w", err)
+	}
+	if resp.AccessToken == "" || resp.ExpiresIn <= 0 {
+		return nil, errors.New("service token: auth-service returned no usable token")
+	}
+	return NewStoredTokens(resp, time.Now()), nil
+}
+
+func (s *ServiceTokenSource) clientCredentials(ctx context.Context) (*AuthResponse, error) {
+	secret := s.config.ClientSecret
+	if secret == "" && s.config.Credentials != nil {
+		var err error
+		if secret, err = s.config.Credentials.Credential(ctx, CredentialClientSecret); err != nil {
+			return nil, newAuthError(KindInternal, "load client secret", err)
+		}
+	}
+	return s.client.ClientCredentials(ctx, ClientCredentialsRequest{
+		ClientID:     s.config.ClientID,
+		ClientSecret: secret,
+		Scope:        strings.Join(s.config.Scopes, " "),
+		Resource:     s.config.Resources,
+		Audience:     s.config.Audience,
+	})
+}
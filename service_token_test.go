@@ -0,0 +1,121 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestServiceTokenSharedRedis(t *testing.T) {
+	var mints atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := mints.Add(1)
+		fmt.Fprintf(w, `{"access_token":"minted-%d","expires_in":900}`, n)
+	}))
+	defer srv.Close()
+
+	setup := func(t *testing.T, lockTTL time.Duration) (*miniredis.Miniredis, func() *ServiceTokenSource) {
+		mints.Store(0)
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+		t.Cleanup(func() { rdb.Close() })
+		return mr, func() *ServiceTokenSource {
+			return NewServiceTokenSource(NewClient(srv.URL, nil), ServiceTokenConfig{
+				ClientID: "orders", ClientSecret: "s3cret", Shared: rdb, LockTTL: lockTTL,
+			})
+		}
+	}
+	token := func(t *testing.T, s *ServiceTokenSource) string {
+		t.Helper()
+		tok, err := s.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tok
+	}
+
+	t.Run("lock holder mints and publishes", func(t *testing.T) {
+		mr, source := setup(t, time.Second)
+		a, b := source(), source()
+		if tok := token(t, a); tok != "minted-1" {
+			t.Fatalf("replica A token = %q, want minted-1", tok)
+		}
+		if mr.Exists(a.key + ":lock") {
+			t.Fatal("mint lock not released")
+		}
+		if tok := token(t, b); tok != "minted-1" || mints.Load() != 1 {
+			t.Fatalf("replica B token = %q after %d mints; want the shared minted-1", tok, mints.Load())
+		}
+	})
+
+	t.Run("waiter picks up the shared token", func(t *testing.T) {
+		mr, source := setup(t, 2*time.Second)
+		s := source()
+		mr.Set(s.key+":lock", "other-replica")
+		go func() {
+			time.Sleep(150 * time.Millisecond)
+			data, _ := json.Marshal(NewStoredTokens(&AuthResponse{AccessToken: "from-holder", ExpiresIn: 900}, time.Now()))
+			mr.Set(s.key, string(data))
+		}()
+		if tok := token(t, s); tok != "from-holder" || mints.Load() != 0 {
+			t.Fatalf("token = %q after %d mints; want the holder's token without minting", tok, mints.Load())
+		}
+	})
+
+	t.Run("lock holder never publishes", func(t *testing.T) {
+		mr, source := setup(t, 300*time.Millisecond)
+		s := source()
+		mr.Set(s.key+":lock", "stuck-replica")
+		if tok := token(t, s); tok != "minted-1" {
+			t.Fatalf("token = %q, want a private mint after LockTTL", tok)
+		}
+	})
+
+	t.Run("redis unavailable", func(t *testing.T) {
+		mr, source := setup(t, time.Second)
+		mr.Close()
+		if tok := token(t, source()); tok != "minted-1" {
+			t.Fatalf("token = %q, want a private mint", tok)
+		}
+	})
+}
+
+func TestServiceTokenReusesShortLivedToken(t *testing.T) {
+	var mints atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := mints.Add(1)
+		fmt.Fprintf(w, `{"access_token":"minted-%d","expires_in":20}`, n)
+	}))
+	defer srv.Close()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	defer rdb.Close()
+	newSource := func() *ServiceTokenSource {
+		// A 20s token is shorter than the 30s default RefreshThreshold.
+		return NewServiceTokenSource(NewClient(srv.URL, nil), ServiceTokenConfig{
+			ClientID: "orders", ClientSecret: "s3cret", Shared: rdb,
+		})
+	}
+
+	a := newSource()
+	for range 3 {
+		if tok, err := a.Token(context.Background()); err != nil || tok != "minted-1" {
+			t.Fatalf("Token() = %q, %v; want the cached minted-1", tok, err)
+		}
+	}
+	if tok, err := newSource().Token(context.Background()); err != nil || tok != "minted-1" {
+		t.Fatalf("replica B Token() = %q, %v; want the shared minted-1", tok, err)
+	}
+	if n := mints.Load(); n != 1 {
+		t.Fatalf("mints = %d, want 1", n)
+	}
+}
@@ -0,0 +1,179 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Error codes written by RequireSessionPolicy. Clients should send the user through a fresh
+// sign-in when they see either.
+const (
+	CodeSessionMaxAge      = "session_max_age_exceeded"
+	CodeSessionIdleTimeout = "session_idle_timeout"
+)
+
+// SessionPolicy bounds how long an interactive session may be used. Zero fields disable
+// their check.
+type SessionPolicy struct {
+	// MaxAge is the absolute session lifetime, measured from auth_time. Tokens without
+	// auth_time are rejected when it is set.
+	MaxAge time.Duration
+	// IdleTimeout ends a session (sid) after this long without requests through the
+	// middleware. Activity tracks requests; share one store (e.g. RedisSessionActivity)
+	// between every replica and route group enforcing the timeout. It defaults to a
+	// per-middleware in-memory store.
+	IdleTimeout time.Duration
+	Activity    SessionActivityStore
+	// FailOpen lets requests through when Activity fails; by default they are rejected with
+	// 503 so an outage cannot silently disable the idle timeout.
+	FailOpen bool
+}
+
+// SessionActivityStore records when each session was last active, for idle timeouts.
+type SessionActivityStore interface {
+	// Touch records activity on sid at now and reports true, unless the previous activity
+	// is more than idle before now: then the session is idle, the record is left unchanged
+	// (so it stays idle) and Touch reports false. Records are kept for at least ttl.
+	Touch(ctx context.Context, sid string, now time.Time, idle, ttl time.Duration) (bool, error)
+}
+
+// RequireSessionPolicy creates middleware enforcing policy on user sessions, for routes
+// that need more recent authentication than the token lifetime alone guarantees. Install one
+// per route group, after RequireAuth. Service credentials (API keys, client_credentials
+// tokens) are not sessions and pass unchecked.
+func RequireSessionPolicy(policy SessionPolicy) func(http.Handler) http.Handler {
+	if policy.IdleTimeout > 0 && policy.Activity == nil {
+		policy.Activity = NewMemorySessionActivity()
+	}
+	ttl := max(policy.MaxAge, 24*time.Hour, 2*policy.IdleTimeout)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				writeAuthError(w, http.StatusUnauthorized, "missing claims")
+				return
+			}
+			if claims.IsService {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if policy.MaxAge > 0 {
+				if age, ok := claims.AuthAge(); !ok || age > policy.MaxAge {
+					writeSessionError(w, CodeSessionMaxAge, "Session has exceeded its maximum age; sign in again")
+					return
+				}
+			}
+			if policy.IdleTimeout > 0 {
+				if claims.SessionID == "" {
+					writeSessionError(w, CodeSessionIdleTimeout, "Token is not bound to a session; sign in again")
+					return
+				}
+				active, err := policy.Activity.Touch(r.Context(), claims.SessionID, time.Now(), policy.IdleTimeout, ttl)
+				switch {
+				case err != nil && !policy.FailOpen:
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusServiceUnavailable)
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"error": "session check temporarily unavailable",
+						"code":  "session_check_unavailable",
+					})
+					return
+				case err == nil && !active:
+					writeSessionError(w, CodeSessionIdleTimeout, "Session has been idle too long; sign in again")
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeSessionError(w http.ResponseWriter, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token", error_description="`+code+`"`)
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"error":          message,
+		"code":           code,
+		"reauthenticate": true,
+	})
+}
+
+// MemorySessionActivity is an in-process SessionActivityStore, suitable for single-replica
+// services and tests.
+type MemorySessionActivity struct {
+	mu       sync.Mutex
+	sessions map[string]sessionActivity
+	sweepAt  time.Time
+}
+
+type sessionActivity struct {
+	last, expires time.Time
+}
+
+// NewMemorySessionActivity creates an empty in-memory activity store.
+func NewMemorySessionActivity() *MemorySessionActivity {
+	return &MemorySessionActivity{sessions: make(map[string]sessionActivity)}
+}
+
+// Touch implements SessionActivityStore.
+func (s *MemorySessionActivity) Touch(ctx context.Context, sid string, now time.Time, idle, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.After(s.sweepAt) {
+		for id, a := range s.sessions {
+			if now.After(a.expires) {
+				delete(s.sessions, id)
+			}
+		}
+		s.sweepAt = now.Add(time.Minute)
+	}
+	if a, ok := s.sessions[sid]; ok && now.Sub(a.last) > idle {
+		return false, nil
+	}
+	s.sessions[sid] = sessionActivity{last: now, expires: now.Add(ttl)}
+	return true, nil
+}
+
+// RedisSessionActivity shares session activity across replicas and services through Redis.
+type RedisSessionActivity struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisSessionActivity creates a store using keys "<prefix><sid>" (prefix defaults to
+// "session-activity:").
+func NewRedisSessionActivity(client *redis.Client, prefix string) *RedisSessionActivity {
+	if prefix == "" {
+		prefix = "session-activity:"
+	}
+	return &RedisSessionActivity{client: client, prefix: prefix}
+}
+
+// touchSessionScript records activity (ARGV[1], unix ms) unless the previous activity is more than
+// ARGV[2] ms old, keeping the key for ARGV[3] ms.
+var touchSessionScript = redis.NewScript(`
+local last = tonumber(redis.call("GET", KEYS[1]))
+if last and tonumber(ARGV[1]) - last > tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[3])
+return 1`)
+
+// Touch implements SessionActivityStore.
+func (s *RedisSessionActivity) Touch(ctx context.Context, sid string, now time.Time, idle, ttl time.Duration) (bool, error) {
+	active, err := touchSessionScript.Run(ctx, s.client, []string{s.prefix + sid},
+		now.UnixMilli(), idle.Milliseconds(), ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("redis session activity: %w", err)
+	}
+	return active == 1, nil
+}
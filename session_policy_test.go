@@ -0,0 +1,71 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestRequireSessionPolicy(t *testing.T) {
+	h := RequireSessionPolicy(SessionPolicy{MaxAge: time.Hour, IdleTimeout: 15 * time.Minute})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	authAt := func(ago time.Duration) *jwt.NumericDate { return jwt.NewNumericDate(time.Now().Add(-ago)) }
+
+	tests := []struct {
+		name     string
+		claims   *Claims
+		wantCode string
+	}{
+		{"fresh session", &Claims{SessionID: "s1", AuthTime: authAt(time.Minute)}, ""},
+		{"too old", &Claims{SessionID: "s2", AuthTime: authAt(2 * time.Hour)}, CodeSessionMaxAge},
+		{"no auth_time", &Claims{SessionID: "s3"}, CodeSessionMaxAge},
+		{"no sid", &Claims{AuthTime: authAt(time.Minute)}, CodeSessionIdleTimeout},
+		{"service", &Claims{IsService: true}, ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/transfers", nil)
+		h.ServeHTTP(rec, req.WithContext(ContextWithClaims(req.Context(), tt.claims)))
+		if tt.wantCode == "" {
+			if rec.Code != http.StatusOK {
+				t.Errorf("%s: status %d, want 200", tt.name, rec.Code)
+			}
+			continue
+		}
+		var body struct {
+			Code           string `json:"code"`
+			Reauthenticate bool   `json:"reauthenticate"`
+		}
+		json.NewDecoder(rec.Body).Decode(&body)
+		if rec.Code != http.StatusUnauthorized || body.Code != tt.wantCode || !body.Reauthenticate {
+			t.Errorf("%s: status %d code %q, want 401 %q", tt.name, rec.Code, body.Code, tt.wantCode)
+		}
+	}
+}
+
+func TestMemorySessionActivity(t *testing.T) {
+	s := NewMemorySessionActivity()
+	ctx := context.Background()
+	start := time.Now()
+	idle, ttl := 10*time.Minute, time.Hour
+	steps := []struct {
+		at   time.Duration
+		want bool
+	}{
+		{0, true},
+		{9 * time.Minute, true},
+		{18 * time.Minute, true}, // 9 minutes after the previous request
+		{29 * time.Minute, false},
+		{30 * time.Minute, false}, // an idle session stays idle
+	}
+	for _, st := range steps {
+		if got, err := s.Touch(ctx, "sid", start.Add(st.at), idle, ttl); err != nil || got != st.want {
+			t.Fatalf("Touch at +%v = %v, %v; want %v", st.at, got, err, st.want)
+		}
+	}
+}
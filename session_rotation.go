@@ -0,0 +1,112 @@
+package authclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SessionRotator replaces a session with a new one. *Client implements it.
+type SessionRotator interface {
+	RotateSession(ctx context.Context, refreshToken string) (*AuthResponse, error)
+}
+
+// RotateSession asks auth-service to replace the session owning refreshToken with a new
+// session ID and token pair, revoking the old session. Call it after login and on privilege
+// elevation (step-up, role change) so a session ID planted or observed before that point is
+// worthless afterwards (session fixation).
+func (c *Client) RotateSession(ctx context.Context, refreshToken string) (*AuthResponse, error) {
+	var authResp AuthResponse
+	if err := c.postJSON(ctx, "/api/v1/auth/session/rotate", "rotate session", RefreshRequest{RefreshToken: refreshToken}, &authResp); err != nil {
+		return nil, err
+	}
+	c.tokensRotated(refreshToken, &authResp)
+	return &authResp, nil
+}
+
+// RotateSession swaps the managed session for a new one from rotator (usually the *Client)
+// and persists it. The manager stays locked for the round trip, so no refresh can spend the
+// old refresh token concurrently; callers of AccessToken wait and then see the new session.
+func (m *TokenManager) RotateSession(ctx context.Context, rotator SessionRotator) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.loadLocked(ctx); err != nil {
+		return err
+	}
+	if m.expired || m.tokens.RefreshToken == "" {
+		return ErrSessionExpired
+	}
+
+	resp, err := rotator.RotateSession(ctx, m.tokens.RefreshToken)
+	if err != nil {
+		if refreshTokenRejected(err) {
+			m.markExpiredLocked(err)
+			return ErrSessionExpired
+		}
+		return fmt.Errorf("token manager: rotate session: %w", err)
+	}
+	old := m.tokens
+	m.setTokensLocked(resp, time.Now())
+	if err := m.saveLocked(ctx); err != nil {
+		return err
+	}
+	if m.config.OnRefresh != nil {
+		m.config.OnRefresh(resp)
+	}
+	if m.config.OnTokensRotated != nil {
+		m.config.OnTokensRotated(old, m.tokens)
+	}
+	return nil
+}
+
+// RotateSession replaces the browser's session with a new one from auth-service and writes
+// the new cookies, dropping the old session's stored tokens and, when auth-service issued a
+// new sid, adding the old one to Revocations. Call it from the handler that elevates privileges, before writing the
+// response. It returns ErrSessionExpired when the request carries no usable session.
+func (b *BFF) RotateSession(w http.ResponseWriter, r *http.Request) (*AuthResponse, error) {
+	session := b.config.Session
+	var refreshToken, oldSID, oldHandle string
+	if session.config.ReferenceOnly {
+		oldHandle = session.SessionID(r)
+		if oldHandle != "" && b.config.Store != nil {
+			if stored, err := b.config.Store.Load(r.Context(), oldHandle); err == nil {
+				refreshToken = stored.Tokens.RefreshToken
+				oldSID = stored.Tokens.SessionID
+			}
+		}
+	} else {
+		var access string
+		access, refreshToken = session.Tokens(r)
+		var claims Claims
+		if _, _, err := jwt.NewParser().ParseUnverified(access, &claims); err == nil {
+			oldSID = claims.SessionID
+		}
+	}
+	if refreshToken == "" {
+		return nil, ErrSessionExpired
+	}
+
+	resp, err := b.config.Client.RotateSession(r.Context(), refreshToken)
+	if err != nil {
+		if refreshTokenRejected(err) {
+			return nil, ErrSessionExpired
+		}
+		return nil, err
+	}
+	if _, err := b.establish(w, r, resp); err != nil {
+		return nil, fmt.Errorf("rotate session: %w", err)
+	}
+
+	if oldHandle != "" && b.config.Store != nil {
+		_ = b.config.Store.Delete(r.Context(), oldHandle)
+	}
+	// auth-service may keep the sid and only replace the tokens; revoking it then would
+	// revoke the new session too.
+	if newSID := resp.SessionID; oldSID != "" && newSID != oldSID && b.config.Revocations != nil {
+		b.config.Revocations.RevokeSession(oldSID)
+	}
+	return resp, nil
+}
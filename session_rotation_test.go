@@ -0,0 +1,55 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBFFRotateSession(t *testing.T) {
+	tests := []struct {
+		name        string
+		newSID      string
+		wantRevoked bool
+	}{
+		{"new sid", "sid-2", true},
+		{"sid kept", "sid-1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(AuthResponse{AccessToken: "at-2", RefreshToken: "rt-2", ExpiresIn: 900, SessionID: tt.newSID})
+			}))
+			defer srv.Close()
+
+			store := NewMemoryTokenStore()
+			revocations := NewRevocationList(0)
+			session := NewCookieSession(CookieSessionConfig{ReferenceOnly: true})
+			bff := NewBFF(BFFConfig{Client: NewClient(srv.URL, nil), Session: session, Store: store, Revocations: revocations})
+			ctx := context.Background()
+			if err := store.Save(ctx, "handle-1", NewStoredTokens(&AuthResponse{AccessToken: "at-1", RefreshToken: "rt-1", ExpiresIn: 900, SessionID: "sid-1"}, time.Now())); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/account/elevate", nil)
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "handle-1"})
+			rec := httptest.NewRecorder()
+			if _, err := bff.RotateSession(rec, req); err != nil {
+				t.Fatalf("RotateSession = %v", err)
+			}
+
+			if got := revocations.IsRevoked(&Claims{SessionID: "sid-1"}); got != tt.wantRevoked {
+				t.Errorf("old sid revoked = %v, want %v", got, tt.wantRevoked)
+			}
+			if revocations.IsRevoked(&Claims{SessionID: tt.newSID}) {
+				t.Error("new session revoked")
+			}
+			if _, err := store.Load(ctx, "handle-1"); err == nil {
+				t.Error("old handle still in the store")
+			}
+		})
+	}
+}
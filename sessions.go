@@ -0,0 +1,104 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Session is one of a user's active sessions: a sign-in on some device, kept alive by its
+// refresh token.
+type Session struct {
+	ID        string    `json:"id"`               // the sid claim of the session's access tokens
+	Device    string    `json:"device,omitempty"` // e.g. "Chrome on macOS", derived from the user agent
+	UserAgent string    `json:"user_agent,omitempty"`
+	IP        string    `json:"ip,omitempty"`       // address of the latest sign-in or refresh
+	Location  string    `json:"location,omitempty"` // approximate, from IP geolocation, when available
+	Current   bool      `json:"current"`            // the session the listing access token belongs to
+	CreatedAt time.Time `json:"created_at"`
+	LastSeen  time.Time `json:"last_seen"` // latest refresh, or CreatedAt
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ListSessions returns the active sessions of the user owning accessToken, for "your
+// devices" pages. Order follows auth-service, newest first.
+func (c *Client) ListSessions(ctx context.Context, accessToken string) ([]Session, error) {
+	if accessToken == "" {
+		return nil, newAuthError(KindTokenMissing, "auth-service: access token required to list sessions", nil)
+	}
+	endpoint := c.baseURL + "/api/v1/auth/sessions"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+accessToken)
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.logger.Error("auth-service: list sessions request failed", "error", err, "url", endpoint)
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, withRetryAfter(c.responseError("list sessions", resp.StatusCode, respBody), resp.Header)
+	}
+
+	var list struct {
+		Sessions []Session `json:"sessions"`
+	}
+	if err := c.decode(respBody, &list); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+	return list.Sessions, nil
+}
+
+// RevokeSession ends one of the user's sessions, e.g. from a "sign out this device" button;
+// its refresh tokens stop working at once. Sessions of other users fail with KindNotFound.
+// Unlike Logout it needs an explicit session ID, so a missing ID cannot end the caller's
+// own session by accident.
+func (c *Client) RevokeSession(ctx context.Context, sessionID, accessToken string) error {
+	if sessionID == "" {
+		return newAuthError(KindInvalidRequest, "auth-service: session ID required to revoke session", nil)
+	}
+	if accessToken == "" {
+		return newAuthError(KindTokenMissing, "auth-service: access token required to revoke session", nil)
+	}
+	endpoint := c.baseURL + "/api/v1/auth/sessions/" + url.PathEscape(sessionID)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+accessToken)
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.logger.Error("auth-service: revoke session request failed", "error", err, "url", endpoint)
+		return newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return withRetryAfter(c.responseError("revoke session", resp.StatusCode, buf.Bytes()), resp.Header)
+	}
+	c.logger.Info("auth-service: session revoked", "session_id", sessionID)
+	return nil
+}
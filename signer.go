@@ -0,0 +1,116 @@
+package authclient
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// DefaultSignerTTL is the lifetime of tokens minted by Signer when none is configured.
+const DefaultSignerTTL = 5 * time.Minute
+
+// SignerConfig configures a Signer.
+type SignerConfig struct {
+	Issuer   string        // iss of minted tokens; the receiving Validator's Config.Issuer
+	Audience []string      // aud of minted tokens, unless the claims set their own
+	TTL      time.Duration // defaults to DefaultSignerTTL
+}
+
+// Signer mints short-lived RS256 JWTs for service-to-service calls. Receivers validate them
+// with an ordinary Validator pointed at the signer's JWKS (see JWKSHandler), so a trusted
+// internal service can assert identity without a round trip to auth-service.
+type Signer struct {
+	key    *rsa.PrivateKey
+	kid    string
+	config SignerConfig
+}
+
+// NewSigner creates a signer for key, advertised in the JWKS under kid.
+func NewSigner(key *rsa.PrivateKey, kid string, config SignerConfig) (*Signer, error) {
+	if key == nil {
+		return nil, fmt.Errorf("signer: private key required")
+	}
+	if kid == "" {
+		return nil, fmt.Errorf("signer: kid required")
+	}
+	if key.N.BitLen() < 2048 {
+		return nil, fmt.Errorf("signer: RSA key must be at least 2048 bits")
+	}
+	if config.TTL <= 0 {
+		config.TTL = DefaultSignerTTL
+	}
+	return &Signer{key: key, kid: kid, config: config}, nil
+}
+
+// Sign mints a token for claims. Issuer, audience, iat, nbf, exp and jti are filled in when
+// unset; the token carries an RFC 9068 "at+jwt" header so Validator treats it as an access token.
+func (s *Signer) Sign(claims Claims) (string, error) {
+	now := time.Now()
+	if claims.Issuer == "" {
+		claims.Issuer = s.config.Issuer
+	}
+	if len(claims.Audience) == 0 {
+		claims.Audience = s.config.Audience
+	}
+	if claims.IssuedAt == nil {
+		claims.IssuedAt = jwt.NewNumericDate(now)
+	}
+	if claims.NotBefore == nil {
+		claims.NotBefore = jwt.NewNumericDate(now)
+	}
+	if claims.RegisteredClaims.ExpiresAt == nil {
+		claims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(now.Add(s.config.TTL))
+	}
+	if claims.ID == "" {
+		claims.ID = uuid.NewString()
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.kid
+	token.Header["typ"] = "at+jwt"
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("signer: %w", err)
+	}
+	return signed, nil
+}
+
+// PublicKey returns the verification key for tokens minted by s.
+func (s *Signer) PublicKey() *rsa.PublicKey {
+	return &s.key.PublicKey
+}
+
+// JWKS returns the signer's public key as a JSON Web Key Set.
+func (s *Signer) JWKS() []byte {
+	data, _ := json.Marshal(map[string]any{"keys": []any{rsaPublicJWK(s.kid, &s.key.PublicKey)}})
+	return data
+}
+
+// JWKSHandler serves JWKS, for use as the JWKSUrl of receiving validators.
+func (s *Signer) JWKSHandler() http.Handler {
+	jwks := s.JWKS()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Write(jwks)
+	})
+}
+
+// rsaPublicJWK encodes pub in the form fetchJWKS accepts.
+func rsaPublicJWK(kid string, pub *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"alg": jwt.SigningMethodRS256.Alg(),
+		"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}
+}
@@ -0,0 +1,39 @@
+package authclient
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSignerTokensValidate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewSigner(key, "internal-1", SignerConfig{Issuer: "orders-service", Audience: []string{"billing"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := httptest.NewServer(signer.JWKSHandler())
+	defer jwks.Close()
+
+	v, err := NewValidator(DefaultConfig(jwks.URL, "orders-service", "billing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Stop()
+
+	token, err := signer.Sign(Claims{ServiceName: "orders-service", IsService: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := v.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() = %v", err)
+	}
+	if claims.ServiceName != "orders-service" || !claims.IsService || claims.ID == "" {
+		t.Fatalf("claims = %+v, want service orders-service with a jti", claims)
+	}
+}
@@ -0,0 +1,82 @@
+package authclient
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// SyncResolution is a way auth-service offers to settle a SyncConflictError. Retry the sync
+// with the chosen resolution in SyncUserRequest.OnConflict; to skip the user, do not retry.
+type SyncResolution string
+
+const (
+	// SyncResolutionLink adds the existing account to the requested tenant, leaving its
+	// password and profile as they are.
+	SyncResolutionLink SyncResolution = "link"
+	// SyncResolutionMerge links the account and also merges the request's profile fields
+	// into it, overwriting fields present in both.
+	SyncResolutionMerge SyncResolution = "merge"
+)
+
+// SyncConflictError is the Cause of a SyncUser or SyncUsers error when the email already
+// belongs to an account in another tenant that auth-service will not join automatically.
+// errors.As also finds the auth-service *Error beneath it.
+type SyncConflictError struct {
+	Email          string           `json:"email,omitempty"`
+	ExistingUserID string           `json:"existing_user_id"`
+	TenantID       string           `json:"tenant_id,omitempty"`   // a tenant the existing account belongs to
+	TenantSlug     string           `json:"tenant_slug,omitempty"` // empty when auth-service withholds it from the caller
+	Resolutions    []SyncResolution `json:"resolutions,omitempty"` // accepted in SyncUserRequest.OnConflict
+
+	apiErr *Error
+}
+
+func (e *SyncConflictError) Error() string {
+	msg := "email belongs to existing user " + e.ExistingUserID
+	if e.TenantSlug != "" {
+		msg += " in tenant " + e.TenantSlug
+	}
+	return msg
+}
+
+func (e *SyncConflictError) Unwrap() error {
+	if e.apiErr == nil {
+		return nil
+	}
+	return e.apiErr
+}
+
+// CanResolve reports whether auth-service offered resolution for this conflict.
+func (e *SyncConflictError) CanResolve(resolution SyncResolution) bool {
+	for _, r := range e.Resolutions {
+		if r == resolution {
+			return true
+		}
+	}
+	return false
+}
+
+// AsSyncConflict returns the sync conflict carried by err, if any.
+func AsSyncConflict(err error) (*SyncConflictError, bool) {
+	var conflict *SyncConflictError
+	ok := errors.As(err, &conflict)
+	return conflict, ok
+}
+
+// withSyncConflict makes the "conflict" object of a sync error body the Cause of err, an
+// AuthError from responseError. Other errors are returned unchanged.
+func withSyncConflict(err error, body []byte) error {
+	var ae *AuthError
+	if !errors.As(err, &ae) {
+		return err
+	}
+	var doc struct {
+		Conflict *SyncConflictError `json:"conflict"`
+	}
+	if json.Unmarshal(body, &doc) != nil || doc.Conflict == nil || doc.Conflict.ExistingUserID == "" {
+		return err
+	}
+	doc.Conflict.apiErr, _ = ae.Cause.(*Error)
+	ae.Cause = doc.Conflict
+	return err
+}
@@ -0,0 +1,62 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestSyncUserConflict(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SyncUserRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.OnConflict == "" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"email exists in another tenant","error_code":"email_conflict",
+				"conflict":{"existing_user_id":"u9","tenant_id":"t2","tenant_slug":"globex","resolutions":["link","merge"]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"user_id":"u9","email":"ada@acme.test","tenant_id":"t1","created":false}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	req := SyncUserRequest{Email: "ada@acme.test", TenantSlug: "acme"}
+	_, err := c.SyncUser(context.Background(), req, "key")
+	conflict, ok := AsSyncConflict(err)
+	var apiErr *Error
+	if !ok || conflict.ExistingUserID != "u9" || conflict.TenantSlug != "globex" || !conflict.CanResolve(SyncResolutionLink) || !errors.As(err, &apiErr) {
+		t.Fatalf("SyncUser: err = %v, conflict = %+v", err, conflict)
+	}
+
+	req.OnConflict = SyncResolutionLink
+	resp, err := c.SyncUser(context.Background(), req, "key")
+	if err != nil || resp.UserID != "u9" {
+		t.Fatalf("SyncUser with resolution = %+v, %v", resp, err)
+	}
+}
+
+func TestUnmarshalSyncConflict(t *testing.T) {
+	conflict := appendProtoString(nil, 1, "u9")
+	conflict = appendProtoString(conflict, 3, "globex")
+	conflict = appendProtoString(conflict, 4, "link")
+	conflict = appendProtoString(conflict, 4, "merge")
+	entry := appendProtoString(nil, 6, "email_conflict")
+	entry = protowire.AppendTag(entry, 7, protowire.BytesType)
+	entry = protowire.AppendBytes(entry, conflict)
+	body := protowire.AppendTag(nil, 1, protowire.BytesType)
+	body = protowire.AppendBytes(body, entry)
+
+	entries, err := unmarshalSyncUsersResponse(body)
+	if err != nil || len(entries) != 1 || entries[0].Conflict == nil {
+		t.Fatalf("unmarshalSyncUsersResponse = %+v, %v", entries, err)
+	}
+	if got := entries[0].Conflict; got.ExistingUserID != "u9" || got.TenantSlug != "globex" || len(got.Resolutions) != 2 {
+		t.Fatalf("conflict = %+v", got)
+	}
+}
@@ -0,0 +1,154 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TenantBootstrap is what BootstrapTenant created.
+type TenantBootstrap struct {
+	Tenant *TenantResponse
+	Admin  *SyncUserResponse // the tenant's first admin; Created is false for an existing account
+	APIKey *TenantAPIKey     // the tenant's service key
+}
+
+// TenantAPIKey is an API key issued to a tenant. Key, the secret, is only returned when the
+// key is created; store it at once, e.g. in a secret manager.
+type TenantAPIKey struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Key       string    `json:"key,omitempty"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BootstrapError reports a BootstrapTenant step that failed after the tenant was created.
+// Err is the step's error; errors.Is and KindOf see through to it. When RollbackErr is set,
+// cleanup failed too and Tenant, and possibly its admin, remain in auth-service.
+type BootstrapError struct {
+	Step        string // "create admin" or "create api key"
+	Tenant      *TenantResponse
+	Err         error
+	RollbackErr error
+}
+
+func (e *BootstrapError) Error() string {
+	msg := "bootstrap tenant " + e.Tenant.Slug + ": " + e.Step + ": " + e.Err.Error()
+	if e.RollbackErr != nil {
+		msg += " (rollback failed: " + e.RollbackErr.Error() + ")"
+	}
+	return msg
+}
+
+func (e *BootstrapError) Unwrap() error { return e.Err }
+
+// BootstrapTenant provisions a tenant in one call: it creates the tenant, makes
+// req.ContactEmail its admin (inviting them by email when the account is new) and issues a
+// service API key named "<slug>-service" with auth-service's default service scopes.
+// adminAPIKey authorizes the admin and key steps; when empty, it comes from
+// WithCredentialProvider.
+//
+// When a step after tenant creation fails, BootstrapTenant deletes what it created, even if
+// ctx has been cancelled, and returns a *BootstrapError. That includes an admin account
+// auth-service created before the admin step failed (e.g. timed out): BootstrapTenant looks
+// the contact up first, so it knows whether the account is new. An existing account made
+// admin is left in place. Creating a tenant whose slug is taken fails before anything is
+// created.
+func (c *Client) BootstrapTenant(ctx context.Context, req TenantRequest, adminAPIKey string) (*TenantBootstrap, error) {
+	if req.Slug == "" || req.ContactEmail == "" {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: tenant slug and contact email required to bootstrap tenant", nil)
+	}
+	apiKey, provided, err := c.adminAPIKey(ctx, adminAPIKey, "bootstrap tenant")
+	if err != nil {
+		return nil, err
+	}
+
+	// Whether the contact has an account decides whether rollback may delete it.
+	newAdminEmail := req.ContactEmail
+	if _, err := c.GetUserByEmail(ctx, req.ContactEmail, apiKey); err == nil {
+		newAdminEmail = ""
+	} else if KindOf(err) != KindNotFound {
+		return nil, err
+	}
+
+	tenant, err := c.CreateTenant(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result := &TenantBootstrap{Tenant: tenant}
+	tenantPath := "/api/v1/admin/tenants/" + url.PathEscape(tenant.ID)
+
+	var admin SyncUserResponse
+	adminReq := map[string]any{"email": req.ContactEmail, "send_invite": true}
+	if err := c.adminCall(ctx, http.MethodPost, tenantPath+"/admins", "create tenant admin", apiKey, provided, adminReq, &admin); err != nil {
+		return nil, c.rollbackBootstrap(ctx, result, "create admin", err, newAdminEmail, apiKey, provided)
+	}
+	result.Admin = &admin
+
+	var key TenantAPIKey
+	keyReq := map[string]any{"name": req.Slug + "-service"}
+	if err := c.adminCall(ctx, http.MethodPost, tenantPath+"/api-keys", "create tenant api key", apiKey, provided, keyReq, &key); err != nil {
+		return nil, c.rollbackBootstrap(ctx, result, "create api key", err, newAdminEmail, apiKey, provided)
+	}
+	result.APIKey = &key
+
+	c.logger.Info("auth-service: tenant bootstrapped", "tenant_slug", tenant.Slug, "tenant_id", tenant.ID, "admin_user_id", admin.UserID, "api_key_id", key.ID)
+	emitAudit(ctx, c.audit, AuditEvent{
+		Type:       AuditTenantBootstrapped,
+		Outcome:    AuditOutcomeSuccess,
+		Subject:    tenant.ID,
+		TenantID:   tenant.ID,
+		TenantSlug: tenant.Slug,
+		Attributes: map[string]any{"admin_user_id": admin.UserID, "api_key_id": key.ID},
+	})
+	return result, nil
+}
+
+// rollbackBootstrap deletes the admin account BootstrapTenant created, then the tenant, and
+// wraps stepErr in a BootstrapError. Deleting the tenant only removes memberships, so a new
+// account has to be deleted on its own. newAdminEmail is the contact's email when they had
+// no account before the bootstrap; if the admin step failed, the account is looked up by it
+// in case auth-service created it anyway.
+func (c *Client) rollbackBootstrap(ctx context.Context, result *TenantBootstrap, step string, stepErr error, newAdminEmail, apiKey string, provided bool) error {
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	var adminID string
+	switch {
+	case result.Admin != nil:
+		if result.Admin.Created {
+			adminID = result.Admin.UserID
+		}
+	case newAdminEmail != "":
+		user, err := c.GetUserByEmail(ctx, newAdminEmail, apiKey)
+		if err == nil {
+			adminID = user.ID
+		} else if KindOf(err) != KindNotFound {
+			errs = append(errs, err)
+		}
+	}
+	if adminID != "" {
+		path := "/api/v1/admin/users/" + url.PathEscape(adminID)
+		if err := c.adminCall(ctx, http.MethodDelete, path, "delete user", apiKey, provided, nil, nil); err != nil && KindOf(err) != KindNotFound {
+			errs = append(errs, err)
+		}
+	}
+	path := "/api/v1/admin/tenants/" + url.PathEscape(result.Tenant.ID)
+	if err := c.adminCall(ctx, http.MethodDelete, path, "delete tenant", apiKey, provided, nil, nil); err != nil && KindOf(err) != KindNotFound {
+		errs = append(errs, err)
+	}
+
+	bootstrapErr := &BootstrapError{Step: step, Tenant: result.Tenant, Err: stepErr, RollbackErr: errors.Join(errs...)}
+	c.logger.Warn("auth-service: tenant bootstrap failed", "tenant_slug", result.Tenant.Slug, "step", step, "error", stepErr, "rolled_back", bootstrapErr.RollbackErr == nil)
+	emitAudit(ctx, c.audit, AuditEvent{
+		Type:       AuditTenantBootstrapped,
+		Outcome:    AuditOutcomeFailure,
+		Subject:    result.Tenant.ID,
+		TenantID:   result.Tenant.ID,
+		TenantSlug: result.Tenant.Slug,
+		Attributes: map[string]any{"step": step, "rolled_back": bootstrapErr.RollbackErr == nil},
+	})
+	return bootstrapErr
+}
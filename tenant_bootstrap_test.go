@@ -0,0 +1,119 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBootstrapTenant(t *testing.T) {
+	var (
+		mu            sync.Mutex
+		deleted       []string
+		accounts      = map[string]string{} // email -> user ID
+		failKeys      bool
+		adminTimesOut bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path != "/api/v1/tenants" && r.Header.Get("X-API-Key") != "admin-key" {
+			http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/api/v1/tenants":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"t1","slug":"acme","status":"active"}`))
+		case r.URL.Path == "/api/v1/admin/users/by-email":
+			id, ok := accounts[r.URL.Query().Get("email")]
+			if !ok {
+				http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"` + id + `","email":"ops@acme.test"}`))
+		case r.URL.Path == "/api/v1/admin/tenants/t1/admins":
+			_, existed := accounts["ops@acme.test"]
+			accounts["ops@acme.test"] = "u1"
+			if adminTimesOut {
+				// The account exists, but the caller gives up before hearing so.
+				mu.Unlock()
+				_, _ = io.Copy(io.Discard, r.Body) // lets the server notice the client leaving
+				<-r.Context().Done()
+				mu.Lock()
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			if existed {
+				_, _ = w.Write([]byte(`{"user_id":"u1","email":"ops@acme.test","tenant_id":"t1","created":false}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"user_id":"u1","email":"ops@acme.test","tenant_id":"t1","created":true}`))
+		case r.URL.Path == "/api/v1/admin/tenants/t1/api-keys" && !failKeys:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"k1","name":"acme-service","key":"ak_secret","scopes":["users:read"]}`))
+		default:
+			http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	ctx := context.Background()
+	req := TenantRequest{Slug: "acme", ContactEmail: "ops@acme.test"}
+	got, err := c.BootstrapTenant(ctx, req, "admin-key")
+	if err != nil || got.Tenant.ID != "t1" || got.Admin.UserID != "u1" || got.APIKey.Key != "ak_secret" || len(deleted) != 0 {
+		t.Fatalf("BootstrapTenant = %+v, %v (deleted %v)", got, err, deleted)
+	}
+
+	tests := []struct {
+		name          string
+		existing      bool // the contact already has an account
+		failKeys      bool
+		adminTimesOut bool
+		wantStep      string
+		wantDeleted   []string
+	}{
+		{"key step fails", false, true, false, "create api key", []string{"/api/v1/admin/users/u1", "/api/v1/admin/tenants/t1"}},
+		{"key step fails, existing account", true, true, false, "create api key", []string{"/api/v1/admin/tenants/t1"}},
+		{"admin step times out after creating the account", false, false, true, "create admin", []string{"/api/v1/admin/users/u1", "/api/v1/admin/tenants/t1"}},
+		{"admin step times out, existing account", true, false, true, "create admin", []string{"/api/v1/admin/tenants/t1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			deleted, failKeys, adminTimesOut = nil, tt.failKeys, tt.adminTimesOut
+			clear(accounts)
+			if tt.existing {
+				accounts["ops@acme.test"] = "u1"
+			}
+			mu.Unlock()
+
+			ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+			defer cancel()
+			_, err := c.BootstrapTenant(ctx, req, "admin-key")
+			var bootstrapErr *BootstrapError
+			if !errors.As(err, &bootstrapErr) || bootstrapErr.Step != tt.wantStep || bootstrapErr.RollbackErr != nil || (tt.failKeys && KindOf(err) != KindUpstream) {
+				t.Fatalf("err = %v, want a %q BootstrapError with a clean rollback", err, tt.wantStep)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if !slices.Equal(deleted, tt.wantDeleted) {
+				t.Fatalf("rolled back %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+
+	if _, err := c.BootstrapTenant(ctx, TenantRequest{Slug: "acme"}, "admin-key"); KindOf(err) != KindInvalidRequest {
+		t.Fatalf("missing contact email: err = %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// TenantStatus is a tenant's lifecycle state in auth-service.
+type TenantStatus string
+
+const (
+	TenantStatusPending   TenantStatus = "pending"   // created, not yet provisioned or approved
+	TenantStatusActive    TenantStatus = "active"    // in normal use
+	TenantStatusSuspended TenantStatus = "suspended" // temporarily disabled, e.g. unpaid or under review
+	TenantStatusDeleted   TenantStatus = "deleted"   // permanently removed
+)
+
+// Valid reports whether s is one of the known statuses.
+func (s TenantStatus) Valid() bool {
+	switch s {
+	case TenantStatusPending, TenantStatusActive, TenantStatusSuspended, TenantStatusDeleted:
+		return true
+	}
+	return false
+}
+
+// Blocked reports whether tokens and API keys of a tenant in this status must be refused.
+// Statuses this package does not know are not blocked, so auth-service can add new ones.
+func (s TenantStatus) Blocked() bool {
+	return s == TenantStatusSuspended || s == TenantStatusDeleted
+}
+
+// TenantStatusChecker reports the current status of a tenant. Implementations must be fast
+// and safe for concurrent use; they run on every authenticated request. ok is false for
+// tenants the checker knows nothing about, which are admitted.
+type TenantStatusChecker interface {
+	TenantStatus(tenantID string) (status TenantStatus, ok bool)
+}
+
+// TenantStatusList is an in-memory TenantStatusChecker. Feed it from tenant webhooks, a
+// broker consumer or a periodic sync of auth-service tenants.
+type TenantStatusList struct {
+	mu       sync.RWMutex
+	statuses map[string]TenantStatus
+}
+
+// NewTenantStatusList creates an empty list.
+func NewTenantStatusList() *TenantStatusList {
+	return &TenantStatusList{statuses: make(map[string]TenantStatus)}
+}
+
+// Set records tenantID's status. Recording TenantStatusActive forgets the tenant, since
+// unknown tenants are admitted anyway.
+func (l *TenantStatusList) Set(tenantID string, status TenantStatus) {
+	if tenantID == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if status == TenantStatusActive {
+		delete(l.statuses, tenantID)
+		return
+	}
+	l.statuses[tenantID] = status
+}
+
+// TenantStatus implements TenantStatusChecker.
+func (l *TenantStatusList) TenantStatus(tenantID string) (TenantStatus, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	status, ok := l.statuses[tenantID]
+	return status, ok
+}
+
+// WithTenantStatus makes RequireAuth refuse, with 403, bearer tokens and API keys whose
+// tenant checker reports as suspended or deleted (see TenantStatus.Blocked). Tokens without
+// a tenant are unaffected.
+func WithTenantStatus(checker TenantStatusChecker) AuthMiddlewareOption {
+	return func(a *AuthMiddleware) {
+		a.tenantStatus = checker
+	}
+}
+
+// tenantAdmitted writes a 403 and returns false when tenantID is blocked.
+func (a *AuthMiddleware) tenantAdmitted(w http.ResponseWriter, r *http.Request, tenantID string) bool {
+	if a.tenantStatus == nil || tenantID == "" {
+		return true
+	}
+	status, ok := a.tenantStatus.TenantStatus(tenantID)
+	if !ok || !status.Blocked() {
+		return true
+	}
+	a.logger.Warn("authentication rejected", "reason", KindForbidden, "method", r.Method, "path", r.URL.Path, "tenant_id", tenantID, "tenant_status", status)
+	writeAuthError(w, http.StatusForbidden, "tenant "+string(status))
+	return false
+}
+
+// SuspendTenant suspends a tenant in auth-service: its users can no longer sign in, and
+// services using WithTenantStatus refuse its tokens once they learn of the change. reason
+// is recorded by auth-service.
+func (c *Client) SuspendTenant(ctx context.Context, tenantID, reason, apiKey string) (*TenantResponse, error) {
+	return c.transitionTenant(ctx, tenantID, "suspend", reason, apiKey, AuditTenantSuspended)
+}
+
+// ActivateTenant activates a pending tenant or reactivates a suspended one.
+func (c *Client) ActivateTenant(ctx context.Context, tenantID, apiKey string) (*TenantResponse, error) {
+	return c.transitionTenant(ctx, tenantID, "activate", "", apiKey, AuditTenantActivated)
+}
+
+// transitionTenant posts a lifecycle action to auth-service's admin tenant endpoint.
+func (c *Client) transitionTenant(ctx context.Context, tenantID, action, reason, apiKey, auditType string) (*TenantResponse, error) {
+	op := action + " tenant"
+	if tenantID == "" {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: tenant ID required to "+action+" tenant", nil)
+	}
+	apiKey, provided, err := c.adminAPIKey(ctx, apiKey, op)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(struct {
+		Reason string `json:"reason,omitempty"`
+	}{reason})
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
+	}
+
+	endpoint := c.baseURL + "/api/v1/admin/tenants/" + url.PathEscape(tenantID) + "/" + action
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("X-API-Key", apiKey)
+	c.signAdmin(httpReq, body)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.logger.Error("auth-service: "+op+" request failed", "error", err, "url", endpoint, "tenant_id", tenantID)
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Warn("auth-service: "+op+" failed",
+			"status", resp.StatusCode,
+			"response", c.redact.body(respBody),
+			"tenant_id", tenantID)
+		c.apiKeyRejected(provided, resp.StatusCode)
+		return nil, withRetryAfter(c.responseError(op, resp.StatusCode, respBody), resp.Header)
+	}
+
+	var tenantResp TenantResponse
+	if err := c.decode(respBody, &tenantResp); err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+
+	c.logger.Info("auth-service: tenant status changed", "tenant_id", tenantID, "status", tenantResp.Status)
+	attrs := map[string]any{"status": string(tenantResp.Status)}
+	if reason != "" {
+		attrs["reason"] = reason
+	}
+	emitAudit(ctx, c.audit, AuditEvent{
+		Type:       auditType,
+		Outcome:    AuditOutcomeSuccess,
+		Subject:    tenantID,
+		TenantID:   tenantID,
+		TenantSlug: tenantResp.Slug,
+		Attributes: attrs,
+	})
+	return &tenantResp, nil
+}
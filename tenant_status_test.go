@@ -0,0 +1,76 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithTenantStatus(t *testing.T) {
+	v, token := newBenchValidator(t)
+	const tenantID = "5b8e2d7c-0f4a-4c1e-9a3b-6d2f8e1c0a7b"
+	statuses := NewTenantStatusList()
+	h := NewAuthMiddleware(v, WithTenantStatus(statuses)).RequireAuth(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	tests := []struct {
+		status TenantStatus
+		want   int
+	}{
+		{TenantStatusActive, http.StatusOK},
+		{TenantStatusPending, http.StatusOK},
+		{TenantStatusSuspended, http.StatusForbidden},
+		{TenantStatusDeleted, http.StatusForbidden},
+		{"archived", http.StatusOK}, // unknown statuses are not blocked
+		{TenantStatusActive, http.StatusOK},
+	}
+	for _, tt := range tests {
+		statuses.Set(tenantID, tt.status)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("tenant %s: status %d, want %d", tt.status, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestSuspendAndActivateTenant(t *testing.T) {
+	var reason string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "admin-key" {
+			http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+			return
+		}
+		var body struct{ Reason string }
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		reason = body.Reason
+		switch r.URL.Path {
+		case "/api/v1/admin/tenants/t1/suspend":
+			_, _ = w.Write([]byte(`{"id":"t1","slug":"acme","status":"suspended"}`))
+		case "/api/v1/admin/tenants/t1/activate":
+			_, _ = w.Write([]byte(`{"id":"t1","slug":"acme","status":"active"}`))
+		default:
+			http.Error(w, `{"error":"tenant not found"}`, http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	ctx := context.Background()
+	tenant, err := c.SuspendTenant(ctx, "t1", "unpaid invoices", "admin-key")
+	if err != nil || tenant.Status != TenantStatusSuspended || reason != "unpaid invoices" {
+		t.Fatalf("SuspendTenant = %+v, %v (reason %q)", tenant, err, reason)
+	}
+	if tenant, err = c.ActivateTenant(ctx, "t1", "admin-key"); err != nil || tenant.Status != TenantStatusActive {
+		t.Fatalf("ActivateTenant = %+v, %v", tenant, err)
+	}
+	if _, err := c.ActivateTenant(ctx, "t2", "admin-key"); KindOf(err) != KindNotFound {
+		t.Fatalf("unknown tenant: err = %v", err)
+	}
+	if _, err := c.SuspendTenant(ctx, "t1", "", ""); KindOf(err) != KindInvalidRequest {
+		t.Fatalf("missing API key: err = %v", err)
+	}
+}
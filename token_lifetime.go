@@ -0,0 +1,73 @@
+package authclient
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ExpiresAt returns when the access token expires. For responses from this client it is
+// ExpiresIn after the response was received, which is immune to clock differences with
+// auth-service. Otherwise (e.g. a response loaded from a TokenStore) it is ExpiresIn after
+// the token's iat, or the token's exp when ExpiresIn is unset. It is zero when unknown.
+func (r AuthResponse) ExpiresAt() time.Time {
+	if r.ExpiresIn > 0 && !r.receivedAt.IsZero() {
+		return r.receivedAt.Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(r.AccessToken, &claims); err != nil {
+		return time.Time{}
+	}
+	if r.ExpiresIn > 0 && claims.IssuedAt != nil {
+		return claims.IssuedAt.Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	if claims.ExpiresAt != nil {
+		return claims.ExpiresAt.Time
+	}
+	return time.Time{}
+}
+
+// TimeToExpiry returns how long the access token remains valid: negative once it has
+// expired, zero when its expiry is unknown.
+func (r AuthResponse) TimeToExpiry() time.Duration {
+	return timeToExpiry(r.ExpiresAt())
+}
+
+// ShouldRefresh reports whether the access token expires within threshold and should be
+// refreshed now. A token with an unknown expiry should always be refreshed.
+func (r AuthResponse) ShouldRefresh(threshold time.Duration) bool {
+	return shouldRefresh(r.ExpiresAt(), threshold)
+}
+
+// TokenExpiresAt returns the token's exp, or zero when it has none. (ExpiresAt is the
+// subscription expiry.)
+func (c *Claims) TokenExpiresAt() time.Time {
+	if c.RegisteredClaims.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return c.RegisteredClaims.ExpiresAt.Time
+}
+
+// TimeToExpiry returns how long the token remains valid: negative once it has expired, zero
+// when it has no exp.
+func (c *Claims) TimeToExpiry() time.Duration {
+	return timeToExpiry(c.TokenExpiresAt())
+}
+
+// ShouldRefresh reports whether the token expires within threshold. A token without exp
+// never needs refreshing.
+func (c *Claims) ShouldRefresh(threshold time.Duration) bool {
+	exp := c.TokenExpiresAt()
+	return !exp.IsZero() && shouldRefresh(exp, threshold)
+}
+
+func timeToExpiry(expiresAt time.Time) time.Duration {
+	if expiresAt.IsZero() {
+		return 0
+	}
+	return time.Until(expiresAt)
+}
+
+func shouldRefresh(expiresAt time.Time, threshold time.Duration) bool {
+	return expiresAt.IsZero() || time.Until(expiresAt) < threshold
+}
@@ -0,0 +1,63 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestAuthResponseLifetime(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"opaque","expires_in":900}`))
+	}))
+	defer srv.Close()
+	received, err := NewClient(srv.URL, nil).Refresh(context.Background(), "rt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	sign := func(claims jwt.RegisteredClaims) string {
+		s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("k"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	tests := []struct {
+		name string
+		resp AuthResponse
+		want time.Duration // approximate time to expiry
+	}{
+		{"received by client", *received, 900 * time.Second},
+		{"stored, from iat", AuthResponse{AccessToken: sign(jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(now.Add(-100 * time.Second))}), ExpiresIn: 900}, 800 * time.Second},
+		{"from exp", AuthResponse{AccessToken: sign(jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(now.Add(-time.Minute))})}, -time.Minute},
+		{"unknown", AuthResponse{AccessToken: "opaque", ExpiresIn: 900}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.resp.TimeToExpiry(); got < tt.want-2*time.Second || got > tt.want+2*time.Second {
+			t.Errorf("%s: TimeToExpiry() = %v, want about %v", tt.name, got, tt.want)
+		}
+		wantRefresh := tt.want < 10*time.Minute
+		if got := tt.resp.ShouldRefresh(10 * time.Minute); got != wantRefresh {
+			t.Errorf("%s: ShouldRefresh(10m) = %v, want %v", tt.name, got, wantRefresh)
+		}
+	}
+}
+
+func TestClaimsLifetime(t *testing.T) {
+	c := &Claims{RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(5 * time.Minute))}}
+	if d := c.TimeToExpiry(); d < 4*time.Minute || d > 5*time.Minute {
+		t.Errorf("TimeToExpiry() = %v, want about 5m", d)
+	}
+	if c.ShouldRefresh(time.Minute) || !c.ShouldRefresh(10*time.Minute) {
+		t.Error("ShouldRefresh disagrees with a 5m remaining lifetime")
+	}
+	if (&Claims{}).ShouldRefresh(time.Hour) {
+		t.Error("a token without exp never needs refreshing")
+	}
+}
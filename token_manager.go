@@ -0,0 +1,542 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrSessionExpired is returned once the refresh token has been rejected or has expired; the
+// user (or service) must authenticate again. When the refresh token was replayed the error
+// also matches ErrTokenReused.
+var ErrSessionExpired = errors.New("session expired: re-authentication required")
+
+// TokenRefresher exchanges a refresh token for a new token set. *Client implements it.
+type TokenRefresher interface {
+	Refresh(ctx context.Context, refreshToken string) (*AuthResponse, error)
+}
+
+// TokenManagerConfig configures a TokenManager.
+type TokenManagerConfig struct {
+	// RefreshThreshold refreshes the access token once less than this much lifetime remains.
+	// Defaults to 30 seconds, and is capped at half the token's lifetime so short-lived tokens
+	// are still reused. Tokens issued without expires_in have no known lifetime: they are used
+	// until a resource server rejects them (see RefreshRejected).
+	RefreshThreshold time.Duration
+	// RefreshFraction enables background refresh once this fraction of the access token's
+	// lifetime has elapsed (e.g. 0.75), with jitter, so AccessToken never blocks on a refresh
+	// round trip. Zero disables background refresh. Call Stop to end the loop.
+	RefreshFraction float64
+	// MaxRefreshBackoff caps the retry delay after failed background refreshes. Defaults to 1 minute.
+	MaxRefreshBackoff time.Duration
+	// ManualRefresh leaves background refresh to Run, e.g. under a Runtime or an errgroup,
+	// instead of starting it in NewTokenManager.
+	ManualRefresh bool
+
+	// Callbacks run synchronously while the manager is locked: keep them short and do not call
+	// back into the TokenManager from them.
+	//
+	// OnRefresh is called after every successful refresh with the new token set.
+	OnRefresh func(*AuthResponse)
+	// OnTokensRotated is called after every successful refresh or session rotation with the
+	// replaced and the new token set, even when saving to Store failed. Persist the new set
+	// here when not using Store.
+	OnTokensRotated TokensRotatedFunc
+	// OnRefreshError is called when a refresh attempt (or persisting its result) fails.
+	OnRefreshError func(error)
+	// OnExpired is called once when the refresh token is rejected or expires. After that the
+	// manager returns ErrSessionExpired until SetTokens installs a new session.
+	OnExpired func(error)
+	// OnCompromised is called when auth-service reports that the refresh token was already
+	// used (ErrTokenReused). The session may have been stolen: the manager has wiped its
+	// tokens, locally and in Store, before calling it, and OnExpired has also fired. Use it
+	// to alert or force a fresh sign-in.
+	OnCompromised func(error)
+
+	// Store optionally persists the token set under StoreKey (a user or session ID). When the
+	// manager has no tokens it loads them from the store, and every new token set is saved, so
+	// rotated refresh tokens survive restarts. Before each refresh the manager reloads the
+	// store and adopts a set another replica has already rotated, so replicas can share a
+	// session; two replicas refreshing at the same instant can still race, which the
+	// background refresh jitter makes unlikely.
+	Store    TokenStore
+	StoreKey string
+}
+
+// TokenManager owns a user or service token set and hands out a valid access token,
+// refreshing through auth-service when the current one is close to expiry. It is safe for
+// concurrent use; consumers should not implement this state machine themselves.
+type TokenManager struct {
+	refresher TokenRefresher
+	config    TokenManagerConfig
+
+	mu               sync.Mutex
+	tokens           AuthResponse
+	issuedAt         time.Time // when the current access token was received
+	expiresAt        time.Time // access token expiry, derived from ExpiresIn at receipt
+	refreshExpiresAt time.Time // zero when auth-service did not report RefreshExpiresIn for the current refresh token
+	loaded           bool      // tokens were seeded or loaded from Store
+	expired          bool      // refresh token is dead; see ErrSessionExpired
+	refreshGroup     singleflight.Group
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	wake     chan struct{} // nudges the background loop when a new session is installed
+}
+
+// NewTokenManager creates a manager seeded with an initial token set (e.g. from Login).
+// When RefreshFraction is set, background refresh starts immediately; call Stop when done.
+func NewTokenManager(refresher TokenRefresher, initial *AuthResponse, config TokenManagerConfig) *TokenManager {
+	if config.RefreshThreshold <= 0 {
+		config.RefreshThreshold = 30 * time.Second
+	}
+	if config.MaxRefreshBackoff <= 0 {
+		config.MaxRefreshBackoff = time.Minute
+	}
+	m := &TokenManager{
+		refresher: refresher,
+		config:    config,
+		stop:      make(chan struct{}),
+		wake:      make(chan struct{}, 1),
+	}
+	if initial != nil {
+		m.setTokensLocked(initial, time.Now())
+		m.loaded = true
+	}
+	if m.backgroundRefresh() && !config.ManualRefresh {
+		ctx, cancel := untilClosed(context.Background(), m.stop)
+		go func() {
+			defer cancel()
+			m.refreshLoop(ctx)
+		}()
+	}
+	return m
+}
+
+// AccessToken returns a valid access token, refreshing first when it expires within
+// RefreshThreshold. If a refresh fails but the current token is still valid, the current token
+// is returned (and OnRefreshError fires); once expired, the refresh error is returned.
+//
+// When many goroutines need a refresh at once, exactly one request is sent and the rest wait
+// for its result (or for their own ctx), so auth-service's refresh-token rotation never sees
+// the same refresh token twice.
+func (m *TokenManager) AccessToken(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	if err := m.loadLocked(ctx); err != nil {
+		m.mu.Unlock()
+		return "", err
+	}
+	if m.expired {
+		m.mu.Unlock()
+		return "", ErrSessionExpired
+	}
+	if m.freshLocked(time.Now()) {
+		token := m.tokens.AccessToken
+		m.mu.Unlock()
+		return token, nil
+	}
+	m.mu.Unlock()
+
+	err := m.refresh(ctx, false)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		if !m.expired && m.tokens.AccessToken != "" && time.Now().Before(m.expiresAt) {
+			return m.tokens.AccessToken, nil
+		}
+		return "", err
+	}
+	return m.tokens.AccessToken, nil
+}
+
+// freshLocked reports whether the access token outlives RefreshThreshold, capped at half its
+// lifetime like ServiceTokenSource's. A token of unknown lifetime stays fresh until
+// RefreshRejected replaces it. m.mu must be held.
+func (m *TokenManager) freshLocked(now time.Time) bool {
+	if m.tokens.AccessToken == "" {
+		return false
+	}
+	if m.expiresAt.IsZero() {
+		return true
+	}
+	threshold := min(m.config.RefreshThreshold, m.expiresAt.Sub(m.issuedAt)/2)
+	return now.Add(threshold).Before(m.expiresAt)
+}
+
+// RefreshRejected refreshes after a resource server answered 401 to rejected, e.g. because a
+// token issued without expires_in has expired, and returns the new access token. When
+// rejected has already been replaced, by a concurrent call or a scheduled refresh, the
+// current token is returned without another round trip.
+func (m *TokenManager) RefreshRejected(ctx context.Context, rejected string) (string, error) {
+	m.mu.Lock()
+	if err := m.loadLocked(ctx); err != nil {
+		m.mu.Unlock()
+		return "", err
+	}
+	if m.expired {
+		m.mu.Unlock()
+		return "", ErrSessionExpired
+	}
+	if m.tokens.AccessToken != rejected {
+		token := m.tokens.AccessToken
+		m.mu.Unlock()
+		return token, nil
+	}
+	m.mu.Unlock()
+
+	if err := m.refresh(ctx, true); err != nil {
+		return "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tokens.AccessToken, nil
+}
+
+// refresh joins (or starts) the single in-flight refresh. The shared request runs on its own
+// timeout so one caller cancelling does not fail the others; ctx only bounds this caller's wait.
+func (m *TokenManager) refresh(ctx context.Context, force bool) error {
+	ch := m.refreshGroup.DoChan("refresh", func() (any, error) {
+		rctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return nil, m.doRefresh(rctx, force)
+	})
+	select {
+	case res := <-ch:
+		return res.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// doRefresh performs one refresh round trip without holding m.mu across the network call.
+// Unless forced, it re-checks freshness first: a caller that saw a stale token just before a
+// previous flight finished must not spend the freshly rotated refresh token again.
+func (m *TokenManager) doRefresh(ctx context.Context, force bool) error {
+	m.mu.Lock()
+	if m.expired {
+		m.mu.Unlock()
+		return ErrSessionExpired
+	}
+	if m.syncStoreLocked(ctx) && m.freshLocked(time.Now()) {
+		// Another replica refreshed; its token set is as good as a new one.
+		m.mu.Unlock()
+		return nil
+	}
+	if !force && m.freshLocked(time.Now()) {
+		m.mu.Unlock()
+		return nil
+	}
+	if m.tokens.RefreshToken == "" {
+		m.mu.Unlock()
+		return fmt.Errorf("token manager: access token expired and no refresh token available")
+	}
+	if !m.refreshExpiresAt.IsZero() && !time.Now().Before(m.refreshExpiresAt) {
+		defer m.mu.Unlock()
+		m.markExpiredLocked(fmt.Errorf("refresh token expired at %s", m.refreshExpiresAt.Format(time.RFC3339)))
+		return ErrSessionExpired
+	}
+	refreshToken := m.tokens.RefreshToken
+	m.mu.Unlock()
+
+	resp, err := m.refresher.Refresh(ctx, refreshToken)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.expired {
+		// Destroy ran while the request was in flight; do not resurrect the session.
+		return ErrSessionExpired
+	}
+	if err != nil {
+		if m.config.OnRefreshError != nil {
+			m.config.OnRefreshError(err)
+		}
+		if errors.Is(err, ErrTokenReused) {
+			m.compromisedLocked(ctx, err)
+			return fmt.Errorf("%w: %w", ErrSessionExpired, err)
+		}
+		if refreshTokenRejected(err) {
+			m.markExpiredLocked(err)
+			return ErrSessionExpired
+		}
+		return fmt.Errorf("token manager: refresh: %w", err)
+	}
+	if m.tokens.RefreshToken != refreshToken {
+		// SetTokens installed a new session while we were refreshing the old one.
+		return nil
+	}
+
+	old := m.tokens
+	m.setTokensLocked(resp, time.Now())
+	if err := m.saveLocked(ctx); err != nil && m.config.OnRefreshError != nil {
+		m.config.OnRefreshError(err)
+	}
+	if m.config.OnRefresh != nil {
+		m.config.OnRefresh(resp)
+	}
+	if m.config.OnTokensRotated != nil {
+		m.config.OnTokensRotated(old, m.tokens)
+	}
+	return nil
+}
+
+// refreshTokenRejected reports whether auth-service definitively refused the refresh token
+// (as opposed to a transient failure worth retrying).
+func refreshTokenRejected(err error) bool {
+	var authErr *Error
+	if !errors.As(err, &authErr) {
+		return false
+	}
+	return authErr.ErrorField == "invalid_grant" ||
+		authErr.StatusCode == http.StatusUnauthorized ||
+		authErr.StatusCode == http.StatusBadRequest
+}
+
+// compromisedLocked ends a session whose refresh token auth-service saw replayed. Unlike a
+// plain expiry, the tokens are wiped so nothing can keep using them.
+func (m *TokenManager) compromisedLocked(ctx context.Context, cause error) {
+	if err := m.wipeLocked(ctx); err != nil && m.config.OnRefreshError != nil {
+		m.config.OnRefreshError(err)
+	}
+	m.markExpiredLocked(cause)
+	if m.config.OnCompromised != nil {
+		m.config.OnCompromised(cause)
+	}
+}
+
+func (m *TokenManager) markExpiredLocked(cause error) {
+	if m.expired {
+		return
+	}
+	m.expired = true
+	if m.config.OnExpired != nil {
+		m.config.OnExpired(cause)
+	}
+}
+
+// refreshLoop refreshes at RefreshFraction of each token's lifetime (minus up to 5% jitter so
+// replicas sharing a session don't refresh in lockstep), backing off exponentially on failure.
+func (m *TokenManager) refreshLoop(ctx context.Context) {
+	var backoff time.Duration
+	for {
+		wait := m.nextRefreshIn()
+		if backoff > 0 {
+			wait = backoff
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-m.wake:
+			timer.Stop()
+			backoff = 0
+			continue
+		case <-timer.C:
+		}
+
+		// Never abandon a refresh half-way: auth-service may already have rotated the
+		// refresh token, and the response is the only copy of the new one.
+		err := m.refresh(context.WithoutCancel(ctx), true)
+		switch {
+		case err == nil:
+			backoff = 0
+		case errors.Is(err, ErrSessionExpired):
+			backoff = 0 // idle until SetTokens wakes us
+		case backoff == 0:
+			backoff = time.Second
+		default:
+			backoff = min(backoff*2, m.config.MaxRefreshBackoff)
+		}
+	}
+}
+
+// nextRefreshIn returns how long the background loop should sleep before refreshing.
+func (m *TokenManager) nextRefreshIn() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	const idle = time.Hour // nothing to refresh; SetTokens wakes the loop early
+	if m.loadLocked(context.Background()) != nil || m.expired || m.tokens.RefreshToken == "" {
+		return idle
+	}
+	lifetime := m.expiresAt.Sub(m.issuedAt)
+	if lifetime <= 0 {
+		// No expires_in: there is no lifetime to schedule against, and waiting zero would
+		// refresh back-to-back. Callers still refresh on demand.
+		return idle
+	}
+	at := m.issuedAt.Add(time.Duration(float64(lifetime)*m.config.RefreshFraction) - jitter(lifetime/20))
+	return max(time.Until(at), 0)
+}
+
+// Stop ends background refresh. It is safe to call more than once.
+func (m *TokenManager) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+// Close ends background refresh, like Stop; it implements Runnable. The tokens stay usable.
+func (m *TokenManager) Close() error {
+	m.Stop()
+	return nil
+}
+
+// Run refreshes in the background (when RefreshFraction is set) until ctx is cancelled or
+// Close is called, then stops. It returns ctx.Err(), which is nil after Close. Without
+// ManualRefresh, NewTokenManager has already started refreshing and Run only ties that loop
+// to ctx.
+func (m *TokenManager) Run(ctx context.Context) error {
+	defer m.Stop()
+	ctx, cancel := untilClosed(ctx, m.stop)
+	defer cancel()
+	if m.backgroundRefresh() && m.config.ManualRefresh {
+		m.refreshLoop(ctx)
+	} else {
+		<-ctx.Done()
+	}
+	return runErr(ctx, m.stop)
+}
+
+func (m *TokenManager) backgroundRefresh() bool {
+	return m.config.RefreshFraction > 0 && m.config.RefreshFraction < 1
+}
+
+// Tokens returns a copy of the current token set.
+func (m *TokenManager) Tokens() AuthResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tokens
+}
+
+// ExpiresAt returns the current access token's expiry, or zero when auth-service did not
+// report its lifetime.
+func (m *TokenManager) ExpiresAt() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.expiresAt
+}
+
+// Destroy ends the session locally: it stops background refresh, wipes the in-memory tokens
+// and deletes them from Store. Afterwards AccessToken returns ErrSessionExpired. Use it on
+// logout and when credentials may be compromised (see Client.LogoutAll).
+func (m *TokenManager) Destroy(ctx context.Context) error {
+	m.Stop()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.wipeLocked(ctx)
+	m.expired = true
+	return err
+}
+
+// wipeLocked clears the token set from memory and Store.
+func (m *TokenManager) wipeLocked(ctx context.Context) error {
+	m.tokens = AuthResponse{}
+	m.issuedAt, m.expiresAt, m.refreshExpiresAt = time.Time{}, time.Time{}, time.Time{}
+	m.loaded = true // do not reload the deleted tokens from Store
+	if m.config.Store == nil {
+		return nil
+	}
+	if err := m.config.Store.Delete(ctx, m.config.StoreKey); err != nil {
+		return fmt.Errorf("token manager: delete tokens: %w", err)
+	}
+	return nil
+}
+
+// SetTokens replaces the managed token set, e.g. after a fresh Login, and persists it when a
+// Store is configured. It also clears an expired session.
+func (m *TokenManager) SetTokens(ctx context.Context, resp *AuthResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.setTokensLocked(resp, time.Now())
+	m.loaded = true
+	m.expired = false
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+	return m.saveLocked(ctx)
+}
+
+// loadLocked seeds the manager from Store the first time tokens are needed.
+func (m *TokenManager) loadLocked(ctx context.Context) error {
+	if m.loaded || m.config.Store == nil {
+		return nil
+	}
+	stored, err := m.config.Store.Load(ctx, m.config.StoreKey)
+	if err != nil {
+		return fmt.Errorf("token manager: load tokens: %w", err)
+	}
+	m.setStoredLocked(stored)
+	m.loaded = true
+	return nil
+}
+
+// syncStoreLocked adopts the stored token set when another replica sharing StoreKey has
+// rotated the refresh token since this manager last saw it, and reports whether it did. A
+// failed load is ignored: the refresh then proceeds with the tokens in memory.
+func (m *TokenManager) syncStoreLocked(ctx context.Context) bool {
+	if m.config.Store == nil {
+		return false
+	}
+	stored, err := m.config.Store.Load(ctx, m.config.StoreKey)
+	if err != nil || stored.Tokens.RefreshToken == "" || stored.Tokens.RefreshToken == m.tokens.RefreshToken {
+		return false
+	}
+	m.setStoredLocked(stored)
+	return true
+}
+
+func (m *TokenManager) setStoredLocked(stored *StoredTokens) {
+	m.tokens = stored.Tokens
+	m.expiresAt = stored.ExpiresAt
+	m.refreshExpiresAt = stored.RefreshExpiresAt
+	m.issuedAt = stored.ExpiresAt.Add(-time.Duration(stored.Tokens.ExpiresIn) * time.Second)
+	if stored.Tokens.ExpiresIn <= 0 {
+		m.expiresAt = time.Time{} // lifetime unknown, not already expired
+	}
+}
+
+// saveLocked persists the current token set when a Store is configured.
+func (m *TokenManager) saveLocked(ctx context.Context) error {
+	if m.config.Store == nil {
+		return nil
+	}
+	err := m.config.Store.Save(ctx, m.config.StoreKey, &StoredTokens{
+		Tokens:           m.tokens,
+		ExpiresAt:        m.expiresAt,
+		RefreshExpiresAt: m.refreshExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("token manager: persist tokens: %w", err)
+	}
+	return nil
+}
+
+func (m *TokenManager) setTokensLocked(resp *AuthResponse, receivedAt time.Time) {
+	next := *resp
+	if next.RefreshToken == "" {
+		// Some refresh responses omit the refresh token when it is not rotated.
+		next.RefreshToken = m.tokens.RefreshToken
+	}
+	m.tokens = next
+	m.issuedAt = receivedAt
+	m.expiresAt = time.Time{} // lifetime unknown, not already expired
+	if resp.ExpiresIn > 0 {
+		m.expiresAt = receivedAt.Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+	switch {
+	case resp.RefreshExpiresIn > 0:
+		m.refreshExpiresAt = receivedAt.Add(time.Duration(resp.RefreshExpiresIn) * time.Second)
+	case resp.RefreshToken != "":
+		// A new refresh token of unknown lifetime; the old token's expiry no longer applies.
+		m.refreshExpiresAt = time.Time{}
+	}
+}
@@ -0,0 +1,285 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeRefresher struct {
+	calls atomic.Int32
+	delay time.Duration
+	resp  *AuthResponse
+	err   error
+}
+
+func (f *fakeRefresher) Refresh(ctx context.Context, refreshToken string) (*AuthResponse, error) {
+	f.calls.Add(1)
+	time.Sleep(f.delay)
+	return f.resp, f.err
+}
+
+// backdate ages m's token set by d, as if it had been issued d ago.
+func backdate(m *TokenManager, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.issuedAt = m.issuedAt.Add(-d)
+	m.expiresAt = m.expiresAt.Add(-d)
+}
+
+func TestTokenManagerRefreshesNearExpiry(t *testing.T) {
+	r := &fakeRefresher{resp: &AuthResponse{AccessToken: "new", RefreshToken: "r2", ExpiresIn: 900}}
+	m := NewTokenManager(r, &AuthResponse{AccessToken: "old", RefreshToken: "r1", ExpiresIn: 10}, TokenManagerConfig{})
+	backdate(m, 8*time.Second) // 2s of 10s left
+
+	tok, err := m.AccessToken(context.Background())
+	if err != nil || tok != "new" || r.calls.Load() != 1 {
+		t.Fatalf("AccessToken() = %q, %v after %d refreshes; want new after 1", tok, err, r.calls.Load())
+	}
+	if tok, _ := m.AccessToken(context.Background()); tok != "new" || r.calls.Load() != 1 {
+		t.Fatalf("fresh token should be served without refreshing, got %q after %d calls", tok, r.calls.Load())
+	}
+	if got := m.Tokens().RefreshToken; got != "r2" {
+		t.Fatalf("refresh token = %q, want rotated r2", got)
+	}
+}
+
+func TestTokenManagerKeepsValidTokenOnRefreshFailure(t *testing.T) {
+	var reported error
+	r := &fakeRefresher{err: errors.New("auth-service down")}
+	m := NewTokenManager(r, &AuthResponse{AccessToken: "old", RefreshToken: "r1", ExpiresIn: 10}, TokenManagerConfig{
+		OnRefreshError: func(err error) { reported = err },
+	})
+	backdate(m, 8*time.Second) // 2s of 10s left
+
+	tok, err := m.AccessToken(context.Background())
+	if err != nil || tok != "old" {
+		t.Fatalf("AccessToken() = %q, %v; want still-valid old token", tok, err)
+	}
+	if reported == nil {
+		t.Fatal("expected OnRefreshError to fire")
+	}
+}
+
+func TestTokenManagerConcurrentCallersShareOneRefresh(t *testing.T) {
+	r := &fakeRefresher{delay: 50 * time.Millisecond, resp: &AuthResponse{AccessToken: "new", RefreshToken: "r2", ExpiresIn: 900}}
+	m := NewTokenManager(r, &AuthResponse{AccessToken: "old", RefreshToken: "r1", ExpiresIn: 10}, TokenManagerConfig{})
+	backdate(m, 8*time.Second) // 2s of 10s left
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if tok, err := m.AccessToken(context.Background()); err != nil || tok != "new" {
+				t.Errorf("AccessToken() = %q, %v; want new", tok, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := r.calls.Load(); n != 1 {
+		t.Fatalf("refresh called %d times, want 1", n)
+	}
+}
+
+func TestTokenManagerDestroyPurgesTokens(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+	r := &fakeRefresher{resp: &AuthResponse{AccessToken: "new", RefreshToken: "r2", ExpiresIn: 900}}
+	m := NewTokenManager(r, nil, TokenManagerConfig{Store: store, StoreKey: "user-1"})
+	if err := m.SetTokens(ctx, &AuthResponse{AccessToken: "a1", RefreshToken: "r1", ExpiresIn: 900}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Destroy(ctx); err != nil {
+		t.Fatalf("Destroy() = %v", err)
+	}
+	if _, err := m.AccessToken(ctx); !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("AccessToken() after Destroy = %v, want ErrSessionExpired", err)
+	}
+	if _, err := store.Load(ctx, "user-1"); !errors.Is(err, ErrTokensNotFound) {
+		t.Fatalf("store still holds tokens after Destroy: %v", err)
+	}
+}
+
+func TestTokenManagerRefreshTokenReuse(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid_grant","error_code":"refresh_token_reused"}`))
+	}))
+	defer srv.Close()
+
+	var compromised error
+	m := NewTokenManager(NewClient(srv.URL, nil), &AuthResponse{AccessToken: "old", RefreshToken: "r1", ExpiresIn: 10}, TokenManagerConfig{
+		OnCompromised: func(err error) { compromised = err },
+	})
+	backdate(m, 8*time.Second) // 2s of 10s left
+
+	// Even though "old" is still valid it must not be handed out once the family is revoked.
+	_, err := m.AccessToken(context.Background())
+	if !errors.Is(err, ErrTokenReused) || !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("AccessToken() err = %v, want ErrTokenReused and ErrSessionExpired", err)
+	}
+	if KindOf(compromised) != KindTokenReused {
+		t.Fatalf("OnCompromised got %v", compromised)
+	}
+	if tokens := m.Tokens(); tokens.AccessToken != "" || tokens.RefreshToken != "" {
+		t.Fatalf("tokens not wiped: %+v", tokens)
+	}
+	if _, err := m.AccessToken(context.Background()); !errors.Is(err, ErrSessionExpired) || calls.Load() != 1 {
+		t.Fatalf("second AccessToken() = %v after %d refreshes; want ErrSessionExpired without retrying", err, calls.Load())
+	}
+}
+
+func TestTokenManagerRefreshExpiry(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     *AuthResponse
+		wantZero bool
+	}{
+		{"rotated with lifetime", &AuthResponse{AccessToken: "new", RefreshToken: "r2", ExpiresIn: 900, RefreshExpiresIn: 3600}, false},
+		{"rotated without lifetime", &AuthResponse{AccessToken: "new", RefreshToken: "r2", ExpiresIn: 900}, true},
+		{"not rotated", &AuthResponse{AccessToken: "new", ExpiresIn: 900}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewTokenManager(&fakeRefresher{resp: tt.resp}, &AuthResponse{AccessToken: "old", RefreshToken: "r1", ExpiresIn: 10, RefreshExpiresIn: 60}, TokenManagerConfig{})
+			backdate(m, 8*time.Second) // 2s of 10s left
+			if _, err := m.AccessToken(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			m.mu.Lock()
+			got := m.refreshExpiresAt
+			m.mu.Unlock()
+			if got.IsZero() != tt.wantZero {
+				t.Fatalf("refreshExpiresAt = %v, want zero %v", got, tt.wantZero)
+			}
+		})
+	}
+}
+
+func TestTokenManagerAdoptsTokensRotatedByReplica(t *testing.T) {
+	store := NewMemoryTokenStore()
+	initial := &AuthResponse{AccessToken: "old", RefreshToken: "r1", ExpiresIn: 10}
+	config := TokenManagerConfig{Store: store, StoreKey: "user-1"}
+	ra := &fakeRefresher{resp: &AuthResponse{AccessToken: "new", RefreshToken: "r2", ExpiresIn: 900}}
+	rb := &fakeRefresher{err: errors.New("refresh token r1 already used")}
+	a := NewTokenManager(ra, initial, config)
+	b := NewTokenManager(rb, initial, config)
+	backdate(a, 8*time.Second) // 2s of 10s left
+	backdate(b, 8*time.Second)
+
+	if tok, err := a.AccessToken(context.Background()); err != nil || tok != "new" {
+		t.Fatalf("replica A: AccessToken() = %q, %v", tok, err)
+	}
+	tok, err := b.AccessToken(context.Background())
+	if err != nil || tok != "new" || rb.calls.Load() != 0 {
+		t.Fatalf("replica B: AccessToken() = %q, %v after %d refreshes; want A's token without refreshing", tok, err, rb.calls.Load())
+	}
+	if got := b.Tokens().RefreshToken; got != "r2" {
+		t.Fatalf("replica B refresh token = %q, want r2", got)
+	}
+}
+
+func TestTokenManagerBackgroundRefresh(t *testing.T) {
+	tests := []struct {
+		name        string
+		refresher   *fakeRefresher
+		wantToken   string
+		wantExpired bool
+	}{
+		{"refreshes before expiry", &fakeRefresher{resp: &AuthResponse{AccessToken: "new", RefreshToken: "r2", ExpiresIn: 900}}, "new", false},
+		{"refresh token rejected", &fakeRefresher{err: &Error{ErrorField: "invalid_grant", StatusCode: http.StatusBadRequest}}, "old", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired := make(chan error, 1)
+			m := NewTokenManager(tt.refresher, &AuthResponse{AccessToken: "old", RefreshToken: "r1", ExpiresIn: 1}, TokenManagerConfig{
+				RefreshFraction:  0.5,
+				RefreshThreshold: time.Millisecond,
+				OnExpired:        func(err error) { expired <- err },
+			})
+			defer m.Stop()
+
+			// The loop refreshes at about half the one-second lifetime, without any caller.
+			deadline := time.Now().Add(2 * time.Second)
+			for tt.refresher.calls.Load() == 0 && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			if tt.refresher.calls.Load() == 0 {
+				t.Fatal("background loop did not refresh")
+			}
+			if tt.wantExpired {
+				select {
+				case <-expired:
+				case <-time.After(time.Second):
+					t.Fatal("OnExpired did not fire")
+				}
+				if _, err := m.AccessToken(context.Background()); !errors.Is(err, ErrSessionExpired) {
+					t.Fatalf("AccessToken() err = %v, want ErrSessionExpired", err)
+				}
+				return
+			}
+			deadline = time.Now().Add(time.Second)
+			for m.Tokens().AccessToken != tt.wantToken && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			if tok, err := m.AccessToken(context.Background()); err != nil || tok != tt.wantToken || tt.refresher.calls.Load() != 1 {
+				t.Fatalf("AccessToken() = %q, %v after %d refreshes; want %q after 1", tok, err, tt.refresher.calls.Load(), tt.wantToken)
+			}
+		})
+	}
+}
+
+func TestTokenManagerBackgroundRefreshIdlesWithoutLifetime(t *testing.T) {
+	r := &fakeRefresher{resp: &AuthResponse{AccessToken: "new", RefreshToken: "r2"}}
+	m := NewTokenManager(r, &AuthResponse{AccessToken: "old", RefreshToken: "r1"}, TokenManagerConfig{RefreshFraction: 0.5})
+	defer m.Stop()
+
+	if wait := m.nextRefreshIn(); wait < time.Minute {
+		t.Fatalf("nextRefreshIn() = %v for a zero-lifetime token set, want idle", wait)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if n := r.calls.Load(); n != 0 {
+		t.Fatalf("background loop refreshed %d times without a token lifetime", n)
+	}
+}
+
+func TestTokenManagerReusesShortLivedTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn int
+	}{
+		{"lifetime below RefreshThreshold", 20},
+		{"no expires_in", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &fakeRefresher{resp: &AuthResponse{AccessToken: "new", RefreshToken: "r2", ExpiresIn: tt.expiresIn}}
+			m := NewTokenManager(r, &AuthResponse{AccessToken: "old", RefreshToken: "r1", ExpiresIn: tt.expiresIn}, TokenManagerConfig{})
+
+			// The first rejection refreshes; every later call reuses the new token.
+			if tok, err := m.RefreshRejected(context.Background(), "old"); err != nil || tok != "new" {
+				t.Fatalf("RefreshRejected() = %q, %v; want new", tok, err)
+			}
+			for range 5 {
+				if tok, err := m.AccessToken(context.Background()); err != nil || tok != "new" {
+					t.Fatalf("AccessToken() = %q, %v; want new", tok, err)
+				}
+			}
+			if tok, err := m.RefreshRejected(context.Background(), "old"); err != nil || tok != "new" {
+				t.Fatalf("stale RefreshRejected() = %q, %v; want the current token", tok, err)
+			}
+			if n := r.calls.Load(); n != 1 {
+				t.Fatalf("refreshed %d times, want 1", n)
+			}
+		})
+	}
+}
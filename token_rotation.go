@@ -0,0 +1,30 @@
+package authclient
+
+// TokensRotatedFunc receives the token set a refresh replaced and its replacement. The old
+// refresh token is spent: persist next before anything else uses the stored tokens, or the
+// user is signed out on the next refresh. next.RefreshToken is never empty; when
+// auth-service did not rotate it, it is the old one.
+type TokensRotatedFunc func(old, next AuthResponse)
+
+// WithOnTokensRotated calls fn after every successful Refresh, RefreshWith and
+// RotateSession, so a service cannot forget to persist a rotated refresh token wherever it
+// refreshes. The client does not know the rest of the previous token set: old carries only
+// the spent RefreshToken. TokenManagerConfig.OnTokensRotated receives the complete old set.
+// fn runs synchronously on the calling goroutine.
+func WithOnTokensRotated(fn TokensRotatedFunc) ClientOption {
+	return func(c *Client) {
+		c.onTokensRotated = fn
+	}
+}
+
+// tokensRotated reports a successful refresh of oldRefreshToken to the WithOnTokensRotated hook.
+func (c *Client) tokensRotated(oldRefreshToken string, resp *AuthResponse) {
+	if c.onTokensRotated == nil {
+		return
+	}
+	next := *resp
+	if next.RefreshToken == "" {
+		next.RefreshToken = oldRefreshToken
+	}
+	c.onTokensRotated(AuthResponse{RefreshToken: oldRefreshToken}, next)
+}
@@ -0,0 +1,40 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOnTokensRotated(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RefreshRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(AuthResponse{AccessToken: "access-2", RefreshToken: req.RefreshToken + "-next", ExpiresIn: 900})
+	}))
+	defer srv.Close()
+
+	var clientOld, clientNew AuthResponse
+	c := NewClient(srv.URL, nil, WithOnTokensRotated(func(old, next AuthResponse) {
+		clientOld, clientNew = old, next
+	}))
+	var managerOld, managerNew AuthResponse
+	m := NewTokenManager(c, &AuthResponse{AccessToken: "access-1", RefreshToken: "refresh-1", SessionID: "s1"}, TokenManagerConfig{
+		OnTokensRotated: func(old, next AuthResponse) { managerOld, managerNew = old, next },
+	})
+	defer m.Stop()
+
+	// access-1 has no known lifetime, so it is refreshed once a resource server rejects it.
+	token, err := m.RefreshRejected(context.Background(), "access-1")
+	if err != nil || token != "access-2" {
+		t.Fatalf("RefreshRejected = %q, %v", token, err)
+	}
+	if clientOld.RefreshToken != "refresh-1" || clientOld.AccessToken != "" || clientNew.RefreshToken != "refresh-1-next" {
+		t.Errorf("client hook: old %+v, new %+v", clientOld, clientNew)
+	}
+	if managerOld.AccessToken != "access-1" || managerOld.SessionID != "s1" || managerNew.RefreshToken != "refresh-1-next" {
+		t.Errorf("manager hook: old %+v, new %+v", managerOld, managerNew)
+	}
+}
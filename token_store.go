@@ -0,0 +1,78 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTokensNotFound is returned by TokenStore.Load when nothing is stored under the key.
+var ErrTokensNotFound = errors.New("tokens not found")
+
+// StoredTokens is a persisted token set with absolute expiries, so it survives restarts.
+type StoredTokens struct {
+	Tokens           AuthResponse `json:"tokens"`
+	ExpiresAt        time.Time    `json:"expires_at"`
+	RefreshExpiresAt time.Time    `json:"refresh_expires_at,omitempty"`
+}
+
+// NewStoredTokens converts a token response received at receivedAt into absolute expiries.
+// RefreshExpiresAt stays zero when auth-service did not report a refresh lifetime.
+func NewStoredTokens(resp *AuthResponse, receivedAt time.Time) *StoredTokens {
+	stored := &StoredTokens{Tokens: *resp, ExpiresAt: receivedAt.Add(time.Duration(resp.ExpiresIn) * time.Second)}
+	if resp.RefreshExpiresIn > 0 {
+		stored.RefreshExpiresAt = receivedAt.Add(time.Duration(resp.RefreshExpiresIn) * time.Second)
+	}
+	return stored
+}
+
+// lifetime returns how long the access token was issued for.
+func (t *StoredTokens) lifetime() time.Duration {
+	return time.Duration(t.Tokens.ExpiresIn) * time.Second
+}
+
+// TokenStore persists token sets keyed by user or session, for TokenManager.
+type TokenStore interface {
+	Save(ctx context.Context, key string, tokens *StoredTokens) error
+	Load(ctx context.Context, key string) (*StoredTokens, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryTokenStore is an in-process TokenStore, suitable for single-replica services and tests.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]StoredTokens
+}
+
+// NewMemoryTokenStore creates an empty in-memory store.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]StoredTokens)}
+}
+
+// Save stores a copy of tokens under key.
+func (s *MemoryTokenStore) Save(ctx context.Context, key string, tokens *StoredTokens) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[key] = *tokens
+	return nil
+}
+
+// Load returns a copy of the tokens stored under key.
+func (s *MemoryTokenStore) Load(ctx context.Context, key string) (*StoredTokens, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.tokens[key]
+	if !ok {
+		return nil, ErrTokensNotFound
+	}
+	return &t, nil
+}
+
+// Delete removes the tokens stored under key.
+func (s *MemoryTokenStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, key)
+	return nil
+}
@@ -0,0 +1,111 @@
+package authclient
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileTokenStore persists token sets as AES-256-GCM encrypted files (one per key, mode 0600),
+// for CLI tools and single-host daemons.
+type FileTokenStore struct {
+	dir  string
+	aead cipher.AEAD
+}
+
+// NewFileTokenStore creates a store under dir, encrypting with a 32-byte key.
+func NewFileTokenStore(dir string, key []byte) (*FileTokenStore, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("file token store: key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("file token store: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("file token store: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("file token store: create dir: %w", err)
+	}
+	return &FileTokenStore{dir: dir, aead: aead}, nil
+}
+
+// path hashes the key so user or session identifiers never appear in file names.
+func (s *FileTokenStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".tok")
+}
+
+// Save encrypts and atomically writes tokens for key.
+func (s *FileTokenStore) Save(ctx context.Context, key string, tokens *StoredTokens) error {
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("file token store: marshal: %w", err)
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("file token store: nonce: %w", err)
+	}
+	// The key is bound as associated data so files cannot be swapped between keys.
+	sealed := s.aead.Seal(nonce, nonce, plaintext, []byte(key))
+
+	tmp, err := os.CreateTemp(s.dir, ".tok-*")
+	if err != nil {
+		return fmt.Errorf("file token store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(sealed); err != nil {
+		tmp.Close()
+		return fmt.Errorf("file token store: write: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("file token store: write: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("file token store: %w", err)
+	}
+	return nil
+}
+
+// Load reads and decrypts the tokens for key.
+func (s *FileTokenStore) Load(ctx context.Context, key string) (*StoredTokens, error) {
+	sealed, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrTokensNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("file token store: read: %w", err)
+	}
+	n := s.aead.NonceSize()
+	if len(sealed) < n {
+		return nil, fmt.Errorf("file token store: corrupt file")
+	}
+	plaintext, err := s.aead.Open(nil, sealed[:n], sealed[n:], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("file token store: decrypt: %w", err)
+	}
+	var tokens StoredTokens
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("file token store: unmarshal: %w", err)
+	}
+	return &tokens, nil
+}
+
+// Delete removes the file for key. Deleting a missing key is not an error.
+func (s *FileTokenStore) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("file token store: %w", err)
+	}
+	return nil
+}
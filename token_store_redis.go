@@ -0,0 +1,70 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisTokenStore shares token sets across replicas through Redis. Entries expire with the
+// refresh token when its expiry is known. Tokens are stored as plain JSON, so the Redis
+// instance must be treated as secret storage.
+type RedisTokenStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisTokenStore creates a store using keys "<prefix><key>" (prefix defaults to "tokens:").
+func NewRedisTokenStore(client *redis.Client, prefix string) *RedisTokenStore {
+	if prefix == "" {
+		prefix = "tokens:"
+	}
+	return &RedisTokenStore{client: client, prefix: prefix}
+}
+
+// Save stores tokens under key.
+func (s *RedisTokenStore) Save(ctx context.Context, key string, tokens *StoredTokens) error {
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("redis token store: marshal: %w", err)
+	}
+	var ttl time.Duration
+	if !tokens.RefreshExpiresAt.IsZero() {
+		ttl = time.Until(tokens.RefreshExpiresAt)
+		if ttl <= 0 {
+			return s.Delete(ctx, key)
+		}
+	}
+	if err := s.client.Set(ctx, s.prefix+key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("redis token store: %w", err)
+	}
+	return nil
+}
+
+// Load returns the tokens stored under key.
+func (s *RedisTokenStore) Load(ctx context.Context, key string) (*StoredTokens, error) {
+	data, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrTokensNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis token store: %w", err)
+	}
+	var tokens StoredTokens
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("redis token store: unmarshal: %w", err)
+	}
+	return &tokens, nil
+}
+
+// Delete removes the tokens stored under key.
+func (s *RedisTokenStore) Delete(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("redis token store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package authclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TokenKind classifies a JWT by its intended use.
+type TokenKind string
+
+const (
+	TokenKindUnknown TokenKind = ""        // no type information (legacy auth-service tokens)
+	TokenKindAccess  TokenKind = "access"  // bearer access token; the only kind APIs should accept
+	TokenKindRefresh TokenKind = "refresh" // refresh token; must only ever be sent to auth-service
+	TokenKindID      TokenKind = "id"      // OIDC ID token; identifies the user to the client app
+)
+
+// accessTokenHeaderTypes are the RFC 9068 "typ" header values for JWT access tokens.
+var accessTokenHeaderTypes = map[string]struct{}{
+	"at+jwt":             {},
+	"application/at+jwt": {},
+}
+
+// TokenKind reports the token's declared kind from its token_use (Cognito/auth-service) or
+// typ (Keycloak) claim. TokenKindUnknown means the token does not declare one.
+func (c *Claims) TokenKind() TokenKind {
+	switch strings.ToLower(c.TokenUse) {
+	case "access":
+		return TokenKindAccess
+	case "refresh":
+		return TokenKindRefresh
+	case "id":
+		return TokenKindID
+	}
+	switch strings.ToLower(c.Type) {
+	case "bearer", "access":
+		return TokenKindAccess
+	case "refresh", "offline":
+		return TokenKindRefresh
+	case "id":
+		return TokenKindID
+	}
+	return TokenKindUnknown
+}
+
+// resolveTokenKind combines the JOSE "typ" header with the claims. An RFC 9068 at+jwt header
+// marks an access token; a generic "JWT" (or absent) header defers to the claims. A token
+// whose at+jwt header contradicts a refresh or ID token_use/typ claim is rejected rather
+// than trusted either way.
+func resolveTokenKind(headerTyp any, claims *Claims) (TokenKind, error) {
+	kind := claims.TokenKind()
+	if typ, ok := headerTyp.(string); ok {
+		if _, isAccess := accessTokenHeaderTypes[strings.ToLower(typ)]; isAccess {
+			if kind != TokenKindAccess && kind != TokenKindUnknown {
+				return kind, newAuthError(KindClaimsInvalid, fmt.Sprintf("token typ %q contradicts token type %s", typ, kind), nil)
+			}
+			return TokenKindAccess, nil
+		}
+	}
+	return kind, nil
+}
+
+// checkAccessTokenProfile enforces RFC 9068 section 2: the at+jwt typ header and the
+// mandatory claims. It runs on the claims as issued, before any ClaimsMapper.
+func checkAccessTokenProfile(headerTyp any, claims *Claims) error {
+	typ, _ := headerTyp.(string)
+	if _, ok := accessTokenHeaderTypes[strings.ToLower(typ)]; !ok {
+		return newAuthError(KindClaimsInvalid, fmt.Sprintf("token typ %q is not at+jwt: access token required", typ), nil)
+	}
+	var missing []string
+	for _, c := range []struct {
+		name    string
+		present bool
+	}{
+		{"iss", claims.Issuer != ""},
+		{"exp", claims.RegisteredClaims.ExpiresAt != nil},
+		{"aud", len(claims.Audience) > 0},
+		{"sub", claims.Subject != ""},
+		{"client_id", hasStringClaim(claims, "client_id")},
+		{"iat", claims.IssuedAt != nil},
+		{"jti", claims.ID != ""},
+	} {
+		if !c.present {
+			missing = append(missing, c.name)
+		}
+	}
+	if len(missing) > 0 {
+		return newAuthError(KindClaimsInvalid, "access token missing required claims: "+strings.Join(missing, ", "), nil)
+	}
+	return nil
+}
+
+func hasStringClaim(claims *Claims, name string) bool {
+	s, ok := claims.GetString(name)
+	return ok && s != ""
+}
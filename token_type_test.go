@@ -0,0 +1,117 @@
+package authclient
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestRequireAccessTokenProfile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewSigner(key, "k1", SignerConfig{Issuer: "https://auth.example.com", Audience: []string{"orders"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := httptest.NewServer(signer.JWKSHandler())
+	defer jwks.Close()
+	config := DefaultConfig(jwks.URL, "https://auth.example.com", "orders")
+	config.RequireAccessTokenProfile = true
+	v, err := NewValidator(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Stop()
+
+	withClientID := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+		Extra:            map[string]json.RawMessage{"client_id": json.RawMessage(`"orders-web"`)},
+	}
+	// signWithTyp signs claims the way Signer does, but with another typ header.
+	signWithTyp := func(typ string) string {
+		t.Helper()
+		tok, err := signer.Sign(withClientID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, _, err := jwt.NewParser().ParseUnverified(tok, &Claims{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resigned := jwt.NewWithClaims(jwt.SigningMethodRS256, parsed.Claims)
+		resigned.Header["kid"] = "k1"
+		resigned.Header["typ"] = typ
+		s, err := resigned.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	sign := func(c Claims) string {
+		t.Helper()
+		tok, err := signer.Sign(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tok
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{"conforming", sign(withClientID), ""},
+		{"id token typ", signWithTyp("JWT"), `typ "JWT" is not at+jwt`},
+		{"media type typ", signWithTyp("application/at+jwt"), ""},
+		{"missing client_id and sub", sign(Claims{}), "missing required claims: sub, client_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.ValidateToken(tt.token)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			case tt.wantErr != "" && KindOf(err) != KindClaimsInvalid:
+				t.Fatalf("kind = %s, want %s", KindOf(err), KindClaimsInvalid)
+			}
+		})
+	}
+}
+
+func TestResolveTokenKind(t *testing.T) {
+	tests := []struct {
+		name      string
+		headerTyp any
+		claims    Claims
+		want      TokenKind
+		wantErr   bool
+	}{
+		{"at+jwt header, no claim", "at+jwt", Claims{}, TokenKindAccess, false},
+		{"at+jwt header, access claim", "at+jwt", Claims{TokenUse: "access"}, TokenKindAccess, false},
+		{"at+jwt header, refresh token_use", "at+jwt", Claims{TokenUse: "refresh"}, TokenKindRefresh, true},
+		{"media type header, ID typ claim", "application/at+jwt", Claims{Type: "ID"}, TokenKindID, true},
+		{"JWT header defers to claims", "JWT", Claims{TokenUse: "refresh"}, TokenKindRefresh, false},
+		{"no header, no claim", nil, Claims{}, TokenKindUnknown, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTokenKind(tt.headerTyp, &tt.claims)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Fatalf("resolveTokenKind = %q, %v; want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+			if err != nil && KindOf(err) != KindClaimsInvalid {
+				t.Fatalf("kind = %s, want %s", KindOf(err), KindClaimsInvalid)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package authclient
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// User is an auth-service user, as returned by GetUser, GetUsers, ExportUsers and in
+// AuthResponse.User.
+type User struct {
+	ID        string         `json:"id"`
+	Email     string         `json:"email"`
+	Status    string         `json:"status,omitempty"` // e.g. "active", "pending", "disabled"
+	Roles     []string       `json:"roles,omitempty"`
+	Profile   map[string]any `json:"profile,omitempty"` // free-form; see WithProfileValidation
+	CreatedAt time.Time      `json:"created_at,omitzero"`
+	UpdatedAt time.Time      `json:"updated_at,omitzero"`
+
+	// Raw keeps the fields auth-service sent that are not modelled above, in either
+	// DecodeMode. They are written back when the user is marshalled, so stored copies keep
+	// them.
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+// Clone returns a copy of u whose Roles, Profile and Raw may be modified without affecting u.
+// Nested profile values are shared.
+func (u *User) Clone() *User {
+	if u == nil {
+		return nil
+	}
+	c := *u
+	c.Roles = slices.Clone(u.Roles)
+	c.Profile = maps.Clone(u.Profile)
+	c.Raw = maps.Clone(u.Raw)
+	return &c
+}
+
+func (u *User) UnmarshalJSON(data []byte) error {
+	type plain User
+	if err := json.Unmarshal(data, (*plain)(u)); err != nil {
+		return err
+	}
+	u.Raw = unmodelledFields(data, reflect.TypeFor[plain]())
+	return nil
+}
+
+func (u User) MarshalJSON() ([]byte, error) {
+	type plain User
+	return marshalWithRaw(plain(u), u.Raw)
+}
+
+// Tenant is the tenant summary auth-service includes in AuthResponse.Tenant. CreateTenant and
+// the tenant status calls return the full TenantResponse.
+type Tenant struct {
+	ID        string       `json:"id"`
+	Slug      string       `json:"slug"`
+	Name      string       `json:"name,omitempty"`
+	Status    TenantStatus `json:"status,omitempty"`
+	CreatedAt time.Time    `json:"created_at,omitzero"`
+	UpdatedAt time.Time    `json:"updated_at,omitzero"`
+
+	Raw map[string]json.RawMessage `json:"-"` // fields not modelled above; see User.Raw
+}
+
+func (t *Tenant) UnmarshalJSON(data []byte) error {
+	type plain Tenant
+	if err := json.Unmarshal(data, (*plain)(t)); err != nil {
+		return err
+	}
+	t.Raw = unmodelledFields(data, reflect.TypeFor[plain]())
+	return nil
+}
+
+func (t Tenant) MarshalJSON() ([]byte, error) {
+	type plain Tenant
+	return marshalWithRaw(plain(t), t.Raw)
+}
+
+// marshalWithRaw marshals v, a struct, adding the fields of raw it does not set itself.
+func marshalWithRaw(v any, raw map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(raw) == 0 {
+		return data, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for k, val := range raw {
+		if _, ok := fields[k]; !ok {
+			fields[k] = val
+		}
+	}
+	return json.Marshal(fields)
+}
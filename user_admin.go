@@ -0,0 +1,194 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// UserListOptions filters and pages ListUsers. Zero fields do not filter.
+type UserListOptions struct {
+	TenantID string // only members of this tenant
+	Status   string // e.g. "active" or "deactivated"
+	Query    string // matched by auth-service against email and name
+	Limit    int    // page size; auth-service caps it and picks a default when zero
+	Cursor   string // UserPage.NextCursor of the previous page; empty for the first page
+}
+
+// UserPage is one page of ListUsers.
+type UserPage struct {
+	Users      []*User `json:"users"`
+	NextCursor string  `json:"next_cursor,omitempty"` // empty on the last page
+}
+
+// UserUpdate changes a user with UpdateUser. Nil fields are left as they are.
+type UserUpdate struct {
+	Email  *string   `json:"email,omitempty"`
+	Status *string   `json:"status,omitempty"`
+	Roles  *[]string `json:"roles,omitempty"` // replaces the user's roles
+	// Profile is merged into the stored profile; a nil value removes that field.
+	Profile map[string]any `json:"profile,omitempty"`
+}
+
+// fields returns the JSON names of the fields u changes, for logs and audit events.
+func (u UserUpdate) fields() []string {
+	var fields []string
+	if u.Email != nil {
+		fields = append(fields, "email")
+	}
+	if u.Status != nil {
+		fields = append(fields, "status")
+	}
+	if u.Roles != nil {
+		fields = append(fields, "roles")
+	}
+	if u.Profile != nil {
+		fields = append(fields, "profile")
+	}
+	return fields
+}
+
+// ListUsers returns a page of users from auth-service's admin API. Follow NextCursor for the
+// rest:
+//
+//	opts := authclient.UserListOptions{TenantID: tenantID}
+//	for {
+//		page, err := client.ListUsers(ctx, opts, apiKey)
+//		...
+//		if page.NextCursor == "" {
+//			break
+//		}
+//		opts.Cursor = page.NextCursor
+//	}
+//
+// To read a whole tenant, ExportUsers is cheaper. apiKey may be empty when the client has a
+// credential provider, as for the other admin calls.
+func (c *Client) ListUsers(ctx context.Context, opts UserListOptions, apiKey string) (*UserPage, error) {
+	apiKey, provided, err := c.adminAPIKey(ctx, apiKey, "list users")
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	for k, v := range map[string]string{"tenant_id": opts.TenantID, "status": opts.Status, "q": opts.Query, "cursor": opts.Cursor} {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	path := "/api/v1/admin/users"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var page UserPage
+	if err := c.adminCall(ctx, http.MethodGet, path, "list users", apiKey, provided, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetUserByEmail looks a user up by email address, e.g. to check whether an invitee already
+// has an account. It fails with KindNotFound when no account uses email.
+func (c *Client) GetUserByEmail(ctx context.Context, email, apiKey string) (*User, error) {
+	if email == "" {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: email required to look up user", nil)
+	}
+	apiKey, provided, err := c.adminAPIKey(ctx, apiKey, "get user by email")
+	if err != nil {
+		return nil, err
+	}
+	path := "/api/v1/admin/users/by-email?" + url.Values{"email": {email}}.Encode()
+	var user User
+	if err := c.adminCall(ctx, http.MethodGet, path, "get user by email", apiKey, provided, nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUser applies update to userID and returns the updated user. An email already used by
+// another account fails with KindInvalidRequest and StatusCode 409.
+func (c *Client) UpdateUser(ctx context.Context, userID string, update UserUpdate, apiKey string) (*User, error) {
+	if userID == "" {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: user ID required to update user", nil)
+	}
+	fields := update.fields()
+	if len(fields) == 0 {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: nothing to update", nil)
+	}
+	apiKey, provided, err := c.adminAPIKey(ctx, apiKey, "update user")
+	if err != nil {
+		return nil, err
+	}
+	var user User
+	if err := c.adminCall(ctx, http.MethodPatch, "/api/v1/admin/users/"+url.PathEscape(userID), "update user", apiKey, provided, update, &user); err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("auth-service: user updated", "user_id", userID, "fields", fields)
+	emitAudit(ctx, c.audit, AuditEvent{
+		Type:       AuditUserUpdated,
+		Outcome:    AuditOutcomeSuccess,
+		Subject:    userID,
+		Attributes: map[string]any{"fields": fields},
+	})
+	return &user, nil
+}
+
+// DeactivateUser disables userID's account: auth-service revokes its sessions and refuses
+// new sign-ins, but keeps the account so it can be reactivated with UpdateUser. reason is
+// recorded by auth-service.
+func (c *Client) DeactivateUser(ctx context.Context, userID, reason, apiKey string) (*User, error) {
+	if userID == "" {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: user ID required to deactivate user", nil)
+	}
+	apiKey, provided, err := c.adminAPIKey(ctx, apiKey, "deactivate user")
+	if err != nil {
+		return nil, err
+	}
+	req := struct {
+		Reason string `json:"reason,omitempty"`
+	}{reason}
+	var user User
+	if err := c.adminCall(ctx, http.MethodPost, "/api/v1/admin/users/"+url.PathEscape(userID)+"/deactivate", "deactivate user", apiKey, provided, req, &user); err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("auth-service: user deactivated", "user_id", userID)
+	var attrs map[string]any
+	if reason != "" {
+		attrs = map[string]any{"reason": reason}
+	}
+	emitAudit(ctx, c.audit, AuditEvent{
+		Type:       AuditUserDeactivated,
+		Outcome:    AuditOutcomeSuccess,
+		Subject:    userID,
+		Attributes: attrs,
+	})
+	return &user, nil
+}
+
+// DeleteUser permanently deletes userID's account and its memberships in every tenant.
+// Prefer DeactivateUser where the account may be needed again.
+func (c *Client) DeleteUser(ctx context.Context, userID, apiKey string) error {
+	if userID == "" {
+		return newAuthError(KindInvalidRequest, "auth-service: user ID required to delete user", nil)
+	}
+	apiKey, provided, err := c.adminAPIKey(ctx, apiKey, "delete user")
+	if err != nil {
+		return err
+	}
+	if err := c.adminCall(ctx, http.MethodDelete, "/api/v1/admin/users/"+url.PathEscape(userID), "delete user", apiKey, provided, nil, nil); err != nil {
+		return err
+	}
+
+	c.logger.Info("auth-service: user deleted", "user_id", userID)
+	emitAudit(ctx, c.audit, AuditEvent{
+		Type:    AuditUserDeleted,
+		Outcome: AuditOutcomeSuccess,
+		Subject: userID,
+	})
+	return nil
+}
@@ -0,0 +1,97 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminUsers(t *testing.T) {
+	var lastBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "admin-key" {
+			http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+			return
+		}
+		lastBody = nil
+		_ = json.NewDecoder(r.Body).Decode(&lastBody)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/admin/users":
+			q := r.URL.Query()
+			if q.Get("tenant_id") != "t1" || q.Get("limit") != "2" {
+				http.Error(w, `{"error":"bad filter"}`, http.StatusBadRequest)
+				return
+			}
+			if q.Get("cursor") == "" {
+				_, _ = w.Write([]byte(`{"users":[{"id":"u1","email":"a@acme.test"},{"id":"u2","email":"b@acme.test"}],"next_cursor":"c2"}`))
+			} else {
+				_, _ = w.Write([]byte(`{"users":[{"id":"u3","email":"c@acme.test"}]}`))
+			}
+		case "GET /api/v1/admin/users/by-email":
+			if r.URL.Query().Get("email") != "a+1@acme.test" {
+				http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"u1","email":"a+1@acme.test"}`))
+		case "PATCH /api/v1/admin/users/u1":
+			_, _ = w.Write([]byte(`{"id":"u1","email":"a@acme.test","roles":["admin"]}`))
+		case "POST /api/v1/admin/users/u1/deactivate":
+			_, _ = w.Write([]byte(`{"id":"u1","email":"a@acme.test","status":"deactivated"}`))
+		case "DELETE /api/v1/admin/users/u1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	ctx := context.Background()
+
+	var ids []string
+	opts := UserListOptions{TenantID: "t1", Limit: 2}
+	for {
+		page, err := c.ListUsers(ctx, opts, "admin-key")
+		if err != nil {
+			t.Fatalf("ListUsers(%+v) = %v", opts, err)
+		}
+		for _, u := range page.Users {
+			ids = append(ids, u.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+	if len(ids) != 3 || ids[2] != "u3" {
+		t.Fatalf("listed %v, want u1..u3", ids)
+	}
+
+	if u, err := c.GetUserByEmail(ctx, "a+1@acme.test", "admin-key"); err != nil || u.ID != "u1" {
+		t.Fatalf("GetUserByEmail = %+v, %v", u, err)
+	}
+	if _, err := c.GetUserByEmail(ctx, "nobody@acme.test", "admin-key"); KindOf(err) != KindNotFound {
+		t.Fatalf("unknown email: err = %v", err)
+	}
+
+	roles := []string{"admin"}
+	u, err := c.UpdateUser(ctx, "u1", UserUpdate{Roles: &roles}, "admin-key")
+	if err != nil || u.Roles[0] != "admin" || len(lastBody) != 1 || lastBody["roles"] == nil {
+		t.Fatalf("UpdateUser = %+v, %v (sent %v)", u, err, lastBody)
+	}
+	if _, err := c.UpdateUser(ctx, "u1", UserUpdate{}, "admin-key"); KindOf(err) != KindInvalidRequest {
+		t.Fatalf("empty update: err = %v", err)
+	}
+
+	if u, err := c.DeactivateUser(ctx, "u1", "left the company", "admin-key"); err != nil || u.Status != "deactivated" || lastBody["reason"] != "left the company" {
+		t.Fatalf("DeactivateUser = %+v, %v", u, err)
+	}
+	if err := c.DeleteUser(ctx, "u1", "admin-key"); err != nil {
+		t.Fatalf("DeleteUser = %v", err)
+	}
+	if err := c.DeleteUser(ctx, "u9", "admin-key"); KindOf(err) != KindNotFound {
+		t.Fatalf("DeleteUser(unknown) = %v", err)
+	}
+}
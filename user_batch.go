@@ -0,0 +1,132 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultGetUsersConcurrency bounds concurrent GetUser calls when auth-service has no user
+// batch endpoint.
+const DefaultGetUsersConcurrency = 8
+
+// maxUserBatch is the most IDs sent in one batch request; longer lists are split.
+const maxUserBatch = 100
+
+// GetUsers fetches many users at once, e.g. to hydrate a page of a list view, and returns
+// them keyed by ID. IDs are deduplicated and users that do not exist are left out of the
+// map. It uses auth-service's batch endpoint, falling back to at most
+// DefaultGetUsersConcurrency concurrent GetUser calls if that endpoint is not available.
+// Any other failure fails the whole call.
+func (c *Client) GetUsers(ctx context.Context, userIDs []string, accessToken string) (map[string]*User, error) {
+	ids := slices.Clone(userIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	if len(ids) > 0 && ids[0] == "" {
+		ids = ids[1:]
+	}
+	users := make(map[string]*User, len(ids))
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	if !c.userBatchUnsupported.Load() {
+		supported := true
+		for chunk := range slices.Chunk(ids, maxUserBatch) {
+			var err error
+			if supported, err = c.getUsersBatch(ctx, chunk, accessToken, users); err != nil {
+				return nil, err
+			}
+			if !supported {
+				c.logger.Warn("auth-service: user batch endpoint not available, falling back to concurrent lookups")
+				c.userBatchUnsupported.Store(true)
+				break
+			}
+		}
+		if supported {
+			return users, nil
+		}
+	}
+
+	fetched := make([]*User, len(ids))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(DefaultGetUsersConcurrency)
+	for i, id := range ids {
+		if _, ok := users[id]; ok {
+			continue // already returned by a batch before the fallback
+		}
+		g.Go(func() error {
+			user, err := c.GetUser(gctx, id, accessToken)
+			if KindOf(err) == KindNotFound {
+				return nil
+			}
+			fetched[i] = user
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	for i, user := range fetched {
+		if user != nil {
+			users[ids[i]] = user
+		}
+	}
+	return users, nil
+}
+
+// getUsersBatch adds the users of ids found by the batch endpoint to users. supported is
+// false when auth-service does not implement the endpoint (404/405/501).
+func (c *Client) getUsersBatch(ctx context.Context, ids []string, accessToken string, users map[string]*User) (supported bool, err error) {
+	body, err := json.Marshal(map[string]any{"ids": ids})
+	if err != nil {
+		return false, newAuthError(KindInternal, "auth-service: marshal request", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/users/batch", bytes.NewReader(body))
+	if err != nil {
+		return false, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+accessToken)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return true, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := readBody(resp.Body)
+	if err != nil {
+		return true, newAuthError(KindUpstream, "auth-service: read response", err)
+	}
+	defer putBuffer(buf)
+	respBody := buf.Bytes()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return false, nil
+	default:
+		return true, c.responseError("get users", resp.StatusCode, respBody)
+	}
+
+	var out struct {
+		Users []*User `json:"users"`
+	}
+	if err := c.decode(respBody, &out); err != nil {
+		return true, newAuthError(KindUpstream, "auth-service: unmarshal response", err)
+	}
+	for _, user := range out.Users {
+		if user != nil && user.ID != "" {
+			users[user.ID] = user
+		}
+	}
+	return true, nil
+}
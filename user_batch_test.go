@@ -0,0 +1,60 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetUsers(t *testing.T) {
+	known := map[string]bool{"u1": true, "u2": true, "u3": true}
+	for _, batch := range []bool{true, false} {
+		var batchCalls, singleCalls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v1/users/batch" {
+				batchCalls.Add(1)
+				if !batch {
+					http.NotFound(w, r)
+					return
+				}
+				var req struct{ IDs []string }
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				var users []map[string]string
+				for _, id := range req.IDs {
+					if known[id] {
+						users = append(users, map[string]string{"id": id})
+					}
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{"users": users})
+				return
+			}
+			singleCalls.Add(1)
+			id := strings.TrimPrefix(r.URL.Path, "/api/v1/users/")
+			if !known[id] {
+				http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": id})
+		}))
+
+		c := NewClient(srv.URL, nil)
+		for range 2 {
+			users, err := c.GetUsers(context.Background(), []string{"u1", "u2", "u1", "missing", "", "u3"}, "token")
+			if err != nil || len(users) != 3 || users["u2"].ID != "u2" {
+				t.Fatalf("batch=%v: GetUsers = %v, %v", batch, users, err)
+			}
+		}
+		if batch && (batchCalls.Load() != 2 || singleCalls.Load() != 0) {
+			t.Errorf("batch endpoint: %d batch and %d single calls", batchCalls.Load(), singleCalls.Load())
+		}
+		// Without the batch endpoint it is probed once, then each distinct ID is fetched.
+		if !batch && (batchCalls.Load() != 1 || singleCalls.Load() != 8) {
+			t.Errorf("fallback: %d batch and %d single calls", batchCalls.Load(), singleCalls.Load())
+		}
+		srv.Close()
+	}
+}
@@ -0,0 +1,159 @@
+package authclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Bengo-Hub/shared-auth-client/events"
+	"golang.org/x/sync/singleflight"
+)
+
+// UserGetter fetches a user from auth-service. *Client, *ClientSet and *UserCache implement
+// it, so a cache can be dropped in wherever a client was used.
+type UserGetter interface {
+	GetUser(ctx context.Context, userID string, accessToken string) (*User, error)
+}
+
+var (
+	_ UserGetter = (*Client)(nil)
+	_ UserGetter = (*ClientSet)(nil)
+	_ UserGetter = (*UserCache)(nil)
+)
+
+// UserCacheConfig configures a UserCache.
+type UserCacheConfig struct {
+	TTL        time.Duration // how long a user is served from cache, defaults to 5 minutes
+	MaxEntries int           // defaults to 10,000
+}
+
+// UserCache caches GetUser results so hot paths (rendering an order's owner, enriching audit
+// logs) do not call auth-service for every request. Register it on an events.Registry so
+// user.updated and user.deleted events drop stale entries at once; TTL then only bounds
+// staleness when an event is missed.
+//
+// Entries are keyed by user ID alone: the access token of the call that filled an entry is
+// not checked again on hits. Use the cache where every caller may see every user it asks
+// for, e.g. with a service token.
+type UserCache struct {
+	source UserGetter
+	cfg    UserCacheConfig
+	group  singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]cachedUser
+	gen     uint64 // bumped by every invalidation; fetches that straddle one are not stored
+}
+
+type cachedUser struct {
+	user    *User
+	expires time.Time
+}
+
+// NewUserCache creates a cache in front of source, usually a *Client, with cfg's zero fields
+// defaulted.
+func NewUserCache(source UserGetter, cfg UserCacheConfig) *UserCache {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 5 * time.Minute
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = 10000
+	}
+	return &UserCache{source: source, cfg: cfg, entries: make(map[string]cachedUser)}
+}
+
+// GetUser returns the cached user, fetching it from source on a miss. Concurrent misses for
+// one user share a single fetch. Errors, including KindNotFound, are not cached. The
+// returned user is a copy the caller may modify.
+func (c *UserCache) GetUser(ctx context.Context, userID string, accessToken string) (*User, error) {
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[userID]
+	if ok && now.After(entry.expires) {
+		delete(c.entries, userID)
+		ok = false
+	}
+	gen := c.gen
+	c.mu.Unlock()
+	if ok {
+		return entry.user.Clone(), nil
+	}
+
+	// The fetch is shared, so it must not fail because the first caller gave up.
+	ch := c.group.DoChan(userID, func() (any, error) {
+		user, err := c.source.GetUser(context.WithoutCancel(ctx), userID, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.gen == gen {
+			if len(c.entries) >= c.cfg.MaxEntries {
+				c.evictLocked(time.Now())
+			}
+			c.entries[userID] = cachedUser{user: user, expires: time.Now().Add(c.cfg.TTL)}
+		}
+		return user, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*User).Clone(), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// evictLocked drops expired entries and, if the cache is still full, arbitrary entries until
+// a tenth of the capacity is free.
+func (c *UserCache) evictLocked(now time.Time) {
+	for id, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, id)
+		}
+	}
+	for id := range c.entries {
+		if len(c.entries) < c.cfg.MaxEntries*9/10 {
+			break
+		}
+		delete(c.entries, id)
+	}
+}
+
+// Invalidate drops userID's cached entry.
+func (c *UserCache) Invalidate(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	delete(c.entries, userID)
+}
+
+// InvalidateAll empties the cache.
+func (c *UserCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	clear(c.entries)
+}
+
+// Len returns the number of cached users, including expired ones not yet evicted.
+func (c *UserCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Register invalidates cached users from registry events: user.updated and user.deleted
+// drop the user's entry.
+func (c *UserCache) Register(registry *events.Registry) {
+	registry.OnUserUpdated(func(ctx context.Context, e events.UserUpdatedEvent) error {
+		c.Invalidate(e.Data.UserID)
+		return nil
+	})
+	registry.OnUserDeleted(func(ctx context.Context, e events.UserDeletedEvent) error {
+		c.Invalidate(e.Data.UserID)
+		return nil
+	})
+}
@@ -0,0 +1,62 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Bengo-Hub/shared-auth-client/events"
+)
+
+// countingUsers serves users whose "fetch" field is the fetch count, so refetches are
+// visible.
+type countingUsers struct{ fetches atomic.Int32 }
+
+func (s *countingUsers) GetUser(ctx context.Context, userID, accessToken string) (*User, error) {
+	if userID == "missing" {
+		return nil, newAuthError(KindNotFound, "user not found", nil)
+	}
+	return &User{ID: userID, Profile: map[string]any{"fetch": s.fetches.Add(1)}}, nil
+}
+
+func TestUserCache(t *testing.T) {
+	source := &countingUsers{}
+	cache := NewUserCache(source, UserCacheConfig{})
+	registry := events.NewRegistry()
+	cache.Register(registry)
+	ctx := context.Background()
+
+	fetch := func(id string) int32 {
+		t.Helper()
+		user, err := cache.GetUser(ctx, id, "token")
+		if err != nil {
+			t.Fatalf("GetUser(%s) = %v", id, err)
+		}
+		return user.Profile["fetch"].(int32)
+	}
+	if fetch("u1") != 1 || fetch("u1") != 1 {
+		t.Fatal("second GetUser should be served from cache")
+	}
+	for i := range 2 {
+		if _, err := cache.GetUser(ctx, "missing", "token"); KindOf(err) != KindNotFound {
+			t.Fatalf("missing user, call %d: err = %v", i, err)
+		}
+	}
+
+	dispatch := func(eventType string, data any) {
+		t.Helper()
+		payload, _ := json.Marshal(data)
+		if err := registry.Dispatch(ctx, events.Event{ID: eventType, Type: eventType, Data: payload}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dispatch(events.TypeUserUpdated, events.UserUpdated{UserID: "u1", Fields: []string{"email"}})
+	if got := fetch("u1"); got != 2 {
+		t.Fatalf("after user.updated: fetch %d, want 2", got)
+	}
+	dispatch(events.TypeUserDeleted, events.UserDeleted{UserID: "u1"})
+	if cache.Len() != 0 {
+		t.Fatalf("after user.deleted: %d cached users", cache.Len())
+	}
+}
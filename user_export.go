@@ -0,0 +1,86 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ContentTypeNDJSON is the media type of auth-service's streaming exports: one JSON document
+// per line.
+const ContentTypeNDJSON = "application/x-ndjson"
+
+// UserStream yields exported users one at a time. Call Next until it returns false, then
+// check Err; Close releases the connection and may be called early to stop the export.
+type UserStream interface {
+	Next() bool
+	User() *User
+	Err() error
+	Close() error
+}
+
+// ExportUsers streams every user of tenantID from auth-service's export endpoint, for
+// backfills and analytics jobs. Users are decoded as they arrive, so memory use does not grow
+// with the tenant's size. The stream is bounded by ctx rather than the client's timeout.
+func (c *Client) ExportUsers(ctx context.Context, tenantID, apiKey string) (UserStream, error) {
+	apiKey, provided, err := c.adminAPIKey(ctx, apiKey, "user export")
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/admin/tenants/"+url.PathEscape(tenantID)+"/users/export", nil)
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Accept", ContentTypeNDJSON)
+	httpReq.Header.Set("X-API-Key", apiKey)
+	c.signAdmin(httpReq, nil)
+
+	// No client timeout: a large export legitimately outlives it.
+	resp, err := (&http.Client{Transport: c.httpClient.Transport}).Do(httpReq)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		c.logger.Warn("auth-service: user export failed", "status", resp.StatusCode, "tenant_id", tenantID, "response", c.redact.body(respBody))
+		c.apiKeyRejected(provided, resp.StatusCode)
+		return nil, c.responseError("user export", resp.StatusCode, respBody)
+	}
+	return &ndjsonUserStream{body: resp.Body, dec: json.NewDecoder(resp.Body)}, nil
+}
+
+// ndjsonUserStream decodes one user per line of an NDJSON body.
+type ndjsonUserStream struct {
+	body io.ReadCloser
+	dec  *json.Decoder
+	user *User
+	done bool
+	err  error
+}
+
+func (s *ndjsonUserStream) Next() bool {
+	if s.done {
+		return false
+	}
+	s.user = nil
+	if err := s.dec.Decode(&s.user); err != nil {
+		s.done = true
+		if !errors.Is(err, io.EOF) {
+			s.err = newAuthError(KindUpstream, "auth-service: user export interrupted", err)
+		}
+		return false
+	}
+	return true
+}
+
+func (s *ndjsonUserStream) User() *User { return s.user }
+
+func (s *ndjsonUserStream) Err() error { return s.err }
+
+func (s *ndjsonUserStream) Close() error { return s.body.Close() }
@@ -0,0 +1,53 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExportUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []string
+		wantErr bool
+	}{
+		{"complete", "{\"id\":\"u1\"}\n{\"id\":\"u2\"}\n\n{\"id\":\"u3\"}\n", []string{"u1", "u2", "u3"}, false},
+		{"empty", "", nil, false},
+		{"truncated", "{\"id\":\"u1\"}\n{\"id\":", []string{"u1"}, true},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v1/admin/tenants/t-1/users/export" || r.Header.Get("X-API-Key") != "key" {
+				http.Error(w, `{"error":"not_found"}`, http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", ContentTypeNDJSON)
+			w.Write([]byte(tt.body))
+		}))
+		stream, err := NewClient(srv.URL, nil).ExportUsers(context.Background(), "t-1", "key")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var got []string
+		for stream.Next() {
+			got = append(got, stream.User().ID)
+		}
+		if (stream.Err() != nil) != tt.wantErr {
+			t.Errorf("%s: Err() = %v, wantErr %v", tt.name, stream.Err(), tt.wantErr)
+		}
+		stream.Close()
+		srv.Close()
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: users = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: users = %v, want %v", tt.name, got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package authclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// SyncUserResult is the per-user outcome of SyncUsers, in input order.
+type SyncUserResult struct {
+	Response *SyncUserResponse
+	Err      error
+}
+
+// syncUserBatchEntry is one result of the user sync batch endpoint.
+type syncUserBatchEntry struct {
+	SyncUserResponse
+	ErrorCode string             `json:"error_code,omitempty"`
+	Conflict  *SyncConflictError `json:"conflict,omitempty"`
+}
+
+// WithProtobuf encodes bulk calls (SyncUsers) as protobuf instead of JSON, cutting
+// serialisation cost for high-volume pipelines. If auth-service answers 415 the client
+// switches back to JSON for the rest of its life.
+func WithProtobuf() ClientOption {
+	return func(c *Client) {
+		c.protobuf = &protobufState{}
+	}
+}
+
+// protobufState tracks protobuf negotiation for a Client or APIKeyValidator.
+type protobufState struct {
+	rejected atomic.Bool // auth-service answered 415 Unsupported Media Type
+}
+
+// use reports whether the next request should be sent as protobuf.
+func (p *protobufState) use() bool {
+	return p != nil && !p.rejected.Load()
+}
+
+// SyncUsers syncs many users in one call to auth-service's batch endpoint. The returned slice
+// is aligned with reqs; per-user rejections, including credentials and profiles that fail
+// local validation, are reported in SyncUserResult.Err, while the error is non-nil only when
+// the batch as a whole failed.
+func (c *Client) SyncUsers(ctx context.Context, reqs []SyncUserRequest, apiKey string) ([]SyncUserResult, error) {
+	apiKey, provided, err := c.adminAPIKey(ctx, apiKey, "user sync")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SyncUserResult, len(reqs))
+	var send []int // indexes of reqs that passed local validation
+	batch := make([]SyncUserRequest, 0, len(reqs))
+	for i, req := range reqs {
+		if err := req.validateCredentials(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		if err := c.validateProfile(ctx, req.TenantSlug, req.Profile); err != nil {
+			results[i].Err = err
+			continue
+		}
+		send = append(send, i)
+		batch = append(batch, req)
+	}
+	if len(batch) == 0 {
+		return results, nil
+	}
+
+	useProto := c.protobuf.use()
+	var body []byte
+	contentType := "application/json"
+	if useProto {
+		body, err = marshalSyncUsersRequest(batch)
+		contentType = ContentTypeProtobuf
+	} else {
+		body, err = json.Marshal(map[string]any{"users": batch})
+	}
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: marshal request", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/admin/users/sync/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, newAuthError(KindInternal, "auth-service: create request", err)
+	}
+
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("Accept", "application/json")
+	if useProto {
+		httpReq.Header.Set("Accept", protobufAccept)
+	}
+	httpReq.Header.Set("X-API-Key", apiKey)
+	c.signAdmin(httpReq, body)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: request failed", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnsupportedMediaType && useProto {
+		c.logger.Warn("auth-service: protobuf not supported, falling back to JSON", "op", "user sync batch")
+		c.protobuf.rejected.Store(true)
+		return c.SyncUsers(ctx, reqs, apiKey)
+	}
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		c.logger.Warn("auth-service: user sync batch failed", "status", resp.StatusCode, "response", c.redact.body(respBody), "users", len(batch))
+		c.apiKeyRejected(provided, resp.StatusCode)
+		return nil, withRetryAfter(c.responseError("user sync batch", resp.StatusCode, respBody), resp.Header)
+	}
+
+	var out struct {
+		Results []syncUserBatchEntry `json:"results"`
+	}
+	err = decodeBody(resp, &out, c.decodeMode, func(data []byte) (err error) {
+		out.Results, err = unmarshalSyncUsersResponse(data)
+		return err
+	})
+	if err != nil {
+		return nil, newAuthError(KindUpstream, "auth-service: decode sync batch response", err)
+	}
+	if len(out.Results) != len(batch) {
+		return nil, newAuthError(KindUpstream, "auth-service: sync batch returned a mismatched result count", nil)
+	}
+
+	for j, entry := range out.Results {
+		i := send[j]
+		if entry.ErrorCode != "" {
+			var cause error = &Error{ErrorCode: entry.ErrorCode, Message: entry.Message}
+			if entry.Conflict != nil {
+				entry.Conflict.apiErr = cause.(*Error)
+				cause = entry.Conflict
+			}
+			results[i].Err = &AuthError{
+				Kind:    KindInvalidRequest,
+				Code:    entry.ErrorCode,
+				Message: "auth-service: user sync failed",
+				Cause:   cause,
+			}
+			continue
+		}
+		synced := entry.SyncUserResponse
+		results[i].Response = &synced
+		emitAudit(ctx, c.audit, AuditEvent{
+			Type:       AuditUserSynced,
+			Outcome:    AuditOutcomeSuccess,
+			Actor:      reqs[i].Service,
+			Subject:    synced.UserID,
+			TenantID:   synced.TenantID,
+			TenantSlug: reqs[i].TenantSlug,
+			Attributes: map[string]any{"created": synced.Created},
+		})
+	}
+	return results, nil
+}
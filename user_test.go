@@ -0,0 +1,54 @@
+package authclient
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestUserRaw(t *testing.T) {
+	body := []byte(`{"access_token":"at","expires_in":900,` +
+		`"user":{"id":"u1","email":"ada@example.com","roles":["admin"],"created_at":"2026-01-02T03:04:05Z","locale":"sw"},` +
+		`"tenant":{"id":"t1","slug":"acme","status":"active","plan":{"tier":2}}}`)
+
+	for _, mode := range []DecodeMode{DecodeLenient, DecodeStrict} {
+		var resp AuthResponse
+		if err := decodeJSON(body, &resp, mode); err != nil {
+			t.Fatalf("mode %d: %v", mode, err)
+		}
+		u, tenant := resp.User, resp.Tenant
+		if u.ID != "u1" || u.Roles[0] != "admin" || !u.CreatedAt.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+			t.Errorf("mode %d: user = %+v", mode, u)
+		}
+		if len(u.Raw) != 1 || string(u.Raw["locale"]) != `"sw"` {
+			t.Errorf("mode %d: user Raw = %v, want locale only", mode, u.Raw)
+		}
+		if tenant.Status != TenantStatusActive || string(tenant.Raw["plan"]) != `{"tier":2}` {
+			t.Errorf("mode %d: tenant = %+v", mode, tenant)
+		}
+	}
+
+	// Stored tokens are JSON; unmodelled fields must survive the round trip.
+	var resp AuthResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(NewStoredTokens(&resp, time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored StoredTokens
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if string(stored.Tokens.User.Raw["locale"]) != `"sw"` || stored.Tokens.Tenant.Slug != "acme" {
+		t.Errorf("round trip: user = %+v, tenant = %+v", stored.Tokens.User, stored.Tokens.Tenant)
+	}
+
+	clone := resp.User.Clone()
+	clone.Roles[0] = "viewer"
+	clone.Raw["locale"] = json.RawMessage(`"en"`)
+	if resp.User.Roles[0] != "admin" || string(resp.User.Raw["locale"]) != `"sw"` {
+		t.Error("Clone shares roles or Raw with the original")
+	}
+}
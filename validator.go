@@ -4,15 +4,16 @@ import (
 	"context"
 	"crypto/rsa"
 	"crypto/sha256"
-	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"math/big"
+	"io"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/Bengo-Hub/shared-auth-client/authcore"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/sync/singleflight"
@@ -28,6 +29,30 @@ type Config struct {
 	HTTPClient      *http.Client
 	RedisClient     *redis.Client // Optional: Redis client for session caching
 	SessionCacheTTL time.Duration // Duration to cache validated sessions
+	Logger          Logger        // Optional: JWKS refresh failures are logged here
+	OnError         ErrorHook     // Optional: JWKS fetch failures, op "jwks.fetch"
+
+	// RevocationChecker rejects tokens whose session or user was revoked before they expire,
+	// e.g. a RevocationList fed by RevocationStream. Checked on cached claims too.
+	RevocationChecker RevocationChecker
+
+	// ClaimsMapper normalizes third-party IdP claims (KeycloakProfile, Auth0Profile,
+	// CognitoProfile, or MappersByIssuer for several) before claims are checked.
+	ClaimsMapper ClaimsMapper
+
+	// AllowNonAccessTokens disables token-type enforcement. By default tokens that declare
+	// themselves refresh or ID tokens (token_use/typ claim) are rejected so a leaked refresh
+	// token cannot call APIs; tokens with an RFC 9068 at+jwt header or no type are accepted.
+	AllowNonAccessTokens bool
+
+	// ManualRefresh leaves background JWKS refresh to Run, e.g. under a Runtime or an
+	// errgroup, instead of starting it in NewValidator.
+	ManualRefresh bool
+
+	// RequireAccessTokenProfile enforces the RFC 9068 JWT access-token profile: an at+jwt
+	// typ header and the iss, exp, aud, sub, client_id, iat and jti claims. ID tokens and
+	// ad-hoc JWTs signed by the same keys are then rejected even when they declare no type.
+	RequireAccessTokenProfile bool
 }
 
 // DefaultConfig returns a config with sensible defaults.
@@ -45,13 +70,20 @@ func DefaultConfig(jwksURL, issuer, audience string) Config {
 
 // Validator validates JWT tokens using JWKS from auth-service.
 type Validator struct {
-	config      Config
-	keys        map[string]*rsa.PublicKey
-	keysMu      sync.RWMutex
-	lastFetch   time.Time
-	fetchGroup  singleflight.Group
-	parser      *jwt.Parser
-	stopRefresh chan struct{}
+	config       Config
+	keys         map[string]*rsa.PublicKey
+	keysMu       sync.RWMutex
+	lastFetch    time.Time
+	lastErr      error     // most recent JWKS fetch failure, cleared on success
+	failingSince time.Time // first failure after the last success
+	fetchGroup   singleflight.Group
+	parser       *jwt.Parser
+	stopRefresh  chan struct{}
+	stopOnce     sync.Once
+	logger       Logger
+
+	headersMu sync.RWMutex
+	headers   map[string]*tokenHeader // verified JOSE header segments; see validateFast
 }
 
 // NewValidator creates a new JWT validator.
@@ -61,6 +93,7 @@ func NewValidator(config Config) (*Validator, error) {
 		keys:        make(map[string]*rsa.PublicKey),
 		parser:      jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()})),
 		stopRefresh: make(chan struct{}),
+		logger:      orNop(config.Logger),
 	}
 
 	// Initial fetch
@@ -68,8 +101,13 @@ func NewValidator(config Config) (*Validator, error) {
 		return nil, fmt.Errorf("initial JWKS fetch: %w", err)
 	}
 
-	// Start background refresh
-	go v.refreshLoop()
+	if !config.ManualRefresh {
+		ctx, cancel := untilClosed(context.Background(), v.stopRefresh)
+		go func() {
+			defer cancel()
+			v.refreshLoop(ctx)
+		}()
+	}
 
 	return v, nil
 }
@@ -80,26 +118,49 @@ func (v *Validator) ValidateToken(tokenString string) (*Claims, error) {
 	if v.config.RedisClient != nil {
 		claims, err := v.getCachedClaims(tokenString)
 		if err == nil && claims != nil {
+			if err := v.checkRevoked(claims); err != nil {
+				return nil, err
+			}
 			return claims, nil
 		}
 	}
 
-	// 2. Parse and validate token (CPU bound)
-	token, err := v.parser.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+	// 2. Parse and validate token (CPU bound). The fast path covers well-formed RS256 tokens
+	// signed by a known key; anything else takes the general jwt parser.
+	claims, handled, err := v.validateFast(tokenString)
+	if !handled {
+		claims, err = v.validate(tokenString, v.parser)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Cache the validated claims if Redis is configured
+	if v.config.RedisClient != nil {
+		_ = v.cacheClaims(tokenString, claims)
+	}
+
+	return claims, nil
+}
+
+// validate parses tokenString with parser and checks its claims, bypassing the claims cache.
+func (v *Validator) validate(tokenString string, parser *jwt.Parser) (*Claims, error) {
+	token, err := parser.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		kid, ok := token.Header["kid"].(string)
 		if !ok {
-			return nil, fmt.Errorf("missing kid in token header")
+			return nil, newAuthError(KindKeyNotFound, "missing kid in token header", nil)
 		}
 
 		key := v.getKey(kid)
 		if key == nil {
 			// Try to refresh JWKS
 			if err := v.fetchJWKS(context.Background()); err != nil {
-				return nil, fmt.Errorf("key not found and JWKS refresh failed: %w", err)
+				v.logger.Warn("JWKS refresh for unknown kid failed", "kid", kid, "error", err)
+				return nil, newAuthError(KindUpstream, "key not found and JWKS refresh failed", err)
 			}
 			key = v.getKey(kid)
 			if key == nil {
-				return nil, fmt.Errorf("key %s not found in JWKS", kid)
+				return nil, newAuthError(KindKeyNotFound, fmt.Sprintf("key %s not found in JWKS", kid), nil)
 			}
 		}
 
@@ -107,21 +168,49 @@ func (v *Validator) ValidateToken(tokenString string) (*Claims, error) {
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("parse token: %w", err)
+		return nil, parseError(err)
 	}
 
 	if !token.Valid {
-		return nil, fmt.Errorf("token invalid")
+		return nil, newAuthError(KindSignatureInvalid, "token invalid", nil)
 	}
 
 	claims, ok := token.Claims.(*Claims)
 	if !ok {
-		return nil, fmt.Errorf("invalid claims type")
+		return nil, newAuthError(KindInternal, "invalid claims type", nil)
+	}
+	if err := v.checkClaims(claims, token.Header["typ"]); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// checkClaims applies the access-token profile, the claims mapper and the token-type, issuer, audience and revocation
+// checks to the claims of a token whose signature and lifetime were verified.
+func (v *Validator) checkClaims(claims *Claims, headerTyp any) error {
+	if v.config.RequireAccessTokenProfile {
+		if err := checkAccessTokenProfile(headerTyp, claims); err != nil {
+			return err
+		}
+	}
+	if v.config.ClaimsMapper != nil {
+		if err := v.config.ClaimsMapper.MapClaims(claims); err != nil {
+			return newAuthError(KindClaimsInvalid, "map claims", err)
+		}
+	}
+
+	// Reject refresh/ID tokens presented as access tokens
+	kind, err := resolveTokenKind(headerTyp, claims)
+	if err != nil {
+		return err
+	}
+	if !v.config.AllowNonAccessTokens && kind != TokenKindAccess && kind != TokenKindUnknown {
+		return newAuthError(KindClaimsInvalid, fmt.Sprintf("token type %s not accepted: access token required", kind), nil)
 	}
 
 	// Validate issuer
 	if v.config.Issuer != "" && claims.Issuer != v.config.Issuer {
-		return nil, fmt.Errorf("invalid issuer: expected %s, got %s", v.config.Issuer, claims.Issuer)
+		return newAuthError(KindClaimsInvalid, fmt.Sprintf("invalid issuer: expected %s, got %s", v.config.Issuer, claims.Issuer), nil)
 	}
 
 	// Validate audience
@@ -134,16 +223,37 @@ func (v *Validator) ValidateToken(tokenString string) (*Claims, error) {
 			}
 		}
 		if !found {
-			return nil, fmt.Errorf("invalid audience: expected %s", v.config.Audience)
+			return newAuthError(KindClaimsInvalid, fmt.Sprintf("invalid audience: expected %s", v.config.Audience), nil)
 		}
 	}
 
-	// 3. Cache the validated claims if Redis is configured
-	if v.config.RedisClient != nil {
-		_ = v.cacheClaims(tokenString, claims)
+	return v.checkRevoked(claims)
+}
+
+func (v *Validator) checkRevoked(claims *Claims) error {
+	if v.config.RevocationChecker != nil && v.config.RevocationChecker.IsRevoked(claims) {
+		return newAuthError(KindTokenRevoked, "token revoked", nil)
 	}
+	return nil
+}
 
-	return claims, nil
+// parseError classifies a jwt parse failure. Errors returned by the key func are already
+// *AuthError and pass through.
+func parseError(err error) error {
+	var ae *AuthError
+	if errors.As(err, &ae) {
+		return ae
+	}
+	kind := KindTokenMalformed
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired), errors.Is(err, jwt.ErrTokenNotValidYet):
+		kind = KindTokenExpired
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		kind = KindSignatureInvalid
+	case errors.Is(err, jwt.ErrTokenInvalidClaims), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		kind = KindClaimsInvalid
+	}
+	return newAuthError(kind, "parse token", err)
 }
 
 func (v *Validator) getCachedClaims(tokenString string) (*Claims, error) {
@@ -205,48 +315,13 @@ func (v *Validator) fetchJWKS(ctx context.Context) error {
 			return nil, fmt.Errorf("JWKS fetch failed: status %d", resp.StatusCode)
 		}
 
-		var jwks struct {
-			Keys []struct {
-				Kty string `json:"kty"`
-				Kid string `json:"kid"`
-				Use string `json:"use"`
-				Alg string `json:"alg"`
-				N   string `json:"n"`
-				E   string `json:"e"`
-			} `json:"keys"`
-		}
-
-		if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
 			return nil, err
 		}
-
-		newKeys := make(map[string]*rsa.PublicKey)
-		for _, jwk := range jwks.Keys {
-			if jwk.Kty != "RSA" || jwk.Use != "sig" || jwk.Alg != "RS256" {
-				continue
-			}
-
-			nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
-			if err != nil {
-				continue
-			}
-
-			eBytes, err := base64.RawURLEncoding.DecodeString(jwk.E)
-			if err != nil {
-				continue
-			}
-
-			var eInt int64
-			for _, b := range eBytes {
-				eInt = eInt<<8 | int64(b)
-			}
-
-			pubKey := &rsa.PublicKey{
-				N: new(big.Int).SetBytes(nBytes),
-				E: int(eInt),
-			}
-
-			newKeys[jwk.Kid] = pubKey
+		newKeys, err := authcore.ParseJWKS(data)
+		if err != nil {
+			return nil, err
 		}
 
 		v.keysMu.Lock()
@@ -257,26 +332,94 @@ func (v *Validator) fetchJWKS(ctx context.Context) error {
 		return nil, nil
 	})
 
+	v.keysMu.Lock()
+	v.lastErr = err
+	switch {
+	case err == nil:
+		v.failingSince = time.Time{}
+	case v.failingSince.IsZero():
+		v.failingSince = time.Now()
+	}
+	v.keysMu.Unlock()
+	if err != nil {
+		v.config.OnError.report(ctx, "jwks.fetch", err)
+	}
 	return err
 }
 
-func (v *Validator) refreshLoop() {
+func (v *Validator) refreshLoop(ctx context.Context) {
 	ticker := time.NewTicker(v.config.RefreshInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			_ = v.fetchJWKS(ctx)
+			fetchCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			if err := v.fetchJWKS(fetchCtx); err != nil {
+				v.logger.Warn("background JWKS refresh failed", "url", v.config.JWKSUrl, "error", err)
+			}
 			cancel()
-		case <-v.stopRefresh:
+		case <-ctx.Done():
 			return
 		}
 	}
 }
 
-// Stop stops the background refresh loop.
+// Run refreshes the JWKS in the background until ctx is cancelled or Close is called, then
+// closes the validator. It returns ctx.Err(), which is nil after Close. Without
+// ManualRefresh, NewValidator has already started refreshing and Run only ties that loop to
+// ctx.
+func (v *Validator) Run(ctx context.Context) error {
+	defer v.Close()
+	ctx, cancel := untilClosed(ctx, v.stopRefresh)
+	defer cancel()
+	if v.config.ManualRefresh {
+		v.refreshLoop(ctx)
+	} else {
+		<-ctx.Done()
+	}
+	return runErr(ctx, v.stopRefresh)
+}
+
+// JWKSHealth describes the validator's key set for health endpoints.
+type JWKSHealth struct {
+	LastFetch time.Time `json:"last_fetch"`
+	KeyCount  int       `json:"key_count"`
+	Fresh     bool      `json:"fresh"` // fetched within CacheTTL (or two refresh intervals)
+	LastError string    `json:"last_error,omitempty"`
+	// FailingSince is the first failed fetch since the last successful one; zero while
+	// fetches succeed.
+	FailingSince time.Time `json:"failing_since,omitempty"`
+}
+
+// Health reports JWKS freshness.
+func (v *Validator) Health() JWKSHealth {
+	v.keysMu.RLock()
+	defer v.keysMu.RUnlock()
+	maxAge := v.config.CacheTTL
+	if maxAge <= 0 {
+		maxAge = 2 * v.config.RefreshInterval
+	}
+	h := JWKSHealth{
+		LastFetch: v.lastFetch,
+		KeyCount:  len(v.keys),
+		Fresh:     !v.lastFetch.IsZero() && time.Since(v.lastFetch) <= maxAge,
+
+		FailingSince: v.failingSince,
+	}
+	if v.lastErr != nil {
+		h.LastError = v.lastErr.Error()
+	}
+	return h
+}
+
+// Stop stops the background refresh loop. It is safe to call more than once.
 func (v *Validator) Stop() {
-	close(v.stopRefresh)
+	v.stopOnce.Do(func() { close(v.stopRefresh) })
+}
+
+// Close stops the background refresh loop, like Stop; it implements Runnable.
+func (v *Validator) Close() error {
+	v.Stop()
+	return nil
 }
@@ -0,0 +1,158 @@
+package authclient
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenHeader is the part of a JOSE header the validator needs.
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ any    `json:"typ"` // kept as decoded, for resolveTokenKind
+}
+
+// maxCachedHeaders bounds the header cache. auth-service signs with a handful of keys, so
+// only a few distinct header segments are ever seen; the cap stops forged headers with valid
+// signatures (which cannot exist without a key) from mattering at all.
+const maxCachedHeaders = 64
+
+// Errors of the fast path, built once so rejecting tokens does not allocate messages.
+var (
+	errFastSignature = fmt.Errorf("%w: %w", jwt.ErrTokenSignatureInvalid, rsa.ErrVerification)
+	errFastExpired   = fmt.Errorf("%w: %w", jwt.ErrTokenInvalidClaims, jwt.ErrTokenExpired)
+	errFastNotYet    = fmt.Errorf("%w: %w", jwt.ErrTokenInvalidClaims, jwt.ErrTokenNotValidYet)
+)
+
+// segmentBuffers holds scratch buffers for decoded signature and payload segments.
+var segmentBuffers = sync.Pool{New: func() any { b := make([]byte, 0, 1024); return &b }}
+
+// validateFast validates well-formed RS256 tokens signed by a known key with fewer
+// allocations than the jwt parser: the header segment is decoded once per key and cached,
+// segments are decoded into pooled buffers, and rejection errors are preallocated. handled is
+// false for anything unusual (unknown kid, other algorithms, malformed segments), which the
+// caller then passes to the general parser for refetching keys and precise errors.
+func (v *Validator) validateFast(token string) (claims *Claims, handled bool, err error) {
+	headerEnd := strings.IndexByte(token, '.')
+	if headerEnd < 0 {
+		return nil, false, nil
+	}
+	payloadEnd := strings.IndexByte(token[headerEnd+1:], '.')
+	if payloadEnd < 0 {
+		return nil, false, nil
+	}
+	payloadEnd += headerEnd + 1
+	if strings.IndexByte(token[payloadEnd+1:], '.') >= 0 {
+		return nil, false, nil
+	}
+
+	header, cached := v.cachedHeader(token[:headerEnd])
+	if !cached {
+		if header = parseTokenHeader(token[:headerEnd]); header == nil {
+			return nil, false, nil
+		}
+	}
+	if header.Alg != jwt.SigningMethodRS256.Alg() || header.Kid == "" {
+		return nil, false, nil
+	}
+	key := v.getKey(header.Kid)
+	if key == nil {
+		return nil, false, nil
+	}
+
+	bufp := segmentBuffers.Get().(*[]byte)
+	defer segmentBuffers.Put(bufp)
+	sig, err := decodeSegment(*bufp, token[payloadEnd+1:])
+	if err != nil {
+		return nil, false, nil
+	}
+	*bufp = sig
+	digest := sha256.Sum256(stringBytes(token[:payloadEnd]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		return nil, true, newAuthError(KindSignatureInvalid, "parse token", errFastSignature)
+	}
+	if !cached {
+		v.cacheHeader(token[:headerEnd], header)
+	}
+
+	payload, err := decodeSegment(*bufp, token[headerEnd+1:payloadEnd])
+	if err != nil {
+		return nil, false, nil
+	}
+	*bufp = payload
+	claims = new(Claims)
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, false, nil
+	}
+
+	// The same lifetime checks the jwt parser applies without options: exp and nbf when
+	// present, no leeway.
+	now := time.Now()
+	if exp := claims.RegisteredClaims.ExpiresAt; exp != nil && !now.Before(exp.Time) {
+		return nil, true, newAuthError(KindTokenExpired, "parse token", errFastExpired)
+	}
+	if nbf := claims.NotBefore; nbf != nil && now.Before(nbf.Time) {
+		return nil, true, newAuthError(KindTokenExpired, "parse token", errFastNotYet)
+	}
+
+	if err := v.checkClaims(claims, header.Typ); err != nil {
+		return nil, true, err
+	}
+	return claims, true, nil
+}
+
+func (v *Validator) cachedHeader(segment string) (*tokenHeader, bool) {
+	v.headersMu.RLock()
+	defer v.headersMu.RUnlock()
+	h, ok := v.headers[segment]
+	return h, ok
+}
+
+// cacheHeader remembers a header segment once a token using it has verified.
+func (v *Validator) cacheHeader(segment string, h *tokenHeader) {
+	v.headersMu.Lock()
+	defer v.headersMu.Unlock()
+	if v.headers == nil {
+		v.headers = make(map[string]*tokenHeader)
+	}
+	if len(v.headers) < maxCachedHeaders {
+		v.headers[strings.Clone(segment)] = h
+	}
+}
+
+func parseTokenHeader(segment string) *tokenHeader {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return nil
+	}
+	var h tokenHeader
+	if json.Unmarshal(data, &h) != nil {
+		return nil
+	}
+	return &h
+}
+
+// decodeSegment base64url-decodes segment into dst's storage, growing it if needed.
+func decodeSegment(dst []byte, segment string) ([]byte, error) {
+	n := base64.RawURLEncoding.DecodedLen(len(segment))
+	if cap(dst) < n {
+		dst = make([]byte, n)
+	}
+	n, err := base64.RawURLEncoding.Decode(dst[:n], stringBytes(segment))
+	return dst[:n], err
+}
+
+// stringBytes returns s's bytes without copying. The result must not be modified.
+func stringBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
@@ -0,0 +1,249 @@
+package authclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ValidatorRegistry validates tokens from several trusted issuers, each with its own
+// Validator (JWKS, audience, mapper, ...). A token is routed by its unverified iss claim;
+// this is safe because the chosen Validator verifies the signature against that issuer's
+// keys and checks iss again. Use it while migrating traffic between issuers.
+type ValidatorRegistry struct {
+	mu         sync.RWMutex
+	validators map[string]*registeredValidator
+
+	unknownIssuer atomic.Uint64
+	malformed     atomic.Uint64
+}
+
+type registeredValidator struct {
+	*Validator
+	validated atomic.Uint64
+	rejected  atomic.Uint64
+}
+
+// IssuerStats counts ValidateToken outcomes for one issuer.
+type IssuerStats struct {
+	Validated uint64 `json:"validated"`
+	Rejected  uint64 `json:"rejected"`
+}
+
+// ValidatorRegistryStats reports ValidateToken outcomes across a registry, e.g. to watch
+// traffic drain from the old issuer during a migration.
+type ValidatorRegistryStats struct {
+	Issuers       map[string]IssuerStats `json:"issuers"`
+	UnknownIssuer uint64                 `json:"unknown_issuer"` // tokens from issuers not registered
+	Malformed     uint64                 `json:"malformed"`      // tokens whose iss could not be read
+}
+
+// WithValidatorRegistry validates bearer tokens through registry instead of the
+// middleware's Validator, which may then be nil.
+func WithValidatorRegistry(registry *ValidatorRegistry) AuthMiddlewareOption {
+	return func(a *AuthMiddleware) {
+		a.registry = registry
+	}
+}
+
+// validateToken validates a bearer token with the registry or the single validator.
+func (a *AuthMiddleware) validateToken(token string) (*Claims, error) {
+	if a.registry != nil {
+		return a.registry.ValidateToken(token)
+	}
+	return a.validator.ValidateToken(token)
+}
+
+// validatorFor returns the Validator responsible for token.
+func (a *AuthMiddleware) validatorFor(token string) *Validator {
+	if a.registry != nil {
+		if rv, err := a.registry.route(token); err == nil {
+			return rv.Validator
+		}
+		return nil
+	}
+	return a.validator
+}
+
+// NewValidatorRegistry creates an empty registry.
+func NewValidatorRegistry() *ValidatorRegistry {
+	return &ValidatorRegistry{validators: make(map[string]*registeredValidator)}
+}
+
+// Register routes tokens issued by v's Config.Issuer to v, replacing any validator already
+// registered for that issuer (which is returned so the caller can Stop it).
+func (r *ValidatorRegistry) Register(v *Validator) (replaced *Validator, err error) {
+	issuer := v.config.Issuer
+	if issuer == "" {
+		return nil, errors.New("validator registry: validator has no Config.Issuer to route on")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.validators[issuer]; ok {
+		replaced = old.Validator
+	}
+	r.validators[issuer] = &registeredValidator{Validator: v}
+	return replaced, nil
+}
+
+// Add creates a Validator from config and registers it.
+func (r *ValidatorRegistry) Add(config Config) (*Validator, error) {
+	if config.Issuer == "" {
+		return nil, errors.New("validator registry: Config.Issuer required")
+	}
+	v, err := NewValidator(config)
+	if err != nil {
+		return nil, err
+	}
+	if old, _ := r.Register(v); old != nil {
+		old.Stop()
+	}
+	return v, nil
+}
+
+// Remove stops routing issuer's tokens, e.g. once a migration completes, and stops its
+// Validator. It reports whether the issuer was registered.
+func (r *ValidatorRegistry) Remove(issuer string) bool {
+	r.mu.Lock()
+	rv, ok := r.validators[issuer]
+	delete(r.validators, issuer)
+	r.mu.Unlock()
+	if ok {
+		rv.Stop()
+	}
+	return ok
+}
+
+// Validator returns the validator registered for issuer.
+func (r *ValidatorRegistry) Validator(issuer string) (*Validator, bool) {
+	rv := r.lookup(issuer)
+	if rv == nil {
+		return nil, false
+	}
+	return rv.Validator, true
+}
+
+func (r *ValidatorRegistry) lookup(issuer string) *registeredValidator {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.validators[issuer]
+}
+
+// ValidateToken validates token with the Validator registered for its issuer. Tokens from
+// unregistered issuers fail with KindClaimsInvalid.
+func (r *ValidatorRegistry) ValidateToken(token string) (*Claims, error) {
+	rv, err := r.route(token)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := rv.ValidateToken(token)
+	if err != nil {
+		rv.rejected.Add(1)
+		return nil, err
+	}
+	rv.validated.Add(1)
+	return claims, nil
+}
+
+// route finds the validator for token's unverified iss claim.
+func (r *ValidatorRegistry) route(token string) (*registeredValidator, error) {
+	issuer, err := unverifiedIssuer(token)
+	if err != nil {
+		r.malformed.Add(1)
+		return nil, err
+	}
+	rv := r.lookup(issuer)
+	if rv == nil {
+		r.unknownIssuer.Add(1)
+		return nil, newAuthError(KindClaimsInvalid, fmt.Sprintf("untrusted issuer %q", issuer), nil)
+	}
+	return rv, nil
+}
+
+// unverifiedIssuer reads the iss claim without verifying the token.
+func unverifiedIssuer(token string) (string, error) {
+	_, rest, ok := strings.Cut(token, ".")
+	payload, _, ok2 := strings.Cut(rest, ".")
+	if !ok || !ok2 {
+		return "", newAuthError(KindTokenMalformed, "token is not a JWS", nil)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", newAuthError(KindTokenMalformed, "decode token payload", err)
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return "", newAuthError(KindTokenMalformed, "decode token payload", err)
+	}
+	if claims.Issuer == "" {
+		return "", newAuthError(KindClaimsInvalid, "token has no iss claim", nil)
+	}
+	return claims.Issuer, nil
+}
+
+// Stats returns per-issuer and routing counters.
+func (r *ValidatorRegistry) Stats() ValidatorRegistryStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stats := ValidatorRegistryStats{
+		Issuers:       make(map[string]IssuerStats, len(r.validators)),
+		UnknownIssuer: r.unknownIssuer.Load(),
+		Malformed:     r.malformed.Load(),
+	}
+	for issuer, rv := range r.validators {
+		stats.Issuers[issuer] = IssuerStats{Validated: rv.validated.Load(), Rejected: rv.rejected.Load()}
+	}
+	return stats
+}
+
+// Health reports JWKS freshness per issuer.
+func (r *ValidatorRegistry) Health() map[string]JWKSHealth {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	health := make(map[string]JWKSHealth, len(r.validators))
+	for issuer, rv := range r.validators {
+		health[issuer] = rv.Health()
+	}
+	return health
+}
+
+// Stop stops every registered validator's background refresh.
+func (r *ValidatorRegistry) Stop() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, rv := range r.validators {
+		rv.Stop()
+	}
+}
+
+// Close stops every registered validator, like Stop; it implements Runnable.
+func (r *ValidatorRegistry) Close() error {
+	r.Stop()
+	return nil
+}
+
+// Run runs every registered validator (see Validator.Run) until ctx is cancelled or they
+// are all closed. Validators registered after Run starts are not run by it, so give those
+// automatic refresh (no ManualRefresh).
+func (r *ValidatorRegistry) Run(ctx context.Context) error {
+	r.mu.RLock()
+	validators := make([]*Validator, 0, len(r.validators))
+	for _, rv := range r.validators {
+		validators = append(validators, rv.Validator)
+	}
+	r.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, v := range validators {
+		wg.Go(func() { _ = v.Run(ctx) })
+	}
+	wg.Wait()
+	return ctx.Err()
+}
@@ -0,0 +1,102 @@
+package authclient
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidatorRegistry(t *testing.T) {
+	newIssuer := func(issuer string) *Signer {
+		t.Helper()
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := NewSigner(key, "k1", SignerConfig{Issuer: issuer, Audience: []string{"orders"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signer
+	}
+	oldIssuer, newIssuerSigner := newIssuer("https://old.example.com"), newIssuer("https://new.example.com")
+	rogue := newIssuer("https://new.example.com") // claims a trusted issuer, signs with another key
+
+	reg := NewValidatorRegistry()
+	defer reg.Stop()
+	for _, s := range []*Signer{oldIssuer, newIssuerSigner} {
+		jwks := httptest.NewServer(s.JWKSHandler())
+		defer jwks.Close()
+		if _, err := reg.Add(DefaultConfig(jwks.URL, s.config.Issuer, "orders")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sign := func(s *Signer) string {
+		tok, err := s.Sign(Claims{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tok
+	}
+	untrusted, err := newIssuer("https://evil.example.com").Sign(Claims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  ErrorKind
+	}{
+		{"old issuer", sign(oldIssuer), ""},
+		{"new issuer", sign(newIssuerSigner), ""},
+		{"new issuer again", sign(newIssuerSigner), ""},
+		{"forged for trusted issuer", sign(rogue), KindSignatureInvalid},
+		{"untrusted issuer", untrusted, KindClaimsInvalid},
+		{"malformed", "not-a-token", KindTokenMalformed},
+	}
+	for _, tt := range tests {
+		claims, err := reg.ValidateToken(tt.token)
+		if KindOf(err) != tt.want {
+			t.Errorf("%s: err = %v, want kind %q", tt.name, err, tt.want)
+		}
+		if err == nil && claims.Issuer == "" {
+			t.Errorf("%s: claims without issuer", tt.name)
+		}
+	}
+
+	stats := reg.Stats()
+	if got := stats.Issuers["https://old.example.com"]; got != (IssuerStats{Validated: 1}) {
+		t.Errorf("old issuer stats = %+v", got)
+	}
+	if got := stats.Issuers["https://new.example.com"]; got != (IssuerStats{Validated: 2, Rejected: 1}) {
+		t.Errorf("new issuer stats = %+v", got)
+	}
+	if stats.UnknownIssuer != 1 || stats.Malformed != 1 {
+		t.Errorf("routing stats = %+v", stats)
+	}
+
+	// The middleware routes through the registry; once the old issuer is removed its tokens fail.
+	mw := NewAuthMiddleware(nil, WithValidatorRegistry(reg))
+	h := mw.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	oldToken := sign(oldIssuer)
+	if code := serve(oldToken); code != http.StatusOK {
+		t.Fatalf("old issuer via middleware: status %d", code)
+	}
+	if !reg.Remove("https://old.example.com") {
+		t.Fatal("Remove reported old issuer missing")
+	}
+	if code := serve(oldToken); code != http.StatusUnauthorized {
+		t.Fatalf("removed issuer: status %d, want 401", code)
+	}
+}
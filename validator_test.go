@@ -0,0 +1,188 @@
+package authclient
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// newBenchValidator returns a validator and a typical auth-service access token it accepts.
+func newBenchValidator(tb testing.TB) (*Validator, string) {
+	tb.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	signer, err := NewSigner(key, "k1", SignerConfig{Issuer: "https://auth.example.com", Audience: []string{"orders"}})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	jwks := httptest.NewServer(signer.JWKSHandler())
+	tb.Cleanup(jwks.Close)
+	v, err := NewValidator(DefaultConfig(jwks.URL, "https://auth.example.com", "orders"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(v.Stop)
+
+	claims := Claims{
+		SessionID:            "2f1c0a9e-7a53-4a63-8d0c-1b1f0c4d5e6f",
+		TenantID:             "5b8e2d7c-0f4a-4c1e-9a3b-6d2f8e1c0a7b",
+		TenantSlug:           "acme",
+		Email:                "ada@example.com",
+		Scope:                ScopeList{"orders:read", "orders:write"},
+		Roles:                []string{"manager"},
+		Permissions:          []string{"orders.view", "orders.create", "orders.refund"},
+		SubscriptionPlan:     "GROWTH",
+		SubscriptionFeatures: []string{"orders", "inventory", "reports"},
+		SubscriptionStatus:   "ACTIVE",
+		SubscriptionTier:     2,
+	}
+	claims.Subject = "7d3e9f1a-2b4c-4d5e-8f6a-0b1c2d3e4f5a"
+	token, err := signer.Sign(claims)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return v, token
+}
+
+func BenchmarkValidateToken(b *testing.B) {
+	v, token := newBenchValidator(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := v.ValidateToken(token); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// validateTokenAllocBudget caps allocations per ValidateToken of a typical access token: the
+// claims themselves plus RSA verification (about 10, inside crypto/rsa). Raise it only with
+// a benchmark showing why.
+const validateTokenAllocBudget = 45
+
+func TestValidateTokenAllocBudget(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector changes allocation counts")
+	}
+	v, token := newBenchValidator(t)
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := v.ValidateToken(token); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > validateTokenAllocBudget {
+		t.Errorf("ValidateToken allocates %.0f times per call, budget %d", allocs, validateTokenAllocBudget)
+	}
+
+	// Rejections must not be more expensive than acceptance: bad signatures are cheap to
+	// mint and arrive in floods.
+	forged := token[:len(token)-4] + "AAAA"
+	allocs = testing.AllocsPerRun(100, func() {
+		if _, err := v.ValidateToken(forged); KindOf(err) != KindSignatureInvalid {
+			t.Fatalf("forged token: %v", err)
+		}
+	})
+	if allocs > validateTokenAllocBudget {
+		t.Errorf("rejecting a forged token allocates %.0f times, budget %d", allocs, validateTokenAllocBudget)
+	}
+}
+
+func TestClaimsExtraDecoding(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   string
+		wantExtra []string
+	}{
+		{"modelled only", `{"sub":"u1","scope":"a b","roles":["x"],"sub_limits":{"seats":5},"aud":["orders"]}`, nil},
+		{"extra after nested value", `{"sub_limits":{"a":1,"b}":2},"org_id":"o1"}`, []string{"org_id"}},
+		{"nested extra", `{"features":{"a":[1,{"y":"}"}]},"sub":"u1"}`, []string{"features"}},
+		{"escaped key", `{"s\u0069d":"s1"}`, nil}, // decodes into SessionID
+		{"extra with escapes", `{"email":"a\"b@example.com","flags":{"beta":true}}`, []string{"flags"}},
+		{"null scope", `{"scope":null,"tier":1}`, []string{"tier"}},
+	}
+	for _, tt := range tests {
+		var c Claims
+		if err := json.Unmarshal([]byte(tt.payload), &c); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var got []string
+		for k := range c.Extra {
+			got = append(got, k)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.wantExtra) {
+			t.Errorf("%s: Extra keys = %v, want %v", tt.name, got, tt.wantExtra)
+		}
+	}
+}
+
+func TestClaimsExtraAccessors(t *testing.T) {
+	var c Claims
+	payload := `{"sub":"u1","org_id":"o1","tier":2,"beta":true,"mode":"on","billing":{"plan":"pro","seats":5}}`
+	if err := json.Unmarshal([]byte(payload), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	if !c.HasClaim("org_id") || c.HasClaim("missing") || c.HasClaim("sub") {
+		t.Fatal("HasClaim should report only present, unmodelled claims")
+	}
+	if s, ok := c.GetString("org_id"); !ok || s != "o1" {
+		t.Fatalf(`GetString("org_id") = %q, %v; want o1, true`, s, ok)
+	}
+	if b, ok := c.GetBool("beta"); !ok || !b {
+		t.Fatalf(`GetBool("beta") = %v, %v; want true, true`, b, ok)
+	}
+	for _, key := range []string{"missing", "tier"} {
+		if s, ok := c.GetString(key); ok || s != "" {
+			t.Errorf("GetString(%q) = %q, %v; want \"\", false", key, s, ok)
+		}
+	}
+	for _, key := range []string{"missing", "mode"} {
+		if b, ok := c.GetBool(key); ok || b {
+			t.Errorf("GetBool(%q) = %v, %v; want false, false", key, b, ok)
+		}
+	}
+
+	var billing struct {
+		Plan  string `json:"plan"`
+		Seats int    `json:"seats"`
+	}
+	if err := c.Decode("billing", &billing); err != nil || billing.Plan != "pro" || billing.Seats != 5 {
+		t.Fatalf("Decode(billing) = %+v, %v; want pro with 5 seats", billing, err)
+	}
+	if err := c.Decode("missing", &billing); err == nil {
+		t.Fatal("Decode of a missing claim should fail")
+	}
+	if err := c.Decode("mode", &billing); err == nil {
+		t.Fatal("Decode of a string into a struct should fail")
+	}
+}
+
+func TestClaimsMarshalKeepsExtra(t *testing.T) {
+	var c Claims
+	payload := `{"sub":"u1","org_id":"o1","billing":{"plan":"pro","seats":5},"flags":[1,"two",null]}`
+	if err := json.Unmarshal([]byte(payload), &c); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]json.RawMessage
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	for k, want := range c.Extra {
+		if got := out[k]; string(got) != string(want) {
+			t.Errorf("%s = %s, want %s unchanged", k, got, want)
+		}
+	}
+	var again Claims
+	if err := json.Unmarshal(data, &again); err != nil || again.Subject != "u1" || len(again.Extra) != len(c.Extra) {
+		t.Fatalf("round trip = %+v, %v; want subject u1 and %d extra claims", again, err, len(c.Extra))
+	}
+}
@@ -0,0 +1,108 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// WebAuthnCeremony is the first half of a WebAuthn registration or login. Options is the
+// JSON form of PublicKeyCredentialCreationOptions (registration) or
+// PublicKeyCredentialRequestOptions (login), with binary fields base64url-encoded. Send it
+// to the browser unchanged and decode it there with
+// PublicKeyCredential.parseCreationOptionsFromJSON or parseRequestOptionsFromJSON; the
+// browser's credential.toJSON() is what the Finish call expects back.
+type WebAuthnCeremony struct {
+	SessionID string          `json:"session_id"` // keep server-side and pass to the Finish call
+	Options   json.RawMessage `json:"options"`
+	ExpiresIn int             `json:"expires_in"` // seconds the browser has to answer
+}
+
+// WebAuthnCredential is a passkey or security key registered to a user.
+type WebAuthnCredential struct {
+	ID         string    `json:"id"` // base64url credential ID
+	Name       string    `json:"name,omitempty"`
+	Transports []string  `json:"transports,omitempty"` // e.g. "internal", "usb", "hybrid"
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebAuthnLoginRequest starts a WebAuthn login.
+type WebAuthnLoginRequest struct {
+	// Email narrows the ceremony to the user's registered credentials. Leave it empty for
+	// passkey autofill, where the browser offers every discoverable credential it holds.
+	Email      string `json:"email,omitempty"`
+	TenantSlug string `json:"tenant_slug"`
+}
+
+// BeginWebAuthnRegistration starts registering a passkey or security key for the user
+// owning accessToken. name labels the credential in the user's device list.
+func (c *Client) BeginWebAuthnRegistration(ctx context.Context, name, accessToken string) (*WebAuthnCeremony, error) {
+	if accessToken == "" {
+		return nil, newAuthError(KindTokenMissing, "auth-service: access token required to begin webauthn registration", nil)
+	}
+	req := struct {
+		Name string `json:"name,omitempty"`
+	}{name}
+	var ceremony WebAuthnCeremony
+	if err := c.postWithToken(ctx, "/api/v1/auth/webauthn/register/begin", "begin webauthn registration", req, accessToken, &ceremony); err != nil {
+		return nil, err
+	}
+	return &ceremony, nil
+}
+
+// FinishWebAuthnRegistration completes BeginWebAuthnRegistration with the browser's
+// attestation (credential.toJSON() of navigator.credentials.create). An attestation
+// auth-service cannot verify fails with KindInvalidCredentials.
+func (c *Client) FinishWebAuthnRegistration(ctx context.Context, sessionID string, credential json.RawMessage, accessToken string) (*WebAuthnCredential, error) {
+	if sessionID == "" || len(credential) == 0 {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: session ID and credential required to finish webauthn registration", nil)
+	}
+	if accessToken == "" {
+		return nil, newAuthError(KindTokenMissing, "auth-service: access token required to finish webauthn registration", nil)
+	}
+	req := webAuthnFinishRequest{SessionID: sessionID, Credential: credential}
+	var registered WebAuthnCredential
+	if err := c.postWithToken(ctx, "/api/v1/auth/webauthn/register/finish", "finish webauthn registration", req, accessToken, &registered); err != nil {
+		return nil, ceremonyError(err)
+	}
+	c.logger.Info("auth-service: webauthn credential registered", "credential_id", registered.ID)
+	return &registered, nil
+}
+
+// BeginWebAuthnLogin starts signing in with a passkey or security key.
+func (c *Client) BeginWebAuthnLogin(ctx context.Context, req WebAuthnLoginRequest) (*WebAuthnCeremony, error) {
+	var ceremony WebAuthnCeremony
+	if err := c.postJSON(ctx, "/api/v1/auth/webauthn/login/begin", "begin webauthn login", req, &ceremony); err != nil {
+		return nil, err
+	}
+	return &ceremony, nil
+}
+
+// FinishWebAuthnLogin completes BeginWebAuthnLogin with the browser's assertion
+// (credential.toJSON() of navigator.credentials.get), issuing a session as Login does. A
+// WebAuthn login satisfies MFA on its own, so it never fails with KindMFARequired.
+func (c *Client) FinishWebAuthnLogin(ctx context.Context, sessionID string, assertion json.RawMessage) (*AuthResponse, error) {
+	if sessionID == "" || len(assertion) == 0 {
+		return nil, newAuthError(KindInvalidRequest, "auth-service: session ID and assertion required to finish webauthn login", nil)
+	}
+	req := webAuthnFinishRequest{SessionID: sessionID, Credential: assertion}
+	var authResp AuthResponse
+	if err := c.postJSON(ctx, "/api/v1/auth/webauthn/login/finish", "finish webauthn login", req, &authResp); err != nil {
+		return nil, ceremonyError(err)
+	}
+	return &authResp, nil
+}
+
+type webAuthnFinishRequest struct {
+	SessionID  string          `json:"session_id"`
+	Credential json.RawMessage `json:"credential"`
+}
+
+// ceremonyError reports an unknown or lapsed ceremony session, which auth-service answers
+// with 404, as KindInvalidRequest: the caller must begin again, not look for a resource.
+func ceremonyError(err error) error {
+	if ae, ok := err.(*AuthError); ok && ae.Kind == KindNotFound {
+		ae.Kind = KindInvalidRequest
+	}
+	return err
+}
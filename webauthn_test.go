@@ -0,0 +1,71 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebAuthnCeremonies(t *testing.T) {
+	const options = `{"publicKey":{"challenge":"Y2hhbGxlbmdl","rpId":"acme.test","allowCredentials":[]}}`
+	const assertion = `{"id":"Y3JlZA","type":"public-key","response":{"signature":"c2ln"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			SessionID  string          `json:"session_id"`
+			Credential json.RawMessage `json:"credential"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path {
+		case "/api/v1/auth/webauthn/register/begin":
+			if r.Header.Get("Authorization") != "Bearer at" {
+				http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"session_id":"reg-1","options":` + options + `,"expires_in":120}`))
+		case "/api/v1/auth/webauthn/register/finish":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"Y3JlZA","name":"Laptop","created_at":"2026-10-16T09:00:00Z"}`))
+		case "/api/v1/auth/webauthn/login/begin":
+			_, _ = w.Write([]byte(`{"session_id":"login-1","options":` + options + `}`))
+		case "/api/v1/auth/webauthn/login/finish":
+			if req.SessionID != "login-1" {
+				http.Error(w, `{"error":"ceremony not found"}`, http.StatusNotFound)
+				return
+			}
+			if string(req.Credential) != assertion {
+				http.Error(w, `{"error":"assertion rejected"}`, http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"at2","refresh_token":"rt2"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	ctx := context.Background()
+	reg, err := c.BeginWebAuthnRegistration(ctx, "Laptop", "at")
+	if err != nil || reg.SessionID != "reg-1" || string(reg.Options) != options {
+		t.Fatalf("BeginWebAuthnRegistration = %+v, %v", reg, err)
+	}
+	cred, err := c.FinishWebAuthnRegistration(ctx, reg.SessionID, json.RawMessage(`{"id":"Y3JlZA"}`), "at")
+	if err != nil || cred.ID != "Y3JlZA" || cred.CreatedAt.IsZero() {
+		t.Fatalf("FinishWebAuthnRegistration = %+v, %v", cred, err)
+	}
+
+	login, err := c.BeginWebAuthnLogin(ctx, WebAuthnLoginRequest{TenantSlug: "acme"})
+	if err != nil || string(login.Options) != options {
+		t.Fatalf("BeginWebAuthnLogin = %+v, %v", login, err)
+	}
+	resp, err := c.FinishWebAuthnLogin(ctx, login.SessionID, json.RawMessage(assertion))
+	if err != nil || resp.AccessToken != "at2" {
+		t.Fatalf("FinishWebAuthnLogin = %+v, %v", resp, err)
+	}
+	if _, err := c.FinishWebAuthnLogin(ctx, "login-0", json.RawMessage(assertion)); KindOf(err) != KindInvalidRequest {
+		t.Fatalf("lapsed ceremony: kind %s", KindOf(err))
+	}
+	if _, err := c.FinishWebAuthnLogin(ctx, login.SessionID, json.RawMessage(`{}`)); KindOf(err) != KindInvalidCredentials {
+		t.Fatalf("rejected assertion: kind %s", KindOf(err))
+	}
+}
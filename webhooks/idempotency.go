@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultIdempotencyTTL is how long delivered event IDs are remembered. auth-service retries
+// failed deliveries for up to a day, re-signing each attempt with a fresh timestamp, so the
+// timestamp tolerance alone does not stop duplicates.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyStore records the IDs of events being or already handled so duplicate and
+// replayed deliveries are acknowledged without running handlers again. Share a store
+// (e.g. RedisIdempotencyStore) between replicas so a duplicate routed to another replica is
+// also caught.
+type IdempotencyStore interface {
+	// Claim records eventID for ttl. It returns false if eventID is already recorded.
+	Claim(ctx context.Context, eventID string, ttl time.Duration) (bool, error)
+	// Release forgets eventID, so a delivery whose handler failed is processed when
+	// auth-service retries it.
+	Release(ctx context.Context, eventID string) error
+}
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore, suitable for a single replica.
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	seen      map[string]time.Time // event ID -> forget after
+	lastPrune time.Time
+}
+
+// NewMemoryIdempotencyStore creates an empty in-memory store.
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{seen: make(map[string]time.Time)}
+}
+
+// Claim implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Claim(ctx context.Context, eventID string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastPrune) > time.Minute {
+		for id, until := range s.seen {
+			if now.After(until) {
+				delete(s.seen, id)
+			}
+		}
+		s.lastPrune = now
+	}
+	if until, ok := s.seen[eventID]; ok && now.Before(until) {
+		return false, nil
+	}
+	s.seen[eventID] = now.Add(ttl)
+	return true, nil
+}
+
+// Release implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Release(ctx context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.seen, eventID)
+	return nil
+}
+
+// RedisIdempotencyStore shares delivered event IDs between replicas through Redis.
+type RedisIdempotencyStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisIdempotencyStore creates a store using keys "<prefix><event ID>" (prefix defaults
+// to "webhook-events:").
+func NewRedisIdempotencyStore(client *redis.Client, prefix string) *RedisIdempotencyStore {
+	if prefix == "" {
+		prefix = "webhook-events:"
+	}
+	return &RedisIdempotencyStore{client: client, prefix: prefix}
+}
+
+// Claim implements IdempotencyStore.
+func (s *RedisIdempotencyStore) Claim(ctx context.Context, eventID string, ttl time.Duration) (bool, error) {
+	ok, err := s.client.SetNX(ctx, s.prefix+eventID, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis idempotency store: %w", err)
+	}
+	return ok, nil
+}
+
+// Release implements IdempotencyStore.
+func (s *RedisIdempotencyStore) Release(ctx context.Context, eventID string) error {
+	if err := s.client.Del(ctx, s.prefix+eventID).Err(); err != nil {
+		return fmt.Errorf("redis idempotency store: %w", err)
+	}
+	return nil
+}
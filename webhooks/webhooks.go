@@ -0,0 +1,219 @@
+// Package webhooks receives auth-service webhooks: it verifies the HMAC signature and
+// timestamp of each delivery, decodes the event and dispatches it to registered handlers.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Bengo-Hub/shared-auth-client/events"
+)
+
+const (
+	// SignatureHeader carries hex(HMAC-SHA256(secret, timestamp + "." + body)), optionally
+	// prefixed with "sha256=".
+	SignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader carries the delivery time in Unix seconds.
+	TimestampHeader = "X-Webhook-Timestamp"
+
+	// DefaultTolerance is the maximum clock difference accepted for TimestampHeader.
+	DefaultTolerance = 5 * time.Minute
+
+	maxBodyBytes = 1 << 20
+)
+
+// Handler is an http.Handler for auth-service webhook deliveries. Handlers are registered on
+// its embedded events.Registry (OnUserCreated, Handle, ...), which can be shared with other
+// transports through WithRegistry.
+type Handler struct {
+	*events.Registry
+
+	secrets        [][]byte
+	tolerance      time.Duration
+	idempotency    IdempotencyStore
+	idempotencyTTL time.Duration
+	now            func() time.Time
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithTolerance sets how far TimestampHeader may be from the local clock (default
+// DefaultTolerance). Deliveries outside the window are rejected as replays.
+func WithTolerance(d time.Duration) Option {
+	return func(h *Handler) {
+		if d > 0 {
+			h.tolerance = d
+		}
+	}
+}
+
+// WithAdditionalSecret also accepts signatures made with secret, for rotating the webhook
+// secret without dropping deliveries.
+func WithAdditionalSecret(secret []byte) Option {
+	return func(h *Handler) {
+		h.secrets = append(h.secrets, secret)
+	}
+}
+
+// WithIdempotencyStore records handled event IDs in store instead of process memory, e.g. a
+// RedisIdempotencyStore shared by all replicas. A nil store disables duplicate detection.
+func WithIdempotencyStore(store IdempotencyStore) Option {
+	return func(h *Handler) {
+		h.idempotency = store
+	}
+}
+
+// WithIdempotencyTTL sets how long handled event IDs are remembered (default
+// DefaultIdempotencyTTL). It should cover auth-service's retry window.
+func WithIdempotencyTTL(ttl time.Duration) Option {
+	return func(h *Handler) {
+		if ttl > 0 {
+			h.idempotencyTTL = ttl
+		}
+	}
+}
+
+// WithRegistry dispatches deliveries through registry instead of a new, empty one.
+func WithRegistry(registry *events.Registry) Option {
+	return func(h *Handler) {
+		if registry != nil {
+			h.Registry = registry
+		}
+	}
+}
+
+// NewHandler creates a webhook receiver verifying deliveries with secret. It fails when secret
+// or a WithAdditionalSecret secret is empty, e.g. an unset environment variable, since an
+// empty HMAC key would let anyone sign deliveries.
+func NewHandler(secret []byte, opts ...Option) (*Handler, error) {
+	h := &Handler{
+		Registry:       events.NewRegistry(),
+		secrets:        [][]byte{secret},
+		tolerance:      DefaultTolerance,
+		idempotency:    NewMemoryIdempotencyStore(),
+		idempotencyTTL: DefaultIdempotencyTTL,
+		now:            time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	for i, secret := range h.secrets {
+		if len(secret) == 0 {
+			if i == 0 {
+				return nil, errors.New("webhooks: empty secret")
+			}
+			return nil, errors.New("webhooks: empty additional secret")
+		}
+	}
+	return h, nil
+}
+
+// ServeHTTP verifies and dispatches one delivery. Events without a registered handler and
+// duplicates of events already handled are acknowledged with 204 so auth-service does not
+// retry them.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil || len(body) > maxBodyBytes {
+		http.Error(w, "unreadable body", http.StatusBadRequest)
+		return
+	}
+	if err := h.verify(r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var event events.Event
+	if err := json.Unmarshal(body, &event); err != nil || event.Type == "" {
+		http.Error(w, "malformed event", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	dedupe := h.idempotency != nil && event.ID != ""
+	if dedupe {
+		first, err := h.idempotency.Claim(ctx, event.ID, h.idempotencyTTL)
+		if err != nil {
+			// Without the store we cannot tell a duplicate apart; let auth-service retry.
+			http.Error(w, "idempotency store unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if !first {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	if err := h.Dispatch(ctx, event); err != nil {
+		if dedupe {
+			_ = h.idempotency.Release(context.WithoutCancel(ctx), event.ID)
+		}
+		http.Error(w, "handler failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) verify(header http.Header, body []byte) error {
+	return verify(header, body, h.secrets, h.tolerance, h.now())
+}
+
+// Verify checks the SignatureHeader and TimestampHeader of a request signed with secret the
+// way auth-service signs webhook deliveries, for receivers of other auth-service posts that
+// do not dispatch events through a Handler. Timestamps more than tolerance from the local
+// clock are rejected as replays; tolerance <= 0 means DefaultTolerance.
+func Verify(secret []byte, header http.Header, body []byte, tolerance time.Duration) error {
+	if len(secret) == 0 {
+		return errors.New("empty secret")
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	return verify(header, body, [][]byte{secret}, tolerance, time.Now())
+}
+
+func verify(header http.Header, body []byte, secrets [][]byte, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		return errors.New("missing or invalid timestamp")
+	}
+	if skew := now.Sub(time.Unix(ts, 0)); skew > tolerance || skew < -tolerance {
+		return errors.New("timestamp outside tolerance")
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(header.Get(SignatureHeader), "sha256="))
+	if err != nil || len(got) == 0 {
+		return errors.New("missing or invalid signature")
+	}
+	for _, secret := range secrets {
+		if hmac.Equal(got, mac(secret, ts, body)) {
+			return nil
+		}
+	}
+	return errors.New("signature mismatch")
+}
+
+// Sign returns the SignatureHeader value for body sent at timestamp, for senders and tests.
+func Sign(secret []byte, timestamp time.Time, body []byte) string {
+	return "sha256=" + hex.EncodeToString(mac(secret, timestamp.Unix(), body))
+}
+
+func mac(secret []byte, ts int64, body []byte) []byte {
+	m := hmac.New(sha256.New, secret)
+	m.Write([]byte(strconv.FormatInt(ts, 10)))
+	m.Write([]byte("."))
+	m.Write(body)
+	return m.Sum(nil)
+}
@@ -0,0 +1,110 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bengo-Hub/shared-auth-client/events"
+)
+
+func deliver(h http.Handler, secret []byte, at time.Time, body string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/auth", strings.NewReader(body))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(at.Unix(), 10))
+	req.Header.Set(SignatureHeader, Sign(secret, at, []byte(body)))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestHandlerVerifiesAndDispatches(t *testing.T) {
+	secret := []byte("whsec")
+	h, err := NewHandler(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got events.UserCreated
+	h.OnUserCreated(func(ctx context.Context, e events.UserCreatedEvent) error {
+		got = e.Data
+		return nil
+	})
+	body := `{"id":"evt_1","type":"user.created","data":{"user_id":"u1","email":"ada@example.com","tenant_id":"t1"}}`
+
+	tests := []struct {
+		name   string
+		secret []byte
+		at     time.Time
+		want   int
+	}{
+		{"valid", secret, time.Now(), http.StatusNoContent},
+		{"wrong secret", []byte("other"), time.Now(), http.StatusUnauthorized},
+		{"stale timestamp", secret, time.Now().Add(-time.Hour), http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := deliver(h, tt.secret, tt.at, body); code != tt.want {
+				t.Fatalf("status = %d, want %d", code, tt.want)
+			}
+		})
+	}
+	if got.UserID != "u1" || got.TenantID != "t1" {
+		t.Fatalf("payload = %+v", got)
+	}
+}
+
+func TestHandlerSkipsDuplicateDeliveries(t *testing.T) {
+	secret := []byte("whsec")
+	h, err := NewHandler(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, fail := 0, true
+	h.OnUserDeleted(func(ctx context.Context, e events.UserDeletedEvent) error {
+		calls++
+		if fail {
+			fail = false
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+	body := `{"id":"evt_2","type":"user.deleted","data":{"user_id":"u1"}}`
+
+	// A failed attempt is released, so the retry runs; later duplicates are acknowledged
+	// without running the handler again.
+	for i, want := range []int{http.StatusInternalServerError, http.StatusNoContent, http.StatusNoContent} {
+		if code := deliver(h, secret, time.Now(), body); code != want {
+			t.Fatalf("delivery %d: status = %d, want %d", i, code, want)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("handler calls = %d, want 2", calls)
+	}
+}
+
+func TestNewHandlerRejectsEmptySecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  []byte
+		opts    []Option
+		wantErr bool
+	}{
+		{"secret", []byte("whsec"), nil, false},
+		{"rotation", []byte("whsec"), []Option{WithAdditionalSecret([]byte("whsec-old"))}, false},
+		{"nil secret", nil, nil, true},
+		{"empty secret", []byte{}, nil, true},
+		{"empty additional secret", []byte("whsec"), []Option{WithAdditionalSecret(nil)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := NewHandler(tt.secret, tt.opts...)
+			if (err != nil) != tt.wantErr || (err == nil) != (h != nil) {
+				t.Fatalf("NewHandler() = %v, %v; want error %v", h, err, tt.wantErr)
+			}
+		})
+	}
+}